@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/importer"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage/postgres"
+)
+
+func main() {
+	var (
+		dsn        = os.Getenv("DSN")
+		zone       = os.Getenv("EVENTS_DEFAULT_TIME_ZONE")
+		driverName = "postgres"
+		formatFlag = flag.String("format", "", "file format: csv, json or ics (detected by extension if empty)")
+	)
+
+	flag.Parse()
+
+	log := setupLogger()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		log.Error("usage: importer [-format csv|json|ics] <file>")
+		os.Exit(1)
+	}
+	path := args[0]
+
+	// Определяем формат файла
+	var (
+		format importer.Format
+		err    error
+	)
+	if *formatFlag != "" {
+		format, err = importer.ParseFormat(*formatFlag)
+	} else {
+		format, err = importer.FormatFromFilename(path)
+	}
+	if err != nil {
+		log.Error("error detecting file format", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Часовой пояс для строк, в которых он не указан
+	if zone == "" {
+		zone = "Europe/Moscow"
+	}
+	defaultZone, err := time.LoadLocation(zone)
+	if err != nil {
+		log.Error("error loading default time zone", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Error("error opening file", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer func() { _ = file.Close() }()
+
+	db, err := postgres.NewStorage(log, driverName, dsn)
+	if err != nil {
+		log.Error("error connecting to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	report, err := service.NewImporter(log, db, defaultZone).Import(context.Background(), format, file)
+	if errors.Is(err, service.ErrInvalidImport) {
+		for _, rowErr := range report.Errors {
+			log.Error("invalid row", slog.Int("row", rowErr.Row), slog.String("error", rowErr.Message))
+		}
+	}
+	if err != nil {
+		log.Error("error importing events", slog.String("error", err.Error()))
+		db.Close()
+		os.Exit(1)
+	}
+
+	log.Info("import complete",
+		slog.String("file", path),
+		slog.Int("total", report.Total),
+		slog.Int("inserted", report.Inserted),
+		slog.Int("updated", report.Updated),
+	)
+}
+
+// setupLogger инициализирует логгер с JSON-обработчиком
+func setupLogger() *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return logger
+}
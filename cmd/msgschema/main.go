@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/message"
+)
+
+// Проверяет, что JSON Schema данных исходящих сообщений совпадают с сохранёнными в репозитории.
+// Изменение полей данных без увеличения версии сообщения завершается ошибкой. С флагом -write
+// записывает схемы новых версий; схема уже выпущенной версии не перезаписывается
+func main() {
+	var (
+		dir   = flag.String("dir", "internal/message/schemas", "directory with JSON schemas")
+		write = flag.Bool("write", false, "write schemas of new message versions")
+	)
+
+	flag.Parse()
+
+	log := setupLogger()
+
+	failed := false
+	for _, k := range message.Kinds() {
+		schema, err := k.Schema()
+		if err != nil {
+			log.Error("error generating schema", slog.String("type", k.Type()), slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		path := filepath.Join(*dir, k.SchemaFile())
+		saved, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && *write:
+			if err = os.WriteFile(path, schema, 0o644); err != nil {
+				log.Error("error writing schema", slog.String("path", path), slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+			log.Info("schema written", slog.String("type", k.Type()), slog.String("path", path))
+		case errors.Is(err, fs.ErrNotExist):
+			log.Error("schema is missing, run with -write", slog.String("type", k.Type()), slog.String("path", path))
+			failed = true
+		case err != nil:
+			log.Error("error reading schema", slog.String("path", path), slog.String("error", err.Error()))
+			os.Exit(1)
+		case !bytes.Equal(saved, schema):
+			log.Error("message payload changed without a version bump, increase the version in message.kinds",
+				slog.String("type", k.Type()), slog.String("path", path))
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	log.Info("message schemas are up to date")
+}
+
+// setupLogger инициализирует логгер с JSON-обработчиком
+func setupLogger() *slog.Logger {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return logger
+}
@@ -5,19 +5,33 @@ import (
 	"os"
 
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/app/grpc"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/app/http"
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/config"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/deeplink"
+	eventgrpc "github.com/Telegram-bot-for-register-on-events/event-service/internal/grpc/event"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/admin"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/events"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/health"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/jobs"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/message"
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/nats"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/payment"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ratelimit"
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage/postgres"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ticket"
 )
 
 // App описывает микросервис целиком
 type App struct {
 	log        *slog.Logger
 	GRPCServer *grpcserver.App
+	HTTPServer *httpserver.App
 	cfg        *config.Config
 	Nats       *nats.Nats
 	Database   *postgres.Storage
+	// Jobs фоновые задачи
+	Jobs []*jobs.Job
 }
 
 // NewApp конструктор для App
@@ -26,25 +40,122 @@ func NewApp(log *slog.Logger) *App {
 	cfg := cfgInit(log)
 	// Инициализируем хранилище данных
 	db := dbInit(log, cfg.GetDatabaseDriverName(), cfg.GetDatabasePath())
-	// Инициализируем сервисный слой
-	s := service.NewService(log, db, db)
 	// Подключаемся к Nats
-	n := natsConn(log, cfg.GetNatsURL())
-	// Создаём поток и топик
-	_, err := n.CreateStream(cfg.GetNatsStream(), []string{cfg.GetNatsTopic()})
+	n := natsConn(log, cfg)
+	// Создаём поток и топики
+	subjects := []string{
+		cfg.GetNatsTopic(), cfg.GetNatsPaymentTopic(), cfg.GetNatsCheckInTopic(),
+		cfg.GetNatsDecisionTopic(), cfg.GetNatsRegistrationOpenedTopic(),
+	}
+	_, err := n.CreateStream(nats.StreamConfig{
+		Name:            cfg.GetNatsStream(),
+		Subjects:        subjects,
+		Retention:       cfg.GetNatsStreamRetention(),
+		Storage:         cfg.GetNatsStreamStorage(),
+		MaxAge:          cfg.GetNatsStreamMaxAge(),
+		MaxBytes:        cfg.GetNatsStreamMaxBytes(),
+		Replicas:        cfg.GetNatsStreamReplicas(),
+		DuplicateWindow: cfg.GetNatsStreamDuplicateWindow(),
+	})
 	if err != nil {
 		log.Error("error", err.Error(), slog.String("failed", "create stream in NATS"))
 		os.Exit(1)
 	}
-	// Создаём gRPC-сервер
-	grpcApp := grpcserver.New(log, cfg.GetGRPCServerPort(), s, n, s)
+	// Все исходящие сообщения публикуются в версионированном конверте в формате NATS_ENCODING
+	encoder, err := message.NewEncoder(cfg.GetNatsEncoding())
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("failed", "init message encoder"))
+		os.Exit(1)
+	}
+	publisher := message.NewPublisher(log, n, encoder, nil)
+	// Билеты подписываются одним ключом при регистрации, оплате и проверке на входе
+	signer := ticket.NewSigner(cfg.GetTicketSecret())
+	// Инициализируем оплату регистраций. Фейковый провайдер подключается, только если выбран явно,
+	// иначе его адрес приёма уведомлений позволил бы подтверждать платежи без оплаты
+	var providers []service.PaymentProvider
+	if cfg.GetPaymentProvider() == payment.FakeProviderName {
+		providers = append(providers, payment.NewFake(cfg.GetFakePaymentCheckoutURL(), cfg.GetPaymentWebhookSecret()))
+	}
+	payments, err := service.NewPayments(log, db, publisher, signer, cfg.GetNatsPaymentTopic(), cfg.GetPaymentProvider(),
+		providers...)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("failed", "init payments"))
+		os.Exit(1)
+	}
+	// Инициализируем сервисный слой
+	eventRateLimit, eventRateWindow := cfg.GetEventRateLimit()
+	options := service.RegistrationOptions{
+		PaymentTTL:      cfg.GetPaymentTTL(),
+		MaxNoShows:      cfg.GetMaxNoShows(),
+		NoShowPeriod:    cfg.GetNoShowBlockPeriod(),
+		EventRateLimit:  eventRateLimit,
+		EventRateWindow: eventRateWindow,
+	}
+	s := service.NewService(log, db, db, payments, signer, options, service.Localization{
+		DefaultLanguage:  cfg.GetDefaultLanguage(),
+		FallbackLanguage: cfg.GetFallbackLanguage(),
+	})
+	// Ссылки на бота, открывающие событие
+	links := service.NewDeepLinks(log, db, deeplink.NewSigner(cfg.GetLinkSecret()), cfg.GetTelegramBotUsername())
+	// Создаём gRPC-сервер. Повторы регистраций с ключом идемпотентности получают сохранённый ответ
+	// до проверки частоты запросов по пользователю и по клиенту gRPC
+	idempotency := service.NewIdempotency(log, db, cfg.GetIdempotencyTTL(), nil)
+	chatRequests, chatPeriod := cfg.GetChatRateLimit()
+	callerRequests, callerPeriod := cfg.GetCallerRateLimit()
+	grpcApp := grpcserver.New(log, cfg.GetGRPCServerPort(), s, publisher, s, links,
+		eventgrpc.IdempotencyInterceptor(idempotency),
+		eventgrpc.RateLimitInterceptor(ratelimit.New(chatRequests, chatPeriod), ratelimit.New(callerRequests, callerPeriod)))
+	// Создаём HTTP-сервер с административным API и календарями
+	calendar := service.NewCalendar(log, db)
+	venues := service.NewVenues(log, db)
+	taxonomy := service.NewTaxonomy(log, db)
+	tickets := service.NewTickets(log, db, signer, publisher, cfg.GetNatsCheckInTopic())
+	attendance := service.NewAttendance(log, db, cfg.GetAttendanceEventDuration())
+	windows := service.NewRegistrationWindows(log, db, publisher, cfg.GetNatsRegistrationOpenedTopic(), nil)
+	series := service.NewSeries(log, db, signer, publisher, cfg.GetNatsTopic(), options, cfg.GetDefaultTimeZone(), cfg.GetSeriesHorizon())
+	adminServices := admin.Services{
+		Importer:     service.NewImporter(log, db, cfg.GetDefaultTimeZone()),
+		Feeds:        calendar,
+		Venues:       venues,
+		Taxonomy:     taxonomy,
+		Series:       series,
+		Sessions:     service.NewSessions(log, db),
+		TicketTypes:  service.NewTicketTypes(log, db),
+		CheckIns:     tickets,
+		Attendance:   attendance,
+		Guests:       service.NewGuests(log, db),
+		Questions:    service.NewQuestions(log, db),
+		Attendees:    service.NewAttendees(log, db),
+		Approvals:    service.NewApprovals(log, db, payments, signer, publisher, cfg.GetNatsDecisionTopic(), cfg.GetPaymentTTL()),
+		Windows:      windows,
+		Invites:      service.NewInvites(log, db, cfg.GetTelegramBotUsername()),
+		DeepLinks:    links,
+		Users:        service.NewUsers(log, db),
+		Translations: service.NewTranslations(log, db, cfg.GetDefaultLanguage()),
+		Blocklist:    service.NewBlocklist(log, db, nil),
+	}
+	eventServices := events.Services{
+		Events:     s,
+		Nearby:     venues,
+		Categories: taxonomy,
+	}
+	httpApp := httpserver.New(log, cfg.GetHTTPServerPort(), cfg.GetAdminToken(), adminServices, eventServices, calendar, payments, tickets,
+		message.Schemas, map[string]health.Checker{"nats": n})
 
 	return &App{
 		log:        log,
 		GRPCServer: grpcApp,
+		HTTPServer: httpApp,
 		cfg:        cfg,
 		Nats:       n,
 		Database:   db,
+		Jobs: []*jobs.Job{
+			jobs.New(log, "series.materialize", cfg.GetSeriesMaterializeInterval(), series.Materialize),
+			jobs.New(log, "payments.expire", cfg.GetPaymentExpireInterval(), payments.ExpirePending),
+			jobs.New(log, "attendance.no_shows", cfg.GetAttendanceInterval(), attendance.MarkNoShows),
+			jobs.New(log, "registration.announce_opened", cfg.GetRegistrationOpenInterval(), windows.AnnounceOpened),
+			jobs.New(log, "idempotency.cleanup", cfg.GetIdempotencyCleanupInterval(), idempotency.DeleteExpired),
+		},
 	}
 }
 
@@ -52,13 +163,21 @@ func NewApp(log *slog.Logger) *App {
 func (a *App) MustStart() {
 	a.log.Info("application successfully started")
 	go a.GRPCServer.MustRun()
+	go a.HTTPServer.MustRun()
+	for _, job := range a.Jobs {
+		job.Start()
+	}
 }
 
 // Stop выполняет остановку всего микросервиса
 func (a *App) Stop() {
 	a.log.Info("shutting down...")
 	a.GRPCServer.Stop()
-	a.Nats.Conn.Close()
+	a.HTTPServer.Stop()
+	for _, job := range a.Jobs {
+		job.Stop()
+	}
+	a.Nats.Close()
 	a.Database.Close()
 }
 
@@ -81,8 +200,18 @@ func dbInit(log *slog.Logger, driverName, dsn string) *postgres.Storage {
 }
 
 // natsConn обёртка для подключения к NATS
-func natsConn(log *slog.Logger, url string) *nats.Nats {
-	n, err := nats.NewNats(log, url)
+func natsConn(log *slog.Logger, cfg *config.Config) *nats.Nats {
+	reconnectWait, maxReconnects := cfg.GetNatsReconnect()
+	connectAttempts, connectBackoffMax := cfg.GetNatsConnectRetry()
+	bufferSize, retryInterval := cfg.GetNatsPublishBuffer()
+	n, err := nats.NewNats(log, cfg.GetNatsURL(), nats.Options{
+		ReconnectWait:     reconnectWait,
+		MaxReconnects:     maxReconnects,
+		ConnectAttempts:   connectAttempts,
+		ConnectBackoffMax: connectBackoffMax,
+		BufferSize:        bufferSize,
+		RetryInterval:     retryInterval,
+	})
 	if err != nil {
 		log.Error("error", err.Error(), slog.String("failed", "connect to nats"))
 		os.Exit(1)
@@ -22,11 +22,11 @@ type App struct {
 	port       string
 }
 
-// New создаёт новый gRPC-сервер
-func New(log *slog.Logger, port string, events eventgrpc.EventService, publisher eventgrpc.Publisher, registerer eventgrpc.Registerer) *App {
-	grpcServer := grpc.NewServer()
+// New создаёт новый gRPC-сервер. interceptors выполняются перед обработчиком в переданном порядке
+func New(log *slog.Logger, port string, events eventgrpc.EventService, publisher eventgrpc.Publisher, registerer eventgrpc.Registerer, links eventgrpc.DeepLinkResolver, interceptors ...grpc.UnaryServerInterceptor) *App {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
 	// Подключаем обработчик
-	eventgrpc.Register(grpcServer, events, publisher, registerer)
+	eventgrpc.Register(grpcServer, events, publisher, registerer, links)
 	return &App{
 		log:        log,
 		gRPCServer: grpcServer,
@@ -0,0 +1,88 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/admin"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/calendar"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/events"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/health"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/payments"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/schemas"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/tickets"
+)
+
+// Константы для описания операций
+const (
+	opStart = "httpserver.Start"
+	opStop  = "httpserver.Stop"
+)
+
+// shutdownTimeout время на завершение активных запросов при остановке
+const shutdownTimeout = 5 * time.Second
+
+// App описывает HTTP-сервер
+type App struct {
+	log        *slog.Logger
+	httpServer *http.Server
+	port       string
+}
+
+// New создаёт новый HTTP-сервер
+func New(log *slog.Logger, port, adminToken string, adminServices admin.Services, eventServices events.Services, feeds calendar.FeedService,
+	webhooks payments.WebhookHandler, qrCodes tickets.QRCodeRenderer, messageSchemas fs.FS,
+	components map[string]health.Checker) *App {
+	mux := http.NewServeMux()
+	// Подключаем обработчики
+	admin.Register(mux, log, adminToken, adminServices)
+	events.Register(mux, log, eventServices)
+	calendar.Register(mux, log, feeds)
+	payments.Register(mux, log, webhooks)
+	tickets.Register(mux, log, qrCodes)
+	schemas.Register(mux, messageSchemas)
+	health.Register(mux, components)
+	return &App{
+		log: log,
+		httpServer: &http.Server{
+			Addr:              ":" + port,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		port: port,
+	}
+}
+
+// start запускает HTTP-сервер
+func (a *App) start() error {
+	a.log.Info("running HTTP-server...", slog.String("operation", opStart), slog.String("port", a.port))
+
+	if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.log.Info("error", err.Error(), slog.String("operation", opStart))
+		return fmt.Errorf("%s: %w", opStart, err)
+	}
+
+	return nil
+}
+
+// MustRun обёртка для метода start, при ошибке - паникует
+func (a *App) MustRun() {
+	if err := a.start(); err != nil {
+		panic(err)
+	}
+}
+
+// Stop останавливает HTTP-сервер
+func (a *App) Stop() {
+	a.log.Info("HTTP-server is stopping", slog.String("operation", opStop), slog.String("port", a.port))
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		a.log.Error("error", err.Error(), slog.String("operation", opStop))
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,11 +18,25 @@ const (
 	opNewServerConfig = "config.NewGRPCServerConfig"
 )
 
+// minLinkSecretLength минимальная длина ключа подписи токенов ссылок. Подпись в токене усечена до 8 байт,
+// поэтому стойкость ссылок целиком зависит от ключа
+const minLinkSecretLength = 32
+
 // Config описывает конфигурацию микросервиса
 type Config struct {
-	gRPCServerConfig *gRPCServerConfig
-	databaseConfig   *databaseConfig
-	natsConfig       *natsConfig
+	gRPCServerConfig  *gRPCServerConfig
+	databaseConfig    *databaseConfig
+	natsConfig        *natsConfig
+	httpServerConfig  *httpServerConfig
+	eventsConfig      *eventsConfig
+	paymentsConfig    *paymentsConfig
+	ticketsConfig     *ticketsConfig
+	attendanceConfig  *attendanceConfig
+	approvalConfig    *approvalConfig
+	windowConfig      *windowConfig
+	telegramConfig    *telegramConfig
+	rateLimitConfig   *rateLimitConfig
+	idempotencyConfig *idempotencyConfig
 }
 
 // gRPCServerConfig описывает конфигурацию gRPC-сервера
@@ -29,6 +45,104 @@ type gRPCServerConfig struct {
 	timeout time.Duration
 }
 
+// httpServerConfig описывает конфигурацию HTTP-сервера
+type httpServerConfig struct {
+	port       string
+	adminToken string
+}
+
+// eventsConfig описывает настройки событий
+type eventsConfig struct {
+	defaultTimeZone *time.Location
+	// seriesHorizon на сколько вперёд создаются повторения серий событий
+	seriesHorizon time.Duration
+	// seriesInterval как часто запускается создание повторений
+	seriesInterval time.Duration
+	// defaultLanguage язык, на котором написаны исходные тексты событий
+	defaultLanguage string
+	// fallbackLanguage язык перевода, который показывается, если нет перевода на язык пользователя
+	fallbackLanguage string
+}
+
+// paymentsConfig описывает настройки оплаты регистраций
+type paymentsConfig struct {
+	// provider платёжный провайдер, через который создаются платежи
+	provider string
+	// ttl сколько место удерживается за неоплаченной регистрацией
+	ttl time.Duration
+	// expireInterval как часто освобождаются места неоплаченных регистраций
+	expireInterval time.Duration
+	// fakeCheckoutURL адрес страницы оплаты фейкового провайдера
+	fakeCheckoutURL string
+	// webhookSecret ключ подписи уведомлений провайдера
+	webhookSecret string
+	// topic топик NATS для сообщений о подтверждённой оплате
+	topic string
+}
+
+// ticketsConfig описывает настройки билетов
+type ticketsConfig struct {
+	// secret ключ подписи токенов билетов
+	secret string
+	// checkInTopic топик NATS для сообщений о проходе по билету
+	checkInTopic string
+}
+
+// attendanceConfig описывает настройки учёта посещений
+type attendanceConfig struct {
+	// eventDuration предполагаемая длительность событий без сессий
+	eventDuration time.Duration
+	// interval как часто отмечаются неявки
+	interval time.Duration
+	// maxNoShows количество неявок за blockPeriod, после которого пользователь не может регистрироваться
+	// на события с ограниченной вместимостью, 0 - без ограничения
+	maxNoShows  int
+	blockPeriod time.Duration
+}
+
+// approvalConfig описывает настройки одобрения регистраций организатором
+type approvalConfig struct {
+	// decisionTopic топик NATS для сообщений о решениях по заявкам
+	decisionTopic string
+}
+
+// windowConfig описывает настройки окон регистрации
+type windowConfig struct {
+	// openedTopic топик NATS для сообщений об открытии регистрации
+	openedTopic string
+	// announceInterval как часто проверяется открытие регистрации
+	announceInterval time.Duration
+}
+
+// telegramConfig описывает настройки ссылок на Telegram-бота
+type telegramConfig struct {
+	// botUsername имя бота, на которого ведут ссылки, пустое - ссылки не формируются
+	botUsername string
+	// linkSecret ключ подписи токенов ссылок на события
+	linkSecret string
+}
+
+// rateLimitConfig описывает ограничения частоты регистраций. Количество 0 отключает ограничение
+type rateLimitConfig struct {
+	// chatRequests запросов на регистрацию от одного chat_id за chatPeriod
+	chatRequests int
+	chatPeriod   time.Duration
+	// callerRequests запросов на регистрацию от одного клиента gRPC за callerPeriod
+	callerRequests int
+	callerPeriod   time.Duration
+	// eventRegistrations регистраций на одно событие за eventWindow
+	eventRegistrations int
+	eventWindow        time.Duration
+}
+
+// idempotencyConfig описывает настройки ключей идемпотентности регистраций
+type idempotencyConfig struct {
+	// ttl сколько хранится ответ на запрос с ключом идемпотентности
+	ttl time.Duration
+	// cleanupInterval как часто удаляются истёкшие ключи
+	cleanupInterval time.Duration
+}
+
 // databaseConfig описывает конфигурацию базы данных
 type databaseConfig struct {
 	driverName string
@@ -40,6 +154,36 @@ type natsConfig struct {
 	url    string
 	stream string
 	topic  string
+	// encoding формат публикуемых сообщений: json или protobuf
+	encoding     string
+	streamConfig streamConfig
+	// reconnectWait пауза между попытками переподключения и начальная пауза между попытками подключения при запуске
+	reconnectWait time.Duration
+	// maxReconnects сколько раз подряд клиент пытается переподключиться, 0 - без ограничения
+	maxReconnects int
+	// connectAttempts сколько раз сервис пытается подключиться при запуске
+	connectAttempts int
+	// connectBackoffMax максимальная пауза между попытками подключения при запуске
+	connectBackoffMax time.Duration
+	// bufferSize сколько сообщений хранится в локальном буфере, пока NATS недоступен
+	bufferSize int
+	// retryInterval как часто повторяется публикация сообщений из буфера
+	retryInterval time.Duration
+}
+
+// streamConfig описывает настройки потока JetStream
+type streamConfig struct {
+	// retention политика хранения: limits, interest или workqueue
+	retention string
+	// storage тип хранилища: file или memory
+	storage string
+	// maxAge максимальный возраст сообщений, 0 - без ограничения
+	maxAge time.Duration
+	// maxBytes максимальный размер потока в байтах, 0 - без ограничения
+	maxBytes int
+	replicas int
+	// duplicateWindow окно дедупликации сообщений по Nats-Msg-Id
+	duplicateWindow time.Duration
 }
 
 // getEnv проверяет наличие переменной окружения и возвращает её текущее значение, либо стандартное, при отсутствии текущего
@@ -106,7 +250,309 @@ func newNatsConfig(log *slog.Logger) (*natsConfig, error) {
 		log.Error("nats stream cannot be empty")
 		return nil, errors.New("nats stream cannot be empty")
 	}
-	return &natsConfig{url: url, stream: stream, topic: topic}, nil
+	streamCfg, err := newStreamConfig()
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	cfg := &natsConfig{
+		url:          url,
+		stream:       stream,
+		topic:        topic,
+		encoding:     getEnv("NATS_ENCODING", "json"),
+		streamConfig: streamCfg,
+	}
+	if err = cfg.loadConnection(); err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadConnection загружает настройки переподключения к NATS и буфера публикаций
+func (c *natsConfig) loadConnection() error {
+	var err error
+	if c.reconnectWait, err = parsePositiveDuration("NATS_RECONNECT_WAIT", "2s"); err != nil {
+		return err
+	}
+	if c.maxReconnects, err = parseNonNegativeInt("NATS_MAX_RECONNECTS", "0"); err != nil {
+		return err
+	}
+	if c.connectAttempts, err = parseNonNegativeInt("NATS_CONNECT_ATTEMPTS", "10"); err != nil {
+		return err
+	}
+	if c.connectAttempts == 0 {
+		return errors.New("NATS_CONNECT_ATTEMPTS must be positive")
+	}
+	if c.connectBackoffMax, err = parsePositiveDuration("NATS_CONNECT_BACKOFF_MAX", "30s"); err != nil {
+		return err
+	}
+	if c.bufferSize, err = parseNonNegativeInt("NATS_PUBLISH_BUFFER_SIZE", "1000"); err != nil {
+		return err
+	}
+	if c.retryInterval, err = parsePositiveDuration("NATS_PUBLISH_RETRY_INTERVAL", "5s"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newStreamConfig загружает настройки потока JetStream
+func newStreamConfig() (streamConfig, error) {
+	cfg := streamConfig{
+		retention: getEnv("NATS_STREAM_RETENTION", "limits"),
+		storage:   getEnv("NATS_STREAM_STORAGE", "file"),
+	}
+	var err error
+	if cfg.maxAge, err = parseNonNegativeDuration("NATS_STREAM_MAX_AGE", "0"); err != nil {
+		return streamConfig{}, err
+	}
+	if cfg.maxBytes, err = parseNonNegativeInt("NATS_STREAM_MAX_BYTES", "0"); err != nil {
+		return streamConfig{}, err
+	}
+	if cfg.replicas, err = parseNonNegativeInt("NATS_STREAM_REPLICAS", "1"); err != nil {
+		return streamConfig{}, err
+	}
+	if cfg.replicas < 1 || cfg.replicas > 5 {
+		return streamConfig{}, fmt.Errorf("NATS_STREAM_REPLICAS must be between 1 and 5, got %d", cfg.replicas)
+	}
+	if cfg.duplicateWindow, err = parsePositiveDuration("NATS_STREAM_DUPLICATE_WINDOW", "2m"); err != nil {
+		return streamConfig{}, err
+	}
+	return cfg, nil
+}
+
+// newHTTPServerConfig загружает конфигурацию для HTTP-сервера
+func newHTTPServerConfig(log *slog.Logger) (*httpServerConfig, error) {
+	port := getEnv("HTTP_PORT", "")
+	if port == "" {
+		log.Error("http port cannot be empty")
+		return nil, errors.New("http port cannot be empty")
+	}
+
+	adminToken := getEnv("ADMIN_TOKEN", "")
+	if adminToken == "" {
+		log.Error("admin token cannot be empty")
+		return nil, errors.New("admin token cannot be empty")
+	}
+	return &httpServerConfig{port: port, adminToken: adminToken}, nil
+}
+
+// newEventsConfig загружает настройки событий
+func newEventsConfig(log *slog.Logger) (*eventsConfig, error) {
+	zone := getEnv("EVENTS_DEFAULT_TIME_ZONE", "Europe/Moscow")
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		log.Error("invalid default time zone", slog.String("time_zone", zone))
+		return nil, fmt.Errorf("invalid default time zone %q: %w", zone, err)
+	}
+
+	horizon, err := parsePositiveDuration("SERIES_HORIZON", "2160h")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	interval, err := parsePositiveDuration("SERIES_MATERIALIZE_INTERVAL", "1h")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	return &eventsConfig{
+		defaultTimeZone:  loc,
+		seriesHorizon:    horizon,
+		seriesInterval:   interval,
+		defaultLanguage:  strings.ToLower(strings.TrimSpace(getEnv("EVENTS_DEFAULT_LANGUAGE", "ru"))),
+		fallbackLanguage: strings.ToLower(strings.TrimSpace(getEnv("EVENTS_FALLBACK_LANGUAGE", "en"))),
+	}, nil
+}
+
+// newPaymentsConfig загружает настройки оплаты регистраций
+func newPaymentsConfig(log *slog.Logger) (*paymentsConfig, error) {
+	ttl, err := parsePositiveDuration("PAYMENT_TTL", "15m")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	interval, err := parsePositiveDuration("PAYMENT_EXPIRE_INTERVAL", "1m")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	// Провайдер не выбирается по умолчанию: фейковый провайдер подтверждает платежи без списания денег
+	provider := getEnv("PAYMENT_PROVIDER", "")
+	if provider == "" {
+		log.Error("payment provider cannot be empty")
+		return nil, errors.New("payment provider cannot be empty")
+	}
+	webhookSecret := getEnv("PAYMENT_WEBHOOK_SECRET", "")
+	if webhookSecret == "" {
+		log.Error("payment webhook secret cannot be empty")
+		return nil, errors.New("payment webhook secret cannot be empty")
+	}
+	return &paymentsConfig{
+		provider:        provider,
+		ttl:             ttl,
+		expireInterval:  interval,
+		fakeCheckoutURL: getEnv("PAYMENT_FAKE_CHECKOUT_URL", "http://localhost:8081/payments/fake"),
+		webhookSecret:   webhookSecret,
+		topic:           getEnv("NATS_PAYMENT_TOPIC", "payment.confirmed"),
+	}, nil
+}
+
+// newTicketsConfig загружает настройки билетов
+func newTicketsConfig(log *slog.Logger) (*ticketsConfig, error) {
+	secret := getEnv("TICKET_SECRET", "")
+	if secret == "" {
+		log.Error("ticket secret cannot be empty")
+		return nil, errors.New("ticket secret cannot be empty")
+	}
+	return &ticketsConfig{
+		secret:       secret,
+		checkInTopic: getEnv("NATS_CHECKIN_TOPIC", "registration.checked_in"),
+	}, nil
+}
+
+// newAttendanceConfig загружает настройки учёта посещений
+func newAttendanceConfig(log *slog.Logger) (*attendanceConfig, error) {
+	eventDuration, err := parsePositiveDuration("ATTENDANCE_EVENT_DURATION", "3h")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	interval, err := parsePositiveDuration("ATTENDANCE_INTERVAL", "15m")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	maxNoShows, err := parseNonNegativeInt("NOSHOW_BLOCK_THRESHOLD", "0")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	blockPeriod, err := parsePositiveDuration("NOSHOW_BLOCK_PERIOD", "720h")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	return &attendanceConfig{
+		eventDuration: eventDuration,
+		interval:      interval,
+		maxNoShows:    maxNoShows,
+		blockPeriod:   blockPeriod,
+	}, nil
+}
+
+// newApprovalConfig загружает настройки одобрения регистраций
+func newApprovalConfig() *approvalConfig {
+	return &approvalConfig{decisionTopic: getEnv("NATS_DECISION_TOPIC", "registration.decision")}
+}
+
+// newWindowConfig загружает настройки окон регистрации
+func newWindowConfig(log *slog.Logger) (*windowConfig, error) {
+	interval, err := parsePositiveDuration("REGISTRATION_OPEN_INTERVAL", "1m")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	return &windowConfig{
+		openedTopic:      getEnv("NATS_REGISTRATION_OPENED_TOPIC", "registration.opened"),
+		announceInterval: interval,
+	}, nil
+}
+
+// newTelegramConfig загружает настройки ссылок на Telegram-бота
+func newTelegramConfig(log *slog.Logger) (*telegramConfig, error) {
+	linkSecret := getEnv("LINK_SECRET", "")
+	if len(linkSecret) < minLinkSecretLength {
+		log.Error("link secret is too short")
+		return nil, fmt.Errorf("LINK_SECRET must be at least %d characters long", minLinkSecretLength)
+	}
+	return &telegramConfig{
+		botUsername: getEnv("TELEGRAM_BOT_USERNAME", ""),
+		linkSecret:  linkSecret,
+	}, nil
+}
+
+// newRateLimitConfig загружает ограничения частоты регистраций
+func newRateLimitConfig(log *slog.Logger) (*rateLimitConfig, error) {
+	var (
+		cfg rateLimitConfig
+		err error
+	)
+	limits := []struct {
+		requests *int
+		period   *time.Duration
+		key      string
+		reserve  string
+	}{
+		{&cfg.chatRequests, &cfg.chatPeriod, "RATE_LIMIT_CHAT", "5"},
+		{&cfg.callerRequests, &cfg.callerPeriod, "RATE_LIMIT_CALLER", "0"},
+		{&cfg.eventRegistrations, &cfg.eventWindow, "RATE_LIMIT_EVENT", "0"},
+	}
+	for _, l := range limits {
+		if *l.requests, err = parseNonNegativeInt(l.key+"_REQUESTS", l.reserve); err != nil {
+			log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+			return nil, err
+		}
+		if *l.period, err = parsePositiveDuration(l.key+"_PERIOD", "1m"); err != nil {
+			log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// newIdempotencyConfig загружает настройки ключей идемпотентности
+func newIdempotencyConfig(log *slog.Logger) (*idempotencyConfig, error) {
+	ttl, err := parsePositiveDuration("IDEMPOTENCY_TTL", "24h")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	interval, err := parsePositiveDuration("IDEMPOTENCY_CLEANUP_INTERVAL", "1h")
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, err
+	}
+	return &idempotencyConfig{ttl: ttl, cleanupInterval: interval}, nil
+}
+
+// parsePositiveDuration читает из переменной окружения положительную длительность
+func parsePositiveDuration(key, reserve string) (time.Duration, error) {
+	value := getEnv(key, reserve)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %q", key, value)
+	}
+	return d, nil
+}
+
+// parseNonNegativeDuration читает из переменной окружения неотрицательную длительность
+func parseNonNegativeDuration(key, reserve string) (time.Duration, error) {
+	value := getEnv(key, reserve)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %q", key, value)
+	}
+	return d, nil
+}
+
+// parseNonNegativeInt читает из переменной окружения неотрицательное целое число
+func parseNonNegativeInt(key, reserve string) (int, error) {
+	value := getEnv(key, reserve)
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %q", key, value)
+	}
+	return n, nil
 }
 
 // LoadConfig создаёт конфигурацию микросервиса
@@ -139,7 +585,76 @@ func LoadConfig(log *slog.Logger) (*Config, error) {
 		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
 	}
 
-	return &Config{gRPCCfg, dbCfg, natsCfg}, nil
+	// Создаём конфигурацию HTTP-сервера
+	httpCfg, err := newHTTPServerConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	eventsCfg, err := newEventsConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	paymentsCfg, err := newPaymentsConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	ticketsCfg, err := newTicketsConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	attendanceCfg, err := newAttendanceConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	windowCfg, err := newWindowConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	rateLimitCfg, err := newRateLimitConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	telegramCfg, err := newTelegramConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	idempotencyCfg, err := newIdempotencyConfig(log)
+	if err != nil {
+		log.Error("error", err.Error(), slog.String("operation", opLoadConfig))
+		return nil, fmt.Errorf("%s: %w", opLoadConfig, err)
+	}
+
+	return &Config{
+		gRPCServerConfig:  gRPCCfg,
+		databaseConfig:    dbCfg,
+		natsConfig:        natsCfg,
+		httpServerConfig:  httpCfg,
+		eventsConfig:      eventsCfg,
+		paymentsConfig:    paymentsCfg,
+		ticketsConfig:     ticketsCfg,
+		attendanceConfig:  attendanceCfg,
+		approvalConfig:    newApprovalConfig(),
+		windowConfig:      windowCfg,
+		telegramConfig:    telegramCfg,
+		rateLimitConfig:   rateLimitCfg,
+		idempotencyConfig: idempotencyCfg,
+	}, nil
 }
 
 // MustLoadConfig обёртка для LoadConfig, при ошибке - паникует
@@ -178,3 +693,203 @@ func (c *Config) GetNatsStream() string {
 
 // GetNatsTopic геттер для получения названия топика, в который будут публиковаться сообщения
 func (c *Config) GetNatsTopic() string { return c.natsConfig.topic }
+
+// GetNatsReconnect геттер для получения паузы между попытками переподключения к NATS
+// и максимального количества попыток подряд (0 - без ограничения)
+func (c *Config) GetNatsReconnect() (time.Duration, int) {
+	return c.natsConfig.reconnectWait, c.natsConfig.maxReconnects
+}
+
+// GetNatsConnectRetry геттер для получения количества попыток подключения к NATS при запуске
+// и максимальной паузы между ними
+func (c *Config) GetNatsConnectRetry() (int, time.Duration) {
+	return c.natsConfig.connectAttempts, c.natsConfig.connectBackoffMax
+}
+
+// GetNatsPublishBuffer геттер для получения размера буфера сообщений на время недоступности NATS
+// и интервала повторной публикации из него
+func (c *Config) GetNatsPublishBuffer() (int, time.Duration) {
+	return c.natsConfig.bufferSize, c.natsConfig.retryInterval
+}
+
+// GetNatsStreamRetention геттер для получения политики хранения потока: limits, interest или workqueue
+func (c *Config) GetNatsStreamRetention() string {
+	return c.natsConfig.streamConfig.retention
+}
+
+// GetNatsStreamStorage геттер для получения типа хранилища потока: file или memory
+func (c *Config) GetNatsStreamStorage() string {
+	return c.natsConfig.streamConfig.storage
+}
+
+// GetNatsStreamMaxAge геттер для получения максимального возраста сообщений потока, 0 - без ограничения
+func (c *Config) GetNatsStreamMaxAge() time.Duration {
+	return c.natsConfig.streamConfig.maxAge
+}
+
+// GetNatsStreamMaxBytes геттер для получения максимального размера потока в байтах, 0 - без ограничения
+func (c *Config) GetNatsStreamMaxBytes() int64 {
+	return int64(c.natsConfig.streamConfig.maxBytes)
+}
+
+// GetNatsStreamReplicas геттер для получения количества реплик потока
+func (c *Config) GetNatsStreamReplicas() int {
+	return c.natsConfig.streamConfig.replicas
+}
+
+// GetNatsStreamDuplicateWindow геттер для получения окна дедупликации сообщений потока
+func (c *Config) GetNatsStreamDuplicateWindow() time.Duration {
+	return c.natsConfig.streamConfig.duplicateWindow
+}
+
+// GetNatsEncoding геттер для получения формата публикуемых сообщений: json или protobuf
+func (c *Config) GetNatsEncoding() string {
+	return c.natsConfig.encoding
+}
+
+// GetHTTPServerPort геттер для получения порта HTTP-сервера
+func (c *Config) GetHTTPServerPort() string {
+	return c.httpServerConfig.port
+}
+
+// GetAdminToken геттер для получения токена доступа к административному API
+func (c *Config) GetAdminToken() string {
+	return c.httpServerConfig.adminToken
+}
+
+// GetDefaultTimeZone геттер для получения часового пояса, применяемого к событиям без явно указанного пояса
+func (c *Config) GetDefaultTimeZone() *time.Location {
+	return c.eventsConfig.defaultTimeZone
+}
+
+// GetDefaultLanguage геттер для получения языка исходных текстов событий
+func (c *Config) GetDefaultLanguage() string {
+	return c.eventsConfig.defaultLanguage
+}
+
+// GetFallbackLanguage геттер для получения языка перевода, показываемого при отсутствии перевода на язык пользователя
+func (c *Config) GetFallbackLanguage() string {
+	return c.eventsConfig.fallbackLanguage
+}
+
+// GetSeriesHorizon геттер для получения горизонта, на который вперёд создаются повторения серий событий
+func (c *Config) GetSeriesHorizon() time.Duration {
+	return c.eventsConfig.seriesHorizon
+}
+
+// GetSeriesMaterializeInterval геттер для получения интервала фонового создания повторений серий
+func (c *Config) GetSeriesMaterializeInterval() time.Duration {
+	return c.eventsConfig.seriesInterval
+}
+
+// GetPaymentProvider геттер для получения платёжного провайдера, через который создаются платежи
+func (c *Config) GetPaymentProvider() string {
+	return c.paymentsConfig.provider
+}
+
+// GetPaymentTTL геттер для получения срока оплаты, в течение которого место удерживается за регистрацией
+func (c *Config) GetPaymentTTL() time.Duration {
+	return c.paymentsConfig.ttl
+}
+
+// GetPaymentExpireInterval геттер для получения интервала освобождения мест неоплаченных регистраций
+func (c *Config) GetPaymentExpireInterval() time.Duration {
+	return c.paymentsConfig.expireInterval
+}
+
+// GetFakePaymentCheckoutURL геттер для получения адреса страницы оплаты фейкового провайдера
+func (c *Config) GetFakePaymentCheckoutURL() string {
+	return c.paymentsConfig.fakeCheckoutURL
+}
+
+// GetPaymentWebhookSecret геттер для получения ключа подписи уведомлений платёжного провайдера
+func (c *Config) GetPaymentWebhookSecret() string {
+	return c.paymentsConfig.webhookSecret
+}
+
+// GetNatsPaymentTopic геттер для получения топика сообщений о подтверждённой оплате
+func (c *Config) GetNatsPaymentTopic() string {
+	return c.paymentsConfig.topic
+}
+
+// GetTicketSecret геттер для получения ключа подписи токенов билетов
+func (c *Config) GetTicketSecret() string {
+	return c.ticketsConfig.secret
+}
+
+// GetNatsCheckInTopic геттер для получения топика сообщений о проходе по билету
+func (c *Config) GetNatsCheckInTopic() string {
+	return c.ticketsConfig.checkInTopic
+}
+
+// GetAttendanceEventDuration геттер для получения предполагаемой длительности событий без сессий
+func (c *Config) GetAttendanceEventDuration() time.Duration {
+	return c.attendanceConfig.eventDuration
+}
+
+// GetAttendanceInterval геттер для получения интервала отметки неявок
+func (c *Config) GetAttendanceInterval() time.Duration {
+	return c.attendanceConfig.interval
+}
+
+// GetMaxNoShows геттер для получения количества неявок, после которого регистрация ограничивается
+func (c *Config) GetMaxNoShows() int {
+	return c.attendanceConfig.maxNoShows
+}
+
+// GetNoShowBlockPeriod геттер для получения периода, за который учитываются неявки
+func (c *Config) GetNoShowBlockPeriod() time.Duration {
+	return c.attendanceConfig.blockPeriod
+}
+
+// GetNatsDecisionTopic геттер для получения топика сообщений о решениях по заявкам на регистрацию
+func (c *Config) GetNatsDecisionTopic() string {
+	return c.approvalConfig.decisionTopic
+}
+
+// GetNatsRegistrationOpenedTopic геттер для получения топика сообщений об открытии регистрации
+func (c *Config) GetNatsRegistrationOpenedTopic() string {
+	return c.windowConfig.openedTopic
+}
+
+// GetRegistrationOpenInterval геттер для получения интервала проверки открытия регистрации
+func (c *Config) GetRegistrationOpenInterval() time.Duration {
+	return c.windowConfig.announceInterval
+}
+
+// GetTelegramBotUsername геттер для получения имени Telegram-бота для ссылок на события и кодов приглашения
+func (c *Config) GetTelegramBotUsername() string {
+	return c.telegramConfig.botUsername
+}
+
+// GetLinkSecret геттер для получения ключа подписи токенов ссылок на события
+func (c *Config) GetLinkSecret() string {
+	return c.telegramConfig.linkSecret
+}
+
+// GetChatRateLimit геттер для получения ограничения запросов на регистрацию от одного пользователя:
+// количество запросов за период
+func (c *Config) GetChatRateLimit() (int, time.Duration) {
+	return c.rateLimitConfig.chatRequests, c.rateLimitConfig.chatPeriod
+}
+
+// GetCallerRateLimit геттер для получения ограничения запросов на регистрацию от одного клиента gRPC:
+// количество запросов за период
+func (c *Config) GetCallerRateLimit() (int, time.Duration) {
+	return c.rateLimitConfig.callerRequests, c.rateLimitConfig.callerPeriod
+}
+
+// GetEventRateLimit геттер для получения ограничения регистраций на одно событие: количество регистраций за окно
+func (c *Config) GetEventRateLimit() (int, time.Duration) {
+	return c.rateLimitConfig.eventRegistrations, c.rateLimitConfig.eventWindow
+}
+
+// GetIdempotencyTTL геттер для получения срока хранения ответов на запросы с ключом идемпотентности
+func (c *Config) GetIdempotencyTTL() time.Duration {
+	return c.idempotencyConfig.ttl
+}
+
+// GetIdempotencyCleanupInterval геттер для получения интервала удаления истёкших ключей идемпотентности
+func (c *Config) GetIdempotencyCleanupInterval() time.Duration {
+	return c.idempotencyConfig.cleanupInterval
+}
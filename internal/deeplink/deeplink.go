@@ -0,0 +1,60 @@
+package deeplink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrInvalidToken токен ссылки повреждён или подписан другим ключом
+var ErrInvalidToken = errors.New("invalid link token")
+
+const (
+	// version первый байт токена. Благодаря ему токен всегда начинается с символа "A"
+	version byte = 1
+	// macSize длина подписи в токене в байтах
+	macSize = 8
+)
+
+// Signer выпускает и проверяет токены ссылок на бота. Токен - base64url от версии, ID ссылки в формате
+// varint и усечённой HMAC-SHA256 подписи. Токен короткий и подходит для параметра start ссылки t.me
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner конструктор для Signer
+func NewSigner(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+// Sign выпускает токен для ссылки с указанным ID
+func (s *Signer) Sign(linkID int64) string {
+	payload := binary.AppendUvarint([]byte{version}, uint64(linkID))
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.mac(payload)...))
+}
+
+// Verify проверяет подпись токена и возвращает ID ссылки
+func (s *Signer) Verify(token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) <= 1+macSize || raw[0] != version {
+		return 0, ErrInvalidToken
+	}
+	payload, mac := raw[:len(raw)-macSize], raw[len(raw)-macSize:]
+	if !hmac.Equal(mac, s.mac(payload)) {
+		return 0, ErrInvalidToken
+	}
+	id, n := binary.Uvarint(payload[1:])
+	if n != len(payload)-1 || id == 0 {
+		return 0, ErrInvalidToken
+	}
+	return int64(id), nil
+}
+
+// mac возвращает усечённую подпись данных токена
+func (s *Signer) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payload)
+	return h.Sum(nil)[:macSize]
+}
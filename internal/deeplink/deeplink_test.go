@@ -0,0 +1,74 @@
+package deeplink
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+const testSecret = "link-secret-for-tests-0123456789"
+
+func TestSignVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int64
+	}{
+		{name: "one", id: 1},
+		{name: "one byte varint", id: 127},
+		{name: "two byte varint", id: 128},
+		{name: "large", id: 1 << 40},
+		{name: "max", id: math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSigner(testSecret)
+			token := s.Sign(tt.id)
+			if !strings.HasPrefix(token, "A") {
+				t.Errorf("Sign(%d) = %q, want prefix A", tt.id, token)
+			}
+			got, err := s.Verify(token)
+			if err != nil {
+				t.Fatalf("Verify(Sign(%d)): %v", tt.id, err)
+			}
+			if got != tt.id {
+				t.Errorf("Verify(Sign(%d)) = %d", tt.id, got)
+			}
+		})
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	s := NewSigner(testSecret)
+	token := s.Sign(42)
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+
+	// tampered - токен с изменённым ID и исходной подписью
+	tampered := append([]byte(nil), raw...)
+	tampered[1]++
+	// wrongVersion - токен с неизвестной версией
+	wrongVersion := append([]byte(nil), raw...)
+	wrongVersion[0] = version + 1
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "signed with another key", token: NewSigner("another-secret-for-tests-0123456").Sign(42)},
+		{name: "tampered id", token: base64.RawURLEncoding.EncodeToString(tampered)},
+		{name: "wrong version", token: base64.RawURLEncoding.EncodeToString(wrongVersion)},
+		{name: "truncated", token: token[:len(token)-1]},
+		{name: "too short", token: base64.RawURLEncoding.EncodeToString(raw[:macSize])},
+		{name: "not base64", token: "A!!!"},
+		{name: "empty"},
+		{name: "zero id", token: s.Sign(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify(%q) error = %v, want ErrInvalidToken", tt.token, err)
+			}
+		})
+	}
+}
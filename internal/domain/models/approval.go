@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Decision описывает решение организатора по заявке на регистрацию
+type Decision string
+
+// Решения по заявке
+const (
+	DecisionApproved Decision = "approved"
+	DecisionRejected Decision = "rejected"
+)
+
+// RegistrationDecision описывает сообщение о решении по заявке на регистрацию для публикации в NATS
+type RegistrationDecision struct {
+	RegistrationID string   `json:"registration_id"`
+	EventID        string   `json:"event_id"`
+	SessionID      *string  `json:"session_id,omitempty"`
+	ChatID         int64    `json:"chat_id"`
+	Username       string   `json:"username"`
+	Decision       Decision `json:"decision"`
+	// Status состояние регистрации после решения: confirmed, pending_payment или rejected
+	Status RegistrationStatus `json:"status"`
+	Reason string             `json:"reason,omitempty"`
+	// TicketToken токен билета, передаётся для одобренной бесплатной регистрации
+	TicketToken string `json:"ticket_token,omitempty"`
+	// PaymentURL ссылка на оплату, передаётся для одобренной регистрации с платным билетом
+	PaymentURL       string     `json:"payment_url,omitempty"`
+	PaymentExpiresAt *time.Time `json:"payment_expires_at,omitempty"`
+	DecidedAt        time.Time  `json:"decided_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// AttendanceStatus описывает итог посещения события
+type AttendanceStatus string
+
+// Итоги посещения
+const (
+	// AttendanceAttended пользователь прошёл по билету
+	AttendanceAttended AttendanceStatus = "attended"
+	// AttendanceNoShow пользователь зарегистрировался, но не пришёл
+	AttendanceNoShow AttendanceStatus = "no_show"
+)
+
+// AttendanceRecord описывает запись истории посещений пользователя
+type AttendanceRecord struct {
+	ID             string           `db:"id"`
+	RegistrationID *string          `db:"registration_id"`
+	EventID        string           `db:"event_id"`
+	SessionID      *string          `db:"session_id"`
+	ChatID         int64            `db:"chat_id"`
+	Status         AttendanceStatus `db:"status"`
+	RecordedAt     time.Time        `db:"recorded_at"`
+	// EventTitle и EventStartsAt заполняются repo-слоем
+	EventTitle    string    `db:"event_title"`
+	EventStartsAt time.Time `db:"event_starts_at"`
+}
+
+// UserAttendance описывает историю посещений пользователя
+type UserAttendance struct {
+	ChatID   int64
+	Attended int
+	NoShows  int
+	Records  []AttendanceRecord
+}
+
+// EventAttendance описывает статистику посещения события
+type EventAttendance struct {
+	EventID string `db:"event_id"`
+	// Registered количество подтверждённых регистраций
+	Registered int `db:"registered"`
+	Attended   int `db:"attended"`
+	NoShows    int `db:"no_shows"`
+	// Unknown регистрации без отметки прохода, итог которых ещё не подведён
+	Unknown int `db:"unknown"`
+}
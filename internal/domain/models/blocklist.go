@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// BlockedChat описывает пользователя, которому запрещена регистрация на события
+type BlockedChat struct {
+	ChatID int64  `db:"chat_id"`
+	Reason string `db:"reason"`
+	// BlockedUntil момент окончания блокировки, nil - бессрочно
+	BlockedUntil *time.Time `db:"blocked_until"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Category описывает категорию событий
+type Category struct {
+	ID   string `db:"id" json:"id"`
+	Slug string `db:"slug" json:"slug"`
+	Name string `db:"name" json:"name"`
+	// Position порядок категории в меню
+	Position int `db:"position" json:"position"`
+}
+
+// EventFilter описывает фильтры списка событий. Пустые поля не ограничивают выборку
+type EventFilter struct {
+	// Category slug категории
+	Category string
+	// Tags событие должно иметь все перечисленные теги
+	Tags []string
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// DeepLink описывает ссылку на бота, открывающую конкретное событие. Campaign и Source помечают,
+// где опубликована ссылка, для подсчёта конверсии
+type DeepLink struct {
+	ID        int64     `db:"id"`
+	EventID   string    `db:"event_id"`
+	Campaign  string    `db:"campaign"`
+	Source    string    `db:"source"`
+	CreatedAt time.Time `db:"created_at"`
+	// Clicks сколько раз ссылку открыли, Visitors - сколько разных пользователей её открыли
+	// (открытия без chat_id считаются отдельными пользователями), Registrations - сколько регистраций
+	// выполнено по ссылке. Заполняются repo-слоем в статистике ссылок
+	Clicks        int `db:"clicks"`
+	Visitors      int `db:"visitors"`
+	Registrations int `db:"registrations"`
+	// Token подписанный токен ссылки и Link ссылка на бота, заполняются сервисным слоем
+	Token string `db:"-"`
+	Link  string `db:"-"`
+}
+
+// Conversion возвращает долю пользователей, открывших ссылку и зарегистрировавшихся по ней
+func (l DeepLink) Conversion() float64 {
+	if l.Visitors == 0 {
+		return 0
+	}
+	return float64(l.Registrations) / float64(l.Visitors)
+}
@@ -5,7 +5,67 @@ import "time"
 // Event описывает соответствующую модель данных
 type Event struct {
 	ID          string    `db:"id"`
+	ExternalID  *string   `db:"external_id"`
 	Title       string    `db:"title"`
 	Description string    `db:"description"`
 	StartsAt    time.Time `db:"starts_at"`
+	// TimeZone часовой пояс события в формате IANA (например, Europe/Moscow)
+	TimeZone  string    `db:"time_zone"`
+	UpdatedAt time.Time `db:"updated_at"`
+	// Sequence номер ревизии события, увеличивается при изменении времени или текста
+	Sequence int     `db:"sequence"`
+	VenueID  *string `db:"venue_id"`
+	// SeriesID серия, повторением которой является событие
+	SeriesID *string `db:"series_id"`
+	// OccurrenceDate местная дата повторения серии
+	OccurrenceDate *time.Time `db:"occurrence_date"`
+	// MaxGuests сколько гостей пользователь может привести с собой, 0 - только сам пользователь
+	MaxGuests int `db:"max_guests"`
+	// RequiresApproval регистрации на событие подтверждает организатор
+	RequiresApproval bool `db:"requires_approval"`
+	// RegistrationOpensAt и RegistrationClosesAt окно регистрации, nil - без ограничения с соответствующей стороны
+	RegistrationOpensAt  *time.Time `db:"registration_opens_at"`
+	RegistrationClosesAt *time.Time `db:"registration_closes_at"`
+	// RegistrationClosesBefore за сколько минут до начала события закрывается регистрация, nil - без ограничения
+	RegistrationClosesBefore *int `db:"registration_closes_before"`
+	// RegistrationOpenAnnouncedAt момент публикации сообщения об открытии регистрации
+	RegistrationOpenAnnouncedAt *time.Time `db:"registration_open_announced_at"`
+	// Visibility видимость события в списке и по ID
+	Visibility Visibility `db:"visibility"`
+	// RegistrationState состояние регистрации на момент запроса, заполняется сервисным слоем
+	RegistrationState RegistrationState `db:"-"`
+	// Venue площадка события, заполняется repo-слоем по VenueID
+	Venue *Venue `db:"-"`
+	// Categories и Tags заполняются repo-слоем
+	Categories []Category `db:"-"`
+	Tags       []string   `db:"-"`
+	// Sessions сессии события в порядке начала, заполняются repo-слоем
+	Sessions []Session `db:"-"`
+	// TicketTypes типы билетов события, заполняются repo-слоем
+	TicketTypes []TicketType `db:"-"`
+	// Questions вопросы анкеты регистрации в порядке показа, заполняются repo-слоем
+	Questions []Question `db:"-"`
+	// Translations переводы текстов события, заполняются repo-слоем
+	Translations []EventTranslation `db:"-"`
+	// Language язык, на котором возвращены Title и Description, заполняется сервисным слоем
+	Language string `db:"-"`
+}
+
+// RegistrationWindow возвращает окно регистрации на событие
+func (e Event) RegistrationWindow() RegistrationWindow {
+	return RegistrationWindow{
+		StartsAt:     e.StartsAt,
+		OpensAt:      e.RegistrationOpensAt,
+		ClosesAt:     e.RegistrationClosesAt,
+		ClosesBefore: e.RegistrationClosesBefore,
+	}
+}
+
+// LocalStartsAt возвращает время начала события в его часовом поясе
+func (e Event) LocalStartsAt() time.Time {
+	loc, err := time.LoadLocation(e.TimeZone)
+	if err != nil {
+		return e.StartsAt.UTC()
+	}
+	return e.StartsAt.In(loc)
 }
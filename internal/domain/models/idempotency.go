@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// IdempotencyKey описывает ключ идемпотентности запроса и сохранённый ответ на него
+type IdempotencyKey struct {
+	Key string `db:"key"`
+	// RequestHash хеш запроса, с которым впервые передан ключ
+	RequestHash string `db:"request_hash"`
+	// Response сохранённый ответ в формате JSON, nil - запрос ещё выполняется
+	Response  []byte    `db:"response"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
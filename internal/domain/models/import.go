@@ -0,0 +1,15 @@
+package models
+
+// ImportRowError описывает ошибку в конкретной строке импортируемого файла
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportReport описывает результат импорта событий
+type ImportReport struct {
+	Total    int              `json:"total"`
+	Inserted int              `json:"inserted"`
+	Updated  int              `json:"updated"`
+	Errors   []ImportRowError `json:"errors,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Visibility описывает видимость события
+type Visibility string
+
+// Видимость события
+const (
+	// VisibilityPublic событие показывается в списке событий
+	VisibilityPublic Visibility = "public"
+	// VisibilityUnlisted событие не показывается в списке, но доступно по ID
+	VisibilityUnlisted Visibility = "unlisted"
+	// VisibilityPrivate событие не показывается в списке и доступно только по коду приглашения,
+	// регистрация на него требует действующего кода
+	VisibilityPrivate Visibility = "private"
+)
+
+// InviteCode описывает код приглашения на событие
+type InviteCode struct {
+	ID      string `db:"id"`
+	EventID string `db:"event_id"`
+	Code    string `db:"code"`
+	// MaxUses сколько регистраций можно выполнить по коду, nil - без ограничения
+	MaxUses *int `db:"max_uses"`
+	Uses    int  `db:"uses"`
+	// ExpiresAt момент, после которого код перестаёт действовать, nil - бессрочно
+	ExpiresAt *time.Time `db:"expires_at"`
+	// RevokedAt момент отзыва кода организатором
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	// Link ссылка на бота с кодом, заполняется сервисным слоем, если задано имя бота
+	Link string `db:"-"`
+}
+
+// Valid проверяет, что по коду можно зарегистрироваться в момент at
+func (c InviteCode) Valid(at time.Time) bool {
+	if c.RevokedAt != nil {
+		return false
+	}
+	if c.ExpiresAt != nil && !at.Before(*c.ExpiresAt) {
+		return false
+	}
+	return c.MaxUses == nil || c.Uses < *c.MaxUses
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// PaymentStatus описывает состояние платежа
+type PaymentStatus string
+
+// Состояния платежа
+const (
+	// PaymentPending платёж создан и ожидает оплаты
+	PaymentPending PaymentStatus = "pending"
+	// PaymentSucceeded платёж подтверждён провайдером
+	PaymentSucceeded PaymentStatus = "succeeded"
+	// PaymentFailed провайдер сообщил об ошибке оплаты или её отмене
+	PaymentFailed PaymentStatus = "failed"
+	// PaymentExpired срок оплаты истёк, место освобождено
+	PaymentExpired PaymentStatus = "expired"
+)
+
+// Payment описывает платёж за регистрацию
+type Payment struct {
+	ID             string  `db:"id"`
+	RegistrationID *string `db:"registration_id"`
+	EventID        string  `db:"event_id"`
+	ChatID         int64   `db:"chat_id"`
+	// Provider название платёжного провайдера, ProviderPaymentID - идентификатор платежа у провайдера
+	Provider          string `db:"provider"`
+	ProviderPaymentID string `db:"provider_payment_id"`
+	// Amount сумма в минимальных единицах валюты (копейках)
+	Amount   int64         `db:"amount"`
+	Currency string        `db:"currency"`
+	Status   PaymentStatus `db:"status"`
+	// ConfirmationURL ссылка, по которой пользователь оплачивает регистрацию
+	ConfirmationURL string    `db:"confirmation_url"`
+	ExpiresAt       time.Time `db:"expires_at"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// ProviderPayment описывает платёж, созданный у платёжного провайдера
+type ProviderPayment struct {
+	ID              string
+	ConfirmationURL string
+}
+
+// PaymentNotification описывает уведомление платёжного провайдера о результате платежа
+type PaymentNotification struct {
+	ProviderPaymentID string
+	// Status PaymentSucceeded или PaymentFailed
+	Status PaymentStatus
+}
+
+// PaymentConfirmed описывает сообщение о подтверждённой оплате регистрации для публикации в NATS
+type PaymentConfirmed struct {
+	RegistrationID string  `json:"registration_id"`
+	PaymentID      string  `json:"payment_id"`
+	EventID        string  `json:"event_id"`
+	ChatID         int64   `json:"chat_id"`
+	Username       string  `json:"username"`
+	SessionID      *string `json:"session_id,omitempty"`
+	TicketTypeID   *string `json:"ticket_type_id,omitempty"`
+	Amount         int64   `json:"amount"`
+	Currency       string  `json:"currency"`
+	// TicketToken токен билета для входа на событие
+	TicketToken string `json:"ticket_token"`
+}
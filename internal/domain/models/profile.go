@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// UserProfile описывает профиль пользователя Telegram. Профиль обновляется при каждой регистрации,
+// поэтому все регистрации пользователя показывают актуальные данные. Пустая строка - значение неизвестно
+type UserProfile struct {
+	ChatID int64 `db:"chat_id"`
+	// Username имя пользователя без @, пусто, если у пользователя его нет
+	Username  string `db:"username"`
+	FirstName string `db:"first_name"`
+	LastName  string `db:"last_name"`
+	// LanguageCode код языка интерфейса Telegram (например, ru или en)
+	LanguageCode string    `db:"language_code"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
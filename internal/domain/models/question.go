@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// QuestionType описывает тип ответа на вопрос анкеты
+type QuestionType string
+
+const (
+	// QuestionText произвольный текст
+	QuestionText QuestionType = "text"
+	// QuestionNumber число
+	QuestionNumber QuestionType = "number"
+	// QuestionBoolean да или нет
+	QuestionBoolean QuestionType = "boolean"
+	// QuestionSingleChoice один из вариантов Options
+	QuestionSingleChoice QuestionType = "single_choice"
+	// QuestionMultipleChoice один или несколько вариантов Options
+	QuestionMultipleChoice QuestionType = "multiple_choice"
+)
+
+// Question описывает вопрос анкеты регистрации на событие (например, ограничения в питании
+// или уровень подготовки)
+type Question struct {
+	ID      string       `db:"id" json:"id"`
+	EventID string       `db:"event_id" json:"event_id"`
+	Type    QuestionType `db:"type" json:"type"`
+	Label   string       `db:"label" json:"label"`
+	// Options варианты ответа для вопросов с выбором, заполняются repo-слоем
+	Options   []string  `db:"-" json:"options"`
+	Required  bool      `db:"required" json:"required"`
+	Position  int       `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Answer описывает ответ пользователя на вопрос анкеты. Для вопросов с несколькими вариантами
+// Values содержит все выбранные варианты, для остальных - одно значение
+type Answer struct {
+	QuestionID string
+	Values     []string
+}
+
+// AttendeeList описывает список участников события вместе с вопросами анкеты для выгрузки
+type AttendeeList struct {
+	EventID   string
+	Questions []Question
+	// Registrations регистрации на событие с ответами на анкету в порядке создания
+	Registrations []Registration
+}
@@ -2,11 +2,87 @@ package models
 
 import "time"
 
-// Registration описывает данные для регистрации пользователя на событие
+// RegistrationStatus описывает состояние регистрации
+type RegistrationStatus string
+
+// Состояния регистрации
+const (
+	// RegistrationConfirmed регистрация действует
+	RegistrationConfirmed RegistrationStatus = "confirmed"
+	// RegistrationPendingPayment место удерживается до оплаты или до истечения срока оплаты
+	RegistrationPendingPayment RegistrationStatus = "pending_payment"
+	// RegistrationPendingApproval заявка ожидает решения организатора и не занимает место
+	RegistrationPendingApproval RegistrationStatus = "pending_approval"
+	// RegistrationRejected заявка отклонена организатором
+	RegistrationRejected RegistrationStatus = "rejected"
+)
+
+// Registration описывает регистрацию пользователя на событие
 type Registration struct {
-	ID        string
-	EventID   string
-	ChatID    int64
-	Username  string
-	CreatedAt time.Time
+	ID           string             `db:"id"`
+	EventID      string             `db:"event_id"`
+	ChatID       int64              `db:"chat_id"`
+	CreatedAt    time.Time          `db:"created_at"`
+	SessionID    *string            `db:"session_id"`
+	TicketTypeID *string            `db:"ticket_type_id"`
+	Status       RegistrationStatus `db:"status"`
+	// Guests количество гостей, каждый гость занимает место
+	Guests int `db:"guests"`
+	// ExpiresAt срок оплаты для регистрации в состоянии RegistrationPendingPayment
+	ExpiresAt *time.Time `db:"expires_at"`
+	// CheckedInAt момент прохода по билету, nil - пользователь ещё не пришёл
+	CheckedInAt *time.Time `db:"checked_in_at"`
+	// DecisionReason и DecidedAt причина и момент решения организатора по заявке
+	DecisionReason *string    `db:"decision_reason"`
+	DecidedAt      *time.Time `db:"decided_at"`
+	// InviteCodeID код приглашения, по которому выполнена регистрация на закрытое событие
+	InviteCodeID *string `db:"invite_code_id"`
+	// LinkID ссылка на бота, по которой пользователь пришёл к регистрации
+	LinkID *int64 `db:"link_id"`
+	// PriceAmount и Currency стоимость билетов на пользователя и гостей, заполняются repo-слоем при регистрации
+	PriceAmount int64  `db:"-"`
+	Currency    string `db:"-"`
+	// Payment платёж, созданный для оплаты регистрации
+	Payment *Payment `db:"-"`
+	// TicketToken подписанный токен билета, выпускается только для подтверждённой регистрации
+	TicketToken string `db:"-"`
+	// Answers ответы на анкету события, заполняются repo-слоем при выгрузке участников
+	Answers []Answer `db:"-"`
+	// User профиль пользователя, заполняется repo-слоем
+	User UserProfile `db:"-"`
+}
+
+// RegistrationRequest описывает запрос на регистрацию пользователя на событие
+type RegistrationRequest struct {
+	EventID string
+	// User данные пользователя из Telegram, по ним обновляется профиль пользователя
+	User UserProfile
+	// SessionID сессия события, обязательна для событий с сессиями
+	SessionID *string
+	// TicketTypeID тип билета, обязателен для событий с типами билетов
+	TicketTypeID *string
+	// Guests количество гостей, не больше максимума события
+	Guests int
+	// Answers проверенные ответы на анкету события
+	Answers []Answer
+	// InviteCode код приглашения, обязателен для закрытых событий
+	InviteCode string
+	// LinkID ссылка на бота, по которой пользователь пришёл к регистрации. Учитывается, только если
+	// ссылка ведёт на то же событие
+	LinkID *int64
+	// PaymentTTL срок оплаты платного билета, в течение которого место удерживается за пользователем
+	PaymentTTL time.Duration
+	RegistrationLimits
+}
+
+// RegistrationLimits описывает ограничения, которые проверяются при любом способе регистрации:
+// на событие, на серию и при автоматической регистрации подписчиков серии
+type RegistrationLimits struct {
+	// MaxNoShows количество неявок с момента NoShowsSince, при котором пользователь не может
+	// зарегистрироваться на события с ограниченной вместимостью, 0 - без ограничения
+	MaxNoShows   int
+	NoShowsSince time.Time
+	// EventRateLimit сколько регистраций на событие принимается с момента EventRateSince, 0 - без ограничения
+	EventRateLimit int
+	EventRateSince time.Time
 }
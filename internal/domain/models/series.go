@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Series описывает серию повторяющихся событий. Повторения создаются по правилу RRule
+// как обычные события и ссылаются на серию
+type Series struct {
+	ID          string `db:"id" json:"id"`
+	Title       string `db:"title" json:"title"`
+	Description string `db:"description" json:"description"`
+	// TimeZone часовой пояс серии, в нём вычисляется местное время повторений
+	TimeZone string `db:"time_zone" json:"time_zone"`
+	// StartsAt начало первого повторения
+	StartsAt time.Time `db:"starts_at" json:"starts_at"`
+	// RRule правило повторения в формате RFC 5545, например FREQ=WEEKLY;BYDAY=TU,TH
+	RRule     string    `db:"rrule" json:"rrule"`
+	VenueID   *string   `db:"venue_id" json:"venue_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	// Exceptions местные даты отменённых повторений в формате YYYY-MM-DD, заполняются repo-слоем
+	Exceptions []string `db:"-" json:"exceptions"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Session описывает сессию (сеанс, слот) внутри события со своим временем и вместимостью
+type Session struct {
+	ID       string    `db:"id" json:"id"`
+	EventID  string    `db:"event_id" json:"event_id"`
+	Title    string    `db:"title" json:"title"`
+	StartsAt time.Time `db:"starts_at" json:"starts_at"`
+	EndsAt   time.Time `db:"ends_at" json:"ends_at"`
+	// Capacity максимальное количество регистраций, nil - без ограничения
+	Capacity  *int      `db:"capacity" json:"capacity"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// Registered количество занятых мест (регистрации вместе с гостями), заполняется repo-слоем
+	Registered int `db:"registered" json:"registered"`
+}
+
+// Remaining возвращает количество свободных мест или nil, если вместимость не ограничена
+func (s Session) Remaining() *int {
+	if s.Capacity == nil {
+		return nil
+	}
+	remaining := max(*s.Capacity-s.Registered, 0)
+	return &remaining
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// TicketType описывает тип билета события (стандартный, VIP, студенческий) со своей вместимостью,
+// окном продаж и ценой
+type TicketType struct {
+	ID          string `db:"id" json:"id"`
+	EventID     string `db:"event_id" json:"event_id"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+	// PriceAmount цена в минимальных единицах валюты (копейках), 0 - бесплатный билет
+	PriceAmount int64  `db:"price_amount" json:"price_amount"`
+	Currency    string `db:"currency" json:"currency"`
+	// Capacity количество билетов, nil - без ограничения
+	Capacity *int `db:"capacity" json:"capacity"`
+	// SalesStartAt и SalesEndAt окно продаж, nil - без ограничения с соответствующей стороны
+	SalesStartAt *time.Time `db:"sales_start_at" json:"sales_start_at"`
+	SalesEndAt   *time.Time `db:"sales_end_at" json:"sales_end_at"`
+	Position     int        `db:"position" json:"position"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	// Sold количество проданных билетов (регистрации вместе с гостями), заполняется repo-слоем
+	Sold int `db:"sold" json:"sold"`
+	// OnSaleNow открыты ли продажи на момент запроса, заполняется сервисным слоем
+	OnSaleNow bool `db:"-" json:"-"`
+}
+
+// Remaining возвращает количество оставшихся билетов или nil, если количество не ограничено
+func (t TicketType) Remaining() *int {
+	if t.Capacity == nil {
+		return nil
+	}
+	remaining := max(*t.Capacity-t.Sold, 0)
+	return &remaining
+}
+
+// OnSale проверяет, что момент at попадает в окно продаж
+func (t TicketType) OnSale(at time.Time) bool {
+	if t.SalesStartAt != nil && at.Before(*t.SalesStartAt) {
+		return false
+	}
+	if t.SalesEndAt != nil && !at.Before(*t.SalesEndAt) {
+		return false
+	}
+	return true
+}
+
+// Ticket описывает билет пользователя - подписанный токен регистрации, который предъявляется на входе
+type Ticket struct {
+	RegistrationID string
+	EventID        string
+	SessionID      *string
+	ChatID         int64
+	Token          string
+	CheckedInAt    *time.Time
+}
+
+// CheckIn описывает сообщение о проходе по билету для публикации в NATS
+type CheckIn struct {
+	RegistrationID string    `json:"registration_id"`
+	EventID        string    `json:"event_id"`
+	SessionID      *string   `json:"session_id,omitempty"`
+	ChatID         int64     `json:"chat_id"`
+	Username       string    `json:"username"`
+	CheckedInAt    time.Time `json:"checked_in_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// EventTranslation описывает перевод текстов события на другой язык
+type EventTranslation struct {
+	EventID string `db:"event_id"`
+	// Language код языка IETF в нижнем регистре (например, en или pt-br)
+	Language    string    `db:"language"`
+	Title       string    `db:"title"`
+	Description string    `db:"description"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// NormalizeLanguage приводит код языка к виду, в котором хранятся переводы: нижний регистр, дефис
+// в качестве разделителя (pt_BR -> pt-br)
+func NormalizeLanguage(language string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"))
+}
+
+// BaseLanguage возвращает основной язык без региона (pt-br -> pt)
+func BaseLanguage(language string) string {
+	base, _, _ := strings.Cut(language, "-")
+	return base
+}
+
+// Translation возвращает перевод события на язык language или false, если его нет
+func (e Event) Translation(language string) (EventTranslation, bool) {
+	for _, t := range e.Translations {
+		if t.Language == language {
+			return t, true
+		}
+	}
+	return EventTranslation{}, false
+}
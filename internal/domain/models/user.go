@@ -5,4 +5,26 @@ type User struct {
 	ChatID   int64  `json:"chat_id"`
 	Username string `json:"username"`
 	EventID  string `json:"event_id"`
+	// SessionID сессия события, если регистрация выполнена на сессию
+	SessionID *string `json:"session_id,omitempty"`
+	// TicketTypeID тип билета, если у события есть типы билетов
+	TicketTypeID *string `json:"ticket_type_id,omitempty"`
+	// TicketToken токен билета для входа на событие
+	TicketToken string `json:"ticket_token,omitempty"`
+	// Guests количество гостей, которых пользователь приведёт с собой
+	Guests int `json:"guests,omitempty"`
+}
+
+// RegisteredUser возвращает сообщение о подтверждённой регистрации reg. Профиль пользователя и токен билета
+// должны быть заполнены в reg
+func RegisteredUser(reg Registration) User {
+	return User{
+		ChatID:       reg.ChatID,
+		Username:     reg.User.Username,
+		EventID:      reg.EventID,
+		SessionID:    reg.SessionID,
+		TicketTypeID: reg.TicketTypeID,
+		TicketToken:  reg.TicketToken,
+		Guests:       reg.Guests,
+	}
 }
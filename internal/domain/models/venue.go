@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Venue описывает площадку, на которой проходят события
+type Venue struct {
+	ID        string    `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name"`
+	Address   string    `db:"address" json:"address"`
+	City      string    `db:"city" json:"city"`
+	Latitude  float64   `db:"latitude" json:"latitude"`
+	Longitude float64   `db:"longitude" json:"longitude"`
+	Capacity  *int      `db:"capacity" json:"capacity,omitempty"`
+	TimeZone  *string   `db:"time_zone" json:"time_zone,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// NearbyEvent описывает событие, найденное рядом с указанной точкой
+type NearbyEvent struct {
+	Event
+	// DistanceKm расстояние от точки поиска до площадки в километрах
+	DistanceKm float64 `db:"distance_km"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// RegistrationState описывает состояние регистрации на событие относительно окна регистрации
+type RegistrationState string
+
+// Состояния окна регистрации
+const (
+	// RegistrationNotYetOpen регистрация ещё не открылась
+	RegistrationNotYetOpen RegistrationState = "not_yet_open"
+	// RegistrationOpen регистрация открыта
+	RegistrationOpen RegistrationState = "open"
+	// RegistrationClosed регистрация закрыта
+	RegistrationClosed RegistrationState = "closed"
+)
+
+// RegistrationWindow описывает окно регистрации на событие
+type RegistrationWindow struct {
+	StartsAt time.Time  `db:"starts_at"`
+	OpensAt  *time.Time `db:"registration_opens_at"`
+	ClosesAt *time.Time `db:"registration_closes_at"`
+	// ClosesBefore за сколько минут до начала события закрывается регистрация
+	ClosesBefore *int `db:"registration_closes_before"`
+}
+
+// Deadline возвращает момент закрытия регистрации - более ранний из ClosesAt и времени за ClosesBefore минут
+// до начала события, или nil, если регистрация не ограничена
+func (w RegistrationWindow) Deadline() *time.Time {
+	deadline := w.ClosesAt
+	if w.ClosesBefore != nil {
+		relative := w.StartsAt.Add(-time.Duration(*w.ClosesBefore) * time.Minute)
+		if deadline == nil || relative.Before(*deadline) {
+			deadline = &relative
+		}
+	}
+	return deadline
+}
+
+// State возвращает состояние регистрации в момент at
+func (w RegistrationWindow) State(at time.Time) RegistrationState {
+	if w.OpensAt != nil && at.Before(*w.OpensAt) {
+		return RegistrationNotYetOpen
+	}
+	if deadline := w.Deadline(); deadline != nil && !at.Before(*deadline) {
+		return RegistrationClosed
+	}
+	return RegistrationOpen
+}
+
+// RegistrationOpened описывает сообщение об открытии регистрации на событие для публикации в NATS
+type RegistrationOpened struct {
+	EventID  string    `json:"event_id"`
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	TimeZone string    `json:"time_zone"`
+	OpensAt  time.Time `json:"opens_at"`
+	// ClosesAt момент закрытия регистрации, отсутствует, если регистрация не ограничена
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+}
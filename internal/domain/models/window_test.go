@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegistrationWindowDeadline(t *testing.T) {
+	startsAt := time.Date(2026, 10, 20, 19, 0, 0, 0, time.UTC)
+	closesAt := startsAt.Add(-2 * time.Hour)
+	earlyClose, lateClose := 180, 60
+
+	tests := []struct {
+		name   string
+		window RegistrationWindow
+		want   *time.Time
+	}{
+		{
+			name:   "unlimited",
+			window: RegistrationWindow{StartsAt: startsAt},
+		},
+		{
+			name:   "closes at",
+			window: RegistrationWindow{StartsAt: startsAt, ClosesAt: &closesAt},
+			want:   &closesAt,
+		},
+		{
+			name:   "closes before start",
+			window: RegistrationWindow{StartsAt: startsAt, ClosesBefore: &lateClose},
+			want:   ptr(startsAt.Add(-time.Hour)),
+		},
+		{
+			name:   "closes at is earlier",
+			window: RegistrationWindow{StartsAt: startsAt, ClosesAt: &closesAt, ClosesBefore: &lateClose},
+			want:   &closesAt,
+		},
+		{
+			name:   "closes before start is earlier",
+			window: RegistrationWindow{StartsAt: startsAt, ClosesAt: &closesAt, ClosesBefore: &earlyClose},
+			want:   ptr(startsAt.Add(-3 * time.Hour)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.window.Deadline()
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil || !got.Equal(*tt.want):
+				t.Fatalf("Deadline = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrationWindowState(t *testing.T) {
+	startsAt := time.Date(2026, 10, 20, 19, 0, 0, 0, time.UTC)
+	opensAt := startsAt.Add(-24 * time.Hour)
+	closesBefore := 60
+	deadline := startsAt.Add(-time.Hour)
+	window := RegistrationWindow{StartsAt: startsAt, OpensAt: &opensAt, ClosesBefore: &closesBefore}
+
+	tests := []struct {
+		name   string
+		window RegistrationWindow
+		at     time.Time
+		want   RegistrationState
+	}{
+		{name: "before opening", window: window, at: opensAt.Add(-time.Second), want: RegistrationNotYetOpen},
+		{name: "at opening", window: window, at: opensAt, want: RegistrationOpen},
+		{name: "before deadline", window: window, at: deadline.Add(-time.Second), want: RegistrationOpen},
+		{name: "at deadline", window: window, at: deadline, want: RegistrationClosed},
+		{name: "after start", window: window, at: startsAt.Add(time.Hour), want: RegistrationClosed},
+		{name: "unlimited", window: RegistrationWindow{StartsAt: startsAt}, at: startsAt.Add(time.Hour), want: RegistrationOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.State(tt.at); got != tt.want {
+				t.Fatalf("State(%s) = %q, want %q", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
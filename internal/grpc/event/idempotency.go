@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/Telegram-bot-for-register-on-events/shared-proto/pb/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// mdIdempotencyKey ключ идемпотентности RegisterUser. Повтор запроса с тем же ключом получает сохранённый ответ,
+// регистрация и публикация сообщения не выполняются повторно
+const mdIdempotencyKey = "x-idempotency-key"
+
+// mdIdempotentReplayed "true" в ответе RegisterUser, если ответ взят из сохранённого по ключу идемпотентности
+const mdIdempotentReplayed = "x-idempotent-replayed"
+
+// idempotentMetadata ключи метаданных RegisterUser, которые входят в хеш запроса вместе с его телом
+var idempotentMetadata = []string{
+	mdRegistrationScope, mdSessionID, mdTicketTypeID, mdGuests, mdAnswers,
+	mdInviteCode, mdLinkToken, mdFirstName, mdLastName, mdLanguageCode,
+}
+
+// IdempotencyKeys описывает методы для работы с ключами идемпотентности
+type IdempotencyKeys interface {
+	Begin(ctx context.Context, key, requestHash string) ([]byte, error)
+	Complete(ctx context.Context, key, requestHash string, response []byte) error
+	Release(ctx context.Context, key, requestHash string) error
+}
+
+// committedError ошибка RegisterUser, возникшая после сохранения регистрации (например, не удалось опубликовать
+// сообщение). Ключ идемпотентности по такой ошибке не освобождается: повтор запроса получает успешный ответ
+// вместо повторной регистрации, которая завершилась бы AlreadyExists
+type committedError struct {
+	err error
+}
+
+func (e *committedError) Error() string { return e.err.Error() }
+
+func (e *committedError) Unwrap() error { return e.err }
+
+// storedResponse описывает сохранённый ответ RegisterUser: тело и метаданные
+type storedResponse struct {
+	Success bool                `json:"success"`
+	Header  map[string][]string `json:"header"`
+}
+
+// IdempotencyInterceptor выполняет RegisterUser с ключом идемпотентности из метаданных не больше одного раза:
+// повтор с тем же ключом и тем же запросом получает сохранённый ответ, с другим запросом - FailedPrecondition,
+// пока первый запрос выполняется - Aborted. Сохраняются успешные ответы и ответы с ошибкой после сохранения
+// регистрации, после остальных ошибок ключ освобождается
+func IdempotencyInterceptor(keys IdempotencyKeys) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		key := incomingValue(ctx, mdIdempotencyKey)
+		r, ok := req.(*event.RegisterUserRequest)
+		if info.FullMethod != event.EventService_RegisterUser_FullMethodName || key == "" || !ok {
+			return handler(ctx, req)
+		}
+		hash, err := requestHash(ctx, r)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		stored, err := keys.Begin(ctx, key, hash)
+		switch {
+		case errors.Is(err, service.ErrInvalidArgument):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrIdempotencyKeyReused):
+			return nil, status.Error(codes.FailedPrecondition, "idempotency key is already used with a different request")
+		case errors.Is(err, storage.ErrIdempotencyKeyBusy):
+			return nil, status.Error(codes.Aborted, "request with this idempotency key is in progress, retry later")
+		case err != nil:
+			return nil, status.Error(codes.Internal, "internal error")
+		case stored != nil:
+			return replay(ctx, stored)
+		}
+
+		recorder := &headerRecorder{ServerTransportStream: grpc.ServerTransportStreamFromContext(ctx), header: metadata.MD{}}
+		resp, err := handler(grpc.NewContextWithServerTransportStream(ctx, recorder), req)
+		// Ключ освобождается и ответ сохраняется, даже если клиент уже отменил запрос
+		ctx = context.WithoutCancel(ctx)
+		var committed *committedError
+		if err != nil && !errors.As(err, &committed) {
+			_ = keys.Release(ctx, key, hash)
+			return resp, err
+		}
+		out, _ := resp.(*event.RegisterUserResponse)
+		data, marshalErr := json.Marshal(storedResponse{
+			Success: out.GetSuccess() || committed != nil,
+			Header:  recorder.header,
+		})
+		// Ответ уже получен: если его не удалось сохранить, повтор после idempotencyStaleAfter выполнится заново
+		if marshalErr == nil {
+			_ = keys.Complete(ctx, key, hash, data)
+		}
+		return resp, err
+	}
+}
+
+// replay возвращает сохранённый ответ RegisterUser
+func replay(ctx context.Context, stored []byte) (*event.RegisterUserResponse, error) {
+	var resp storedResponse
+	if err := json.Unmarshal(stored, &resp); err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	header := metadata.MD(resp.Header).Copy()
+	header.Set(mdIdempotentReplayed, "true")
+	_ = grpc.SetHeader(ctx, header)
+	return &event.RegisterUserResponse{Success: resp.Success}, nil
+}
+
+// requestHash возвращает хеш тела запроса и метаданных, влияющих на регистрацию
+func requestHash(ctx context.Context, req *event.RegisterUserRequest) (string, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := make(map[string][]string, len(idempotentMetadata))
+	for _, key := range idempotentMetadata {
+		if v := md.Get(key); len(v) > 0 {
+			values[key] = v
+		}
+	}
+	data, err := json.Marshal(struct {
+		Body     []byte              `json:"body"`
+		Metadata map[string][]string `json:"metadata"`
+	}{body, values})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// headerRecorder запоминает метаданные ответа, которые устанавливает обработчик, и передаёт их дальше
+type headerRecorder struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+// SetHeader запоминает и устанавливает метаданные ответа
+func (h *headerRecorder) SetHeader(md metadata.MD) error {
+	h.header = metadata.Join(h.header, md)
+	if h.ServerTransportStream == nil {
+		return nil
+	}
+	return h.ServerTransportStream.SetHeader(md)
+}
+
+// SendHeader запоминает и отправляет метаданные ответа
+func (h *headerRecorder) SendHeader(md metadata.MD) error {
+	h.header = metadata.Join(h.header, md)
+	if h.ServerTransportStream == nil {
+		return nil
+	}
+	return h.ServerTransportStream.SendHeader(md)
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/Telegram-bot-for-register-on-events/shared-proto/pb/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// memoryKey состояние ключа идемпотентности в памяти
+type memoryKey struct {
+	hash     string
+	response []byte
+}
+
+// memoryKeys хранит ключи идемпотентности в памяти
+type memoryKeys map[string]*memoryKey
+
+func (m memoryKeys) Begin(_ context.Context, key, requestHash string) ([]byte, error) {
+	k, ok := m[key]
+	switch {
+	case !ok:
+		m[key] = &memoryKey{hash: requestHash}
+		return nil, nil
+	case k.hash != requestHash:
+		return nil, service.ErrIdempotencyKeyReused
+	case k.response == nil:
+		return nil, storage.ErrIdempotencyKeyBusy
+	}
+	return k.response, nil
+}
+
+func (m memoryKeys) Complete(_ context.Context, key, requestHash string, response []byte) error {
+	if k, ok := m[key]; ok && k.hash == requestHash {
+		k.response = response
+	}
+	return nil
+}
+
+func (m memoryKeys) Release(_ context.Context, key, requestHash string) error {
+	if k, ok := m[key]; ok && k.hash == requestHash && k.response == nil {
+		delete(m, key)
+	}
+	return nil
+}
+
+// transportStream запоминает метаданные ответа вместо отправки клиенту
+type transportStream struct {
+	header metadata.MD
+}
+
+func (s *transportStream) Method() string { return event.EventService_RegisterUser_FullMethodName }
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *transportStream) SetTrailer(metadata.MD) error { return nil }
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	first := &event.RegisterUserRequest{ChatId: 1, Username: "user", EventId: "event"}
+	other := &event.RegisterUserRequest{ChatId: 2, Username: "user", EventId: "event"}
+	published := &committedError{errors.New("publish failed")}
+
+	tests := []struct {
+		name string
+		// key ключ идемпотентности в метаданных обоих запросов
+		key string
+		// inProgress ключ уже занят тем же запросом, который ещё выполняется
+		inProgress bool
+		// errs ошибки обработчика по порядку вызовов, после них обработчик завершается успешно
+		errs   []error
+		second *event.RegisterUserRequest
+		// wantCalls сколько раз выполнен обработчик за два запроса
+		wantCalls    int
+		wantCode     codes.Code
+		wantReplayed bool
+	}{
+		{name: "replay", key: "key", second: first, wantCalls: 1, wantReplayed: true},
+		{name: "reused with another request", key: "key", second: other, wantCalls: 1, wantCode: codes.FailedPrecondition},
+		{name: "busy", key: "key", inProgress: true, second: first, wantCode: codes.Aborted},
+		{name: "released after error", key: "key", errs: []error{status.Error(codes.Unavailable, "unavailable")}, second: first, wantCalls: 2},
+		{name: "completed after commit", key: "key", errs: []error{published}, second: first, wantCalls: 1, wantReplayed: true},
+		{name: "without key", second: first, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := memoryKeys{}
+			interceptor := IdempotencyInterceptor(keys)
+			calls := 0
+			handler := func(ctx context.Context, _ any) (any, error) {
+				calls++
+				_ = grpc.SetHeader(ctx, metadata.Pairs(mdRegistrationStatus, "confirmed"))
+				if calls <= len(tt.errs) {
+					return &event.RegisterUserResponse{Success: false}, tt.errs[calls-1]
+				}
+				return &event.RegisterUserResponse{Success: true}, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: event.EventService_RegisterUser_FullMethodName}
+			call := func(req *event.RegisterUserRequest) (*event.RegisterUserResponse, *transportStream, error) {
+				stream := &transportStream{}
+				ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+				if tt.key != "" {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(mdIdempotencyKey, tt.key))
+				}
+				resp, err := interceptor(ctx, req, info, handler)
+				out, _ := resp.(*event.RegisterUserResponse)
+				return out, stream, err
+			}
+			if tt.inProgress {
+				hash, err := requestHash(metadata.NewIncomingContext(context.Background(), metadata.Pairs(mdIdempotencyKey, tt.key)), first)
+				if err != nil {
+					t.Fatalf("requestHash: %v", err)
+				}
+				keys[tt.key] = &memoryKey{hash: hash}
+			}
+
+			_, _, _ = call(first)
+			resp, stream, err := call(tt.second)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("second request code = %v, want %v (error %v)", status.Code(err), tt.wantCode, err)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("handler called %d times, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			if !resp.GetSuccess() {
+				t.Fatal("second request is not successful")
+			}
+			replayed := slices.Equal(stream.header.Get(mdIdempotentReplayed), []string{"true"})
+			if replayed != tt.wantReplayed {
+				t.Fatalf("replayed = %v, want %v", replayed, tt.wantReplayed)
+			}
+			if got := stream.header.Get(mdRegistrationStatus); !slices.Equal(got, []string{"confirmed"}) {
+				t.Fatalf("%s = %v, want [confirmed]", mdRegistrationStatus, got)
+			}
+		})
+	}
+}
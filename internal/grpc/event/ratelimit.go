@@ -0,0 +1,45 @@
+package events
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ratelimit"
+	"github.com/Telegram-bot-for-register-on-events/shared-proto/pb/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitInterceptor ограничивает частоту запросов RegisterUser: byChat - по chat_id пользователя,
+// byCaller - по IP-адресу клиента gRPC. nil Limiter отключает соответствующее ограничение.
+// Превышение лимита возвращает ResourceExhausted, остальные методы не ограничиваются
+func RateLimitInterceptor(byChat, byCaller *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if info.FullMethod != event.EventService_RegisterUser_FullMethodName {
+			return handler(ctx, req)
+		}
+		if !byCaller.Allow(callerID(ctx)) {
+			return nil, status.Error(codes.ResourceExhausted, "too many registration requests from client")
+		}
+		if r, ok := req.(*event.RegisterUserRequest); ok && !byChat.Allow(strconv.FormatInt(r.GetChatId(), 10)) {
+			return nil, status.Error(codes.ResourceExhausted, "too many registration requests for chat")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// callerID возвращает IP-адрес клиента gRPC, от которого пришёл запрос
+func callerID(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -3,29 +3,177 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/deeplink"
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
 	"github.com/Telegram-bot-for-register-on-events/shared-proto/pb/event"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Ключи метаданных запроса. GetEventsRequest из shared-proto не содержит полей,
+// поэтому фильтры списка событий передаются в метаданных
+const (
+	// mdCategory slug категории
+	mdCategory = "x-category"
+	// mdTags теги через запятую или несколькими значениями
+	mdTags = "x-tags"
+	// mdRegistrationScope область регистрации: event (по умолчанию) или series - на все будущие
+	// повторения серии, к которой относится событие
+	mdRegistrationScope = "x-registration-scope"
+	// mdSessionID сессия события, на которую регистрируется пользователь
+	mdSessionID = "x-session-id"
+	// mdTicketTypeID тип билета, обязателен для событий с типами билетов
+	mdTicketTypeID = "x-ticket-type-id"
+	// mdGuests количество гостей, которых пользователь приведёт с собой, по умолчанию 0
+	mdGuests = "x-guests"
+	// mdAnswers ответы на анкету события, JSON-объект "<question_id>": значение. Значение - строка, число,
+	// логическое значение или массив строк для вопросов с несколькими вариантами
+	mdAnswers = "x-answers-bin"
+	// mdInviteCode код приглашения на закрытое событие. Обязателен для регистрации на закрытое событие,
+	// в GetEvent позволяет получить закрытое событие, а при пустом event_id - найти событие по коду
+	mdInviteCode = "x-invite-code"
+	// mdLinkToken токен ссылки на бота из параметра start. В GetEvent при пустом event_id возвращает событие,
+	// на которое ведёт ссылка, и учитывает открытие ссылки, в RegisterUser - связывает регистрацию со ссылкой
+	mdLinkToken = "x-link-token"
+	// mdChatID пользователь, открывший ссылку на бота, в GetEvent с mdLinkToken. В GetEvents и GetEvent
+	// язык этого пользователя из профиля используется, если не передан mdLanguageCode
+	mdChatID = "x-chat-id"
+	// mdFirstName и mdLastName имя и фамилия пользователя из Telegram в RegisterUser. Могут содержать
+	// не-ASCII символы, поэтому используются бинарные ключи
+	mdFirstName = "x-first-name-bin"
+	mdLastName  = "x-last-name-bin"
+	// mdLanguageCode код языка интерфейса Telegram пользователя (например, ru). В RegisterUser сохраняется
+	// в профиле, в GetEvents и GetEvent - язык, на который переводятся тексты событий
+	mdLanguageCode = "x-language-code"
+)
+
+// Значения mdRegistrationScope
+const (
+	scopeEvent  = "event"
+	scopeSeries = "series"
+)
+
+// Ключи метаданных ответа. Сообщение Event из shared-proto не содержит часового пояса,
+// поэтому он передаётся в заголовках ответа
+const (
+	// mdEventTimeZone часовой пояс события в ответе GetEvent
+	mdEventTimeZone = "x-event-time-zone"
+	// mdEventTimeZones пары "<event_id>=<часовой пояс>" в ответе GetEvents
+	mdEventTimeZones = "x-event-time-zones"
+	// mdEventLanguage язык, на котором возвращены название и описание события, в ответе GetEvent
+	mdEventLanguage = "x-event-language"
+	// mdEventLanguages пары "<event_id>=<язык>" в ответе GetEvents
+	mdEventLanguages = "x-event-languages"
+	// mdEventSeriesID серия, повторением которой является событие, в ответе GetEvent
+	mdEventSeriesID = "x-event-series-id"
+	// mdEventSessions сессии события в ответе GetEvent, по одному JSON-объекту sessionMetadata на значение.
+	// Названия сессий могут содержать не-ASCII символы, поэтому используется бинарный ключ
+	mdEventSessions = "x-event-sessions-bin"
+	// mdEventTicketTypes типы билетов события с оставшимся количеством в ответе GetEvent,
+	// по одному JSON-объекту ticketTypeMetadata на значение
+	mdEventTicketTypes = "x-event-ticket-types-bin"
+	// mdEventQuestions вопросы анкеты регистрации в ответе GetEvent, по одному JSON-объекту questionMetadata на значение
+	mdEventQuestions = "x-event-questions-bin"
+	// mdEventRequiresApproval "true", если регистрации на событие подтверждает организатор, в ответе GetEvent
+	mdEventRequiresApproval = "x-event-requires-approval"
+	// mdEventRegistrationState состояние регистрации в ответе GetEvent: open, not_yet_open или closed.
+	// mdEventRegistrationOpensAt и mdEventRegistrationClosesAt - границы окна регистрации в RFC 3339,
+	// передаются, только если заданы
+	mdEventRegistrationState    = "x-event-registration-state"
+	mdEventRegistrationOpensAt  = "x-event-registration-opens-at"
+	mdEventRegistrationClosesAt = "x-event-registration-closes-at"
+	// mdEventVisibility видимость события в ответе GetEvent: public, unlisted или private
+	mdEventVisibility = "x-event-visibility"
+	// mdEventMaxGuests сколько гостей пользователь может привести на событие, в ответе GetEvent
+	mdEventMaxGuests = "x-event-max-guests"
+	// mdRegistrationStatus состояние регистрации в ответе RegisterUser: confirmed, pending_payment
+	// или pending_approval
+	mdRegistrationStatus = "x-registration-status"
+	// mdPayment платёж за регистрацию в ответе RegisterUser, JSON-объект paymentMetadata.
+	// Передаётся только для регистрации, ожидающей оплаты
+	mdPayment = "x-payment"
+	// mdTicket токен билета в ответе RegisterUser для подтверждённой регистрации на событие.
+	// QR-код билета доступен по HTTP: GET /tickets/<токен>.png
+	mdTicket = "x-ticket"
+)
+
+// sessionMetadata описывает сессию события в метаданных ответа
+type sessionMetadata struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Capacity  *int      `json:"capacity"`
+	Remaining *int      `json:"remaining"`
+}
+
+// ticketTypeMetadata описывает тип билета в метаданных ответа
+type ticketTypeMetadata struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	PriceAmount  int64      `json:"price_amount"`
+	Currency     string     `json:"currency"`
+	Capacity     *int       `json:"capacity"`
+	Remaining    *int       `json:"remaining"`
+	SalesStartAt *time.Time `json:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at"`
+	OnSale       bool       `json:"on_sale"`
+}
+
+// questionMetadata описывает вопрос анкеты в метаданных ответа
+type questionMetadata struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Label    string   `json:"label"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+// paymentMetadata описывает платёж в метаданных ответа. Место удерживается до expires_at,
+// после чего неоплаченная регистрация отменяется
+type paymentMetadata struct {
+	ID              string    `json:"id"`
+	ConfirmationURL string    `json:"confirmation_url"`
+	Amount          int64     `json:"amount"`
+	Currency        string    `json:"currency"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
 // EventService описывает методы для взаимодействия с сервисным слоем
 type EventService interface {
-	GetEvents(ctx context.Context) ([]*event.Event, error)
-	GetEvent(ctx context.Context, eventID string) (*event.Event, error)
+	GetEvents(ctx context.Context, filter models.EventFilter) ([]models.Event, error)
+	GetEvent(ctx context.Context, eventID string) (models.Event, error)
+	GetEventByInvite(ctx context.Context, code string) (models.Event, error)
+	Localize(ctx context.Context, language string, chatID *int64, events ...*models.Event)
 }
 
-// Registerer описывает метод для передачи данных о регистрации в сервисный слой
+// Registerer описывает методы для передачи данных о регистрации в сервисный слой
 type Registerer interface {
-	RegisterUser(ctx context.Context, eventID string, chatID int64, username string) error
+	RegisterUser(ctx context.Context, req models.RegistrationRequest) (models.Registration, error)
+	RegisterSeries(ctx context.Context, eventID string, user models.UserProfile) ([]models.Registration, error)
+}
+
+// DeepLinkResolver описывает методы для работы с токенами ссылок на бота
+type DeepLinkResolver interface {
+	ResolveDeepLink(ctx context.Context, token string, chatID *int64) (models.DeepLink, error)
+	DeepLinkID(token string) (int64, error)
 }
 
 // Publisher описывает метод для публикации сообщения в Nats
 type Publisher interface {
-	PublishMessage(topic string, data []byte) error
+	Publish(topic string, payload any) error
 }
 
 // serverAPI описывает API для взаимодействия с gRPC-сервером
@@ -34,55 +182,410 @@ type serverAPI struct {
 	events     EventService
 	publisher  Publisher
 	registerer Registerer
+	links      DeepLinkResolver
 }
 
 // Register регистрирует обработчик, который обрабатывает запросы, приходящие на gRPC-сервер
-func Register(grpc *grpc.Server, events EventService, publisher Publisher, registerer Registerer) {
-	event.RegisterEventServiceServer(grpc, &serverAPI{events: events, publisher: publisher, registerer: registerer})
+func Register(grpc *grpc.Server, events EventService, publisher Publisher, registerer Registerer, links DeepLinkResolver) {
+	event.RegisterEventServiceServer(grpc, &serverAPI{events: events, publisher: publisher, registerer: registerer, links: links})
 }
 
-// GetEvents обрабатывает входящий запрос на получение всех событий
+// GetEvents обрабатывает входящий запрос на получение всех событий. Тексты событий переводятся
+// на язык пользователя
 func (s *serverAPI) GetEvents(ctx context.Context, req *event.GetEventsRequest) (*event.GetEventsResponse, error) {
-	events, err := s.events.GetEvents(ctx)
+	filter := models.EventFilter{Tags: incomingValues(ctx, mdTags)}
+	if categories := incomingValues(ctx, mdCategory); len(categories) > 0 {
+		filter.Category = categories[0]
+	}
+	chatID, err := incomingChatID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	eventsDB, err := s.events.GetEvents(ctx, filter)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "internal error")
 	}
+	ptrs := make([]*models.Event, 0, len(eventsDB))
+	for i := range eventsDB {
+		ptrs = append(ptrs, &eventsDB[i])
+	}
+	s.events.Localize(ctx, incomingValue(ctx, mdLanguageCode), chatID, ptrs...)
+
+	// Преобразуем доменные структуры в protobuf-структуры
+	events := make([]*event.Event, 0, len(eventsDB))
+	zones := make([]string, 0, len(eventsDB))
+	languages := make([]string, 0, len(eventsDB))
+	for _, e := range eventsDB {
+		events = append(events, convertingEventsStruct(e))
+		zones = append(zones, e.ID+"="+e.TimeZone)
+		languages = append(languages, e.ID+"="+e.Language)
+	}
+	_ = grpc.SetHeader(ctx, metadata.MD{mdEventTimeZones: zones, mdEventLanguages: languages})
 	return &event.GetEventsResponse{Events: events}, nil
 }
 
-// GetEvent обрабатывает запрос на получение конкретного события
+// GetEvent обрабатывает запрос на получение конкретного события. С кодом приглашения в метаданных
+// возвращается событие, на которое выдан код, в том числе закрытое. С токеном ссылки на бота
+// возвращается событие, на которое ведёт ссылка. Тексты события переводятся на язык пользователя
 func (s *serverAPI) GetEvent(ctx context.Context, req *event.GetEventRequest) (*event.GetEventResponse, error) {
-	e, err := s.events.GetEvent(ctx, req.GetEventId())
+	chatID, err := incomingChatID(ctx)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var e models.Event
+	invites, tokens := incomingValues(ctx, mdInviteCode), incomingValues(ctx, mdLinkToken)
+	switch {
+	case len(invites) > 0:
+		e, err = s.events.GetEventByInvite(ctx, invites[0])
+		if err == nil && req.GetEventId() != "" && req.GetEventId() != e.ID {
+			err = storage.ErrInviteCodeNotFound
+		}
+	case len(tokens) > 0:
+		e, err = s.getEventByLink(ctx, tokens[0], chatID)
+	default:
+		e, err = s.events.GetEvent(ctx, req.GetEventId())
+	}
+	switch {
+	case errors.Is(err, deeplink.ErrInvalidToken), errors.Is(err, storage.ErrDeepLinkNotFound):
+		return nil, status.Error(codes.NotFound, "link not found")
+	case errors.Is(err, storage.ErrEventNotFound):
+		return nil, status.Error(codes.NotFound, "event not found")
+	case errors.Is(err, storage.ErrInviteCodeNotFound):
+		return nil, status.Error(codes.NotFound, "invite code not found")
+	case errors.Is(err, storage.ErrInviteCodeInvalid):
+		return nil, status.Error(codes.PermissionDenied, "invite code is expired, revoked or used up")
+	case err != nil:
 		return nil, status.Error(codes.Internal, "internal error")
 	}
-	return &event.GetEventResponse{Event: e}, nil
+	s.events.Localize(ctx, incomingValue(ctx, mdLanguageCode), chatID, &e)
+	header := metadata.Pairs(mdEventTimeZone, e.TimeZone, mdEventLanguage, e.Language, mdEventMaxGuests, strconv.Itoa(e.MaxGuests),
+		mdEventRequiresApproval, strconv.FormatBool(e.RequiresApproval),
+		mdEventRegistrationState, string(e.RegistrationState), mdEventVisibility, string(e.Visibility))
+	window := e.RegistrationWindow()
+	if window.OpensAt != nil {
+		header.Set(mdEventRegistrationOpensAt, window.OpensAt.UTC().Format(time.RFC3339))
+	}
+	if deadline := window.Deadline(); deadline != nil {
+		header.Set(mdEventRegistrationClosesAt, deadline.UTC().Format(time.RFC3339))
+	}
+	if e.SeriesID != nil {
+		header.Set(mdEventSeriesID, *e.SeriesID)
+	}
+	for _, session := range e.Sessions {
+		data, err := json.Marshal(sessionMetadata{
+			ID:        session.ID,
+			Title:     session.Title,
+			StartsAt:  session.StartsAt.UTC(),
+			EndsAt:    session.EndsAt.UTC(),
+			Capacity:  session.Capacity,
+			Remaining: session.Remaining(),
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		header.Append(mdEventSessions, string(data))
+	}
+	for _, t := range e.TicketTypes {
+		data, err := json.Marshal(ticketTypeMetadata{
+			ID:           t.ID,
+			Name:         t.Name,
+			Description:  t.Description,
+			PriceAmount:  t.PriceAmount,
+			Currency:     t.Currency,
+			Capacity:     t.Capacity,
+			Remaining:    t.Remaining(),
+			SalesStartAt: t.SalesStartAt,
+			SalesEndAt:   t.SalesEndAt,
+			OnSale:       t.OnSaleNow,
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		header.Append(mdEventTicketTypes, string(data))
+	}
+	for _, q := range e.Questions {
+		data, err := json.Marshal(questionMetadata{
+			ID:       q.ID,
+			Type:     string(q.Type),
+			Label:    q.Label,
+			Options:  q.Options,
+			Required: q.Required,
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		header.Append(mdEventQuestions, string(data))
+	}
+	_ = grpc.SetHeader(ctx, header)
+	return &event.GetEventResponse{Event: convertingEventsStruct(e)}, nil
+}
+
+// getEventByLink учитывает открытие ссылки на бота пользователем chatID и возвращает событие, на которое она ведёт
+func (s *serverAPI) getEventByLink(ctx context.Context, token string, chatID *int64) (models.Event, error) {
+	link, err := s.links.ResolveDeepLink(ctx, token, chatID)
+	if err != nil {
+		return models.Event{}, err
+	}
+	return s.events.GetEvent(ctx, link.EventID)
 }
 
 // RegisterUser обрабатывает запрос на регистрацию пользователя на конкретное событие
+// или на все повторения его серии. Регистрация с платным билетом ожидает оплаты: ссылка на оплату
+// передаётся в метаданных ответа, а сообщение о регистрации публикуется после подтверждения платежа.
+// На событие с одобрением организатора создаётся заявка, решение по ней публикуется отдельным сообщением
 func (s *serverAPI) RegisterUser(ctx context.Context, req *event.RegisterUserRequest) (*event.RegisterUserResponse, error) {
-	err := s.registerer.RegisterUser(ctx, req.GetEventId(), req.GetChatId(), req.GetUsername())
+	scope := scopeEvent
+	if values := incomingValues(ctx, mdRegistrationScope); len(values) > 0 {
+		scope = strings.ToLower(values[0])
+	}
+
+	profile := models.UserProfile{
+		ChatID:       req.GetChatId(),
+		Username:     req.GetUsername(),
+		FirstName:    incomingValue(ctx, mdFirstName),
+		LastName:     incomingValue(ctx, mdLastName),
+		LanguageCode: incomingValue(ctx, mdLanguageCode),
+	}
+	registration := models.RegistrationRequest{EventID: req.GetEventId(), User: profile}
+	if values := incomingValues(ctx, mdSessionID); len(values) > 0 {
+		registration.SessionID = &values[0]
+	}
+	if values := incomingValues(ctx, mdTicketTypeID); len(values) > 0 {
+		registration.TicketTypeID = &values[0]
+	}
+	if values := incomingValues(ctx, mdGuests); len(values) > 0 {
+		guests, err := strconv.Atoi(values[0])
+		if err != nil || guests < 0 {
+			return &event.RegisterUserResponse{Success: false}, status.Error(codes.InvalidArgument, mdGuests+" must be a non-negative integer")
+		}
+		registration.Guests = guests
+	}
+	answers, err := incomingAnswers(ctx)
+	if err != nil {
+		return &event.RegisterUserResponse{Success: false}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	registration.Answers = answers
+	if values := incomingValues(ctx, mdInviteCode); len(values) > 0 {
+		registration.InviteCode = values[0]
+	}
+	// Недействительный токен ссылки не мешает регистрации, она просто не связывается со ссылкой
+	if values := incomingValues(ctx, mdLinkToken); len(values) > 0 {
+		if linkID, err := s.links.DeepLinkID(values[0]); err == nil {
+			registration.LinkID = &linkID
+		}
+	}
+
+	var reg models.Registration
+	switch scope {
+	case scopeEvent:
+		reg, err = s.registerer.RegisterUser(ctx, registration)
+	case scopeSeries:
+		return s.registerSeries(ctx, req.GetEventId(), profile)
+	default:
+		return &event.RegisterUserResponse{Success: false}, status.Errorf(codes.InvalidArgument, "unknown registration scope %q", scope)
+	}
 	if err != nil {
-		return &event.RegisterUserResponse{Success: false}, fmt.Errorf("events.RegisterUser: %w", err)
+		return &event.RegisterUserResponse{Success: false}, registrationError(err)
+	}
+
+	header := metadata.Pairs(mdRegistrationStatus, string(reg.Status))
+	if p := reg.Payment; p != nil {
+		data, err := json.Marshal(paymentMetadata{
+			ID:              p.ID,
+			ConfirmationURL: p.ConfirmationURL,
+			Amount:          p.Amount,
+			Currency:        p.Currency,
+			ExpiresAt:       p.ExpiresAt.UTC(),
+		})
+		if err != nil {
+			return &event.RegisterUserResponse{Success: false}, &committedError{fmt.Errorf("events.RegisterUser: %w", err)}
+		}
+		header.Set(mdPayment, string(data))
+	}
+	if reg.TicketToken != "" {
+		header.Set(mdTicket, reg.TicketToken)
+	}
+	_ = grpc.SetHeader(ctx, header)
+	// О регистрациях, ожидающих оплаты или решения организатора, сообщается после подтверждения
+	if reg.Status != models.RegistrationConfirmed {
+		return &event.RegisterUserResponse{Success: true}, nil
 	}
 
 	// Формируем сообщение для публикации в шину данных
-	user := &models.User{
-		ChatID:   req.GetChatId(),
-		Username: req.GetUsername(),
-		EventID:  req.GetEventId(),
+	user := models.User{
+		ChatID:       req.GetChatId(),
+		Username:     req.GetUsername(),
+		EventID:      req.GetEventId(),
+		SessionID:    registration.SessionID,
+		TicketTypeID: registration.TicketTypeID,
+		TicketToken:  reg.TicketToken,
+		Guests:       reg.Guests,
 	}
 
-	// Сериализируем данные
-	jsonData, err := json.Marshal(user)
-	if err != nil {
-		return &event.RegisterUserResponse{Success: false}, fmt.Errorf("events.RegisterUser: %w", err)
+	// Публикуем сообщение в версионированном конверте
+	if err := s.publisher.Publish("register.user", user); err != nil {
+		return &event.RegisterUserResponse{Success: false}, &committedError{fmt.Errorf("events.RegisterUser: %w", err)}
 	}
+	return &event.RegisterUserResponse{Success: true}, nil
+}
 
-	// Публикуем сообщение
-	err = s.publisher.PublishMessage("register.user", jsonData)
+// registerSeries регистрирует пользователя на повторения серии и публикует сообщение о каждой созданной регистрации.
+// Состояние регистрации и токен билета передаются в метаданных ответа, только если запрошенное повторение
+// зарегистрировано этим запросом
+func (s *serverAPI) registerSeries(ctx context.Context, eventID string, profile models.UserProfile) (*event.RegisterUserResponse, error) {
+	regs, err := s.registerer.RegisterSeries(ctx, eventID, profile)
 	if err != nil {
-		return &event.RegisterUserResponse{Success: false}, fmt.Errorf("events.RegisterUser: %w", err)
+		return &event.RegisterUserResponse{Success: false}, registrationError(err)
+	}
+	for _, reg := range regs {
+		if strings.EqualFold(reg.EventID, eventID) {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(mdRegistrationStatus, string(reg.Status), mdTicket, reg.TicketToken))
+		}
+	}
+	for _, reg := range regs {
+		if err = s.publisher.Publish("register.user", models.RegisteredUser(reg)); err != nil {
+			return &event.RegisterUserResponse{Success: false}, &committedError{fmt.Errorf("events.RegisterUser: %w", err)}
+		}
 	}
 	return &event.RegisterUserResponse{Success: true}, nil
 }
+
+// registrationError преобразует ошибку регистрации в gRPC-статус
+func registrationError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrEventNotFound):
+		return status.Error(codes.NotFound, "event not found")
+	case errors.Is(err, storage.ErrAlreadyRegistered):
+		return status.Error(codes.AlreadyExists, "user already registered for event")
+	case errors.Is(err, storage.ErrSessionNotFound):
+		return status.Error(codes.NotFound, "session not found")
+	case errors.Is(err, storage.ErrSessionRequired):
+		return status.Error(codes.InvalidArgument, "event has sessions, pass session id in "+mdSessionID)
+	case errors.Is(err, storage.ErrSessionFull):
+		return status.Error(codes.ResourceExhausted, "session is full")
+	case errors.Is(err, storage.ErrSessionOverlap):
+		return status.Error(codes.FailedPrecondition, "user already registered for an overlapping session")
+	case errors.Is(err, storage.ErrTicketTypeNotFound):
+		return status.Error(codes.NotFound, "ticket type not found")
+	case errors.Is(err, storage.ErrTicketTypeRequired):
+		return status.Error(codes.InvalidArgument, "event has ticket types, pass ticket type id in "+mdTicketTypeID)
+	case errors.Is(err, storage.ErrTicketTypeSoldOut):
+		return status.Error(codes.ResourceExhausted, "ticket type is sold out")
+	case errors.Is(err, storage.ErrTicketSalesClosed):
+		return status.Error(codes.FailedPrecondition, "ticket sales are closed")
+	case errors.Is(err, service.ErrRegistrationNotOpen):
+		return status.Error(codes.FailedPrecondition, "registration is not open yet")
+	case errors.Is(err, service.ErrRegistrationClosed):
+		return status.Error(codes.FailedPrecondition, "registration is closed")
+	case errors.Is(err, service.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrQuestionNotFound):
+		return status.Error(codes.FailedPrecondition, "event questionnaire has changed, reload the event")
+	case errors.Is(err, storage.ErrTooManyGuests):
+		return status.Error(codes.InvalidArgument, "too many guests for event")
+	case errors.Is(err, storage.ErrChatBlocked):
+		return status.Error(codes.PermissionDenied, "user is blocked from registering")
+	case errors.Is(err, storage.ErrEventRateLimited):
+		return status.Error(codes.ResourceExhausted, "too many registrations for event, try again later")
+	case errors.Is(err, storage.ErrInviteCodeRequired):
+		return status.Error(codes.PermissionDenied, "event is private, pass invite code in "+mdInviteCode)
+	case errors.Is(err, storage.ErrInviteCodeInvalid):
+		return status.Error(codes.PermissionDenied, "invite code is invalid, expired, revoked or used up")
+	case errors.Is(err, storage.ErrTooManyNoShows):
+		return status.Error(codes.PermissionDenied, "registration is blocked due to repeated no-shows")
+	case errors.Is(err, storage.ErrNotSeriesEvent):
+		return status.Error(codes.FailedPrecondition, "event is not part of a series")
+	default:
+		return fmt.Errorf("events.RegisterUser: %w", err)
+	}
+}
+
+// convertingEventsStruct преобразует доменную структуру события в protobuf-структуру.
+// Время передаётся как момент в UTC, часовой пояс - в метаданных ответа
+func convertingEventsStruct(e models.Event) *event.Event {
+	return &event.Event{
+		Id:          e.ID,
+		Title:       e.Title,
+		Description: e.Description,
+		StartsAt:    timestamppb.New(e.StartsAt),
+	}
+}
+
+// incomingValues возвращает значения метаданных запроса по ключу, разбивая их по запятым
+func incomingValues(ctx context.Context, key string) []string {
+	var values []string
+	for _, v := range metadata.ValueFromIncomingContext(ctx, key) {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+// incomingValue возвращает первое значение метаданных запроса по ключу без разбиения по запятым,
+// пустую строку - если значение не передано
+func incomingValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// incomingChatID разбирает пользователя из метаданных запроса, nil - не передан
+func incomingChatID(ctx context.Context) (*int64, error) {
+	values := incomingValues(ctx, mdChatID)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return nil, errors.New(mdChatID + " must be an integer")
+	}
+	return &id, nil
+}
+
+// incomingAnswers разбирает ответы на анкету из метаданных запроса. Значения приводятся к строкам,
+// их проверка выполняется сервисным слоем
+func incomingAnswers(ctx context.Context) ([]models.Answer, error) {
+	values := metadata.ValueFromIncomingContext(ctx, mdAnswers)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(values[0]))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON object: %w", mdAnswers, err)
+	}
+
+	answers := make([]models.Answer, 0, len(raw))
+	for questionID, value := range raw {
+		answer := models.Answer{QuestionID: questionID}
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			answer.Values = []string{v}
+		case json.Number:
+			answer.Values = []string{v.String()}
+		case bool:
+			answer.Values = []string{strconv.FormatBool(v)}
+		case []any:
+			for _, item := range v {
+				str, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("answer to question %q must contain only strings", questionID)
+				}
+				answer.Values = append(answer.Values, str)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported answer to question %q", questionID)
+		}
+		answers = append(answers, answer)
+	}
+	return answers, nil
+}
@@ -0,0 +1,140 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opSetRequiresApproval = "admin.SetRequiresApproval"
+	opGetApplications     = "admin.GetApplications"
+	opApprove             = "admin.Approve"
+	opReject              = "admin.Reject"
+)
+
+// ApprovalManager описывает методы рассмотрения заявок на регистрацию
+type ApprovalManager interface {
+	SetRequiresApproval(ctx context.Context, eventID string, requiresApproval bool) error
+	GetApplications(ctx context.Context, eventID string) ([]models.Registration, error)
+	Approve(ctx context.Context, registrationID, reason string) (models.Registration, error)
+	Reject(ctx context.Context, registrationID, reason string) (models.Registration, error)
+}
+
+// requiresApprovalRequest описывает тело запроса на включение одобрения регистраций
+type requiresApprovalRequest struct {
+	RequiresApproval bool `json:"requires_approval"`
+}
+
+// decisionRequest описывает тело запроса на одобрение или отклонение заявки
+type decisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// applicationResponse описывает заявку на регистрацию в ответе API
+type applicationResponse struct {
+	RegistrationID string              `json:"registration_id"`
+	ChatID         int64               `json:"chat_id"`
+	Username       string              `json:"username"`
+	SessionID      *string             `json:"session_id,omitempty"`
+	TicketTypeID   *string             `json:"ticket_type_id,omitempty"`
+	Guests         int                 `json:"guests"`
+	AppliedAt      time.Time           `json:"applied_at"`
+	Answers        map[string][]string `json:"answers"`
+}
+
+// decisionResponse описывает результат решения по заявке
+type decisionResponse struct {
+	RegistrationID string     `json:"registration_id"`
+	Status         string     `json:"status"`
+	Reason         *string    `json:"reason"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	PaymentURL     string     `json:"payment_url,omitempty"`
+}
+
+// setRequiresApproval включает или выключает одобрение регистраций на событие
+func (h *handler) setRequiresApproval(w http.ResponseWriter, r *http.Request) {
+	var req requiresApprovalRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.Approvals.SetRequiresApproval(r.Context(), r.PathValue("id"), req.RequiresApproval); err != nil {
+		h.writeError(w, opSetRequiresApproval, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getApplications возвращает заявки на событие, ожидающие решения
+func (h *handler) getApplications(w http.ResponseWriter, r *http.Request) {
+	applications, err := h.Approvals.GetApplications(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opGetApplications, err)
+		return
+	}
+
+	resp := make([]applicationResponse, 0, len(applications))
+	for _, reg := range applications {
+		answers := make(map[string][]string, len(reg.Answers))
+		for _, a := range reg.Answers {
+			answers[a.QuestionID] = a.Values
+		}
+		resp = append(resp, applicationResponse{
+			RegistrationID: reg.ID,
+			ChatID:         reg.ChatID,
+			Username:       reg.User.Username,
+			SessionID:      reg.SessionID,
+			TicketTypeID:   reg.TicketTypeID,
+			Guests:         reg.Guests,
+			AppliedAt:      reg.CreatedAt.UTC(),
+			Answers:        answers,
+		})
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// approve одобряет заявку. Причина необязательна
+func (h *handler) approve(w http.ResponseWriter, r *http.Request) {
+	var req decisionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	reg, err := h.Approvals.Approve(r.Context(), r.PathValue("id"), req.Reason)
+	if err != nil {
+		h.writeError(w, opApprove, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, toDecisionResponse(reg))
+}
+
+// reject отклоняет заявку. Причина обязательна и передаётся пользователю
+func (h *handler) reject(w http.ResponseWriter, r *http.Request) {
+	var req decisionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	reg, err := h.Approvals.Reject(r.Context(), r.PathValue("id"), req.Reason)
+	if err != nil {
+		h.writeError(w, opReject, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, toDecisionResponse(reg))
+}
+
+// toDecisionResponse преобразует регистрацию после решения в ответ API
+func toDecisionResponse(reg models.Registration) decisionResponse {
+	resp := decisionResponse{
+		RegistrationID: reg.ID,
+		Status:         string(reg.Status),
+		Reason:         reg.DecisionReason,
+		DecidedAt:      reg.DecidedAt,
+	}
+	if reg.Payment != nil {
+		resp.PaymentURL = reg.Payment.ConfirmationURL
+	}
+	return resp
+}
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetUserAttendance  = "admin.GetUserAttendance"
+	opGetEventAttendance = "admin.GetEventAttendance"
+)
+
+// AttendanceReporter описывает методы для получения истории и статистики посещений
+type AttendanceReporter interface {
+	GetUserAttendance(ctx context.Context, chatID int64) (models.UserAttendance, error)
+	GetEventAttendance(ctx context.Context, eventID string) (models.EventAttendance, error)
+}
+
+// attendanceRecordResponse описывает запись истории посещений в ответе API
+type attendanceRecordResponse struct {
+	EventID       string    `json:"event_id"`
+	EventTitle    string    `json:"event_title"`
+	EventStartsAt time.Time `json:"event_starts_at"`
+	SessionID     *string   `json:"session_id,omitempty"`
+	Status        string    `json:"status"`
+	RecordedAt    time.Time `json:"recorded_at"`
+}
+
+// userAttendanceResponse описывает историю посещений пользователя в ответе API
+type userAttendanceResponse struct {
+	ChatID   int64                      `json:"chat_id"`
+	Attended int                        `json:"attended"`
+	NoShows  int                        `json:"no_shows"`
+	History  []attendanceRecordResponse `json:"history"`
+}
+
+// eventAttendanceResponse описывает статистику посещения события в ответе API
+type eventAttendanceResponse struct {
+	EventID    string `json:"event_id"`
+	Registered int    `json:"registered"`
+	Attended   int    `json:"attended"`
+	NoShows    int    `json:"no_shows"`
+	Unknown    int    `json:"unknown"`
+}
+
+// getUserAttendance возвращает историю посещений пользователя
+func (h *handler) getUserAttendance(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+
+	attendance, err := h.Attendance.GetUserAttendance(r.Context(), chatID)
+	if err != nil {
+		h.writeError(w, opGetUserAttendance, err)
+		return
+	}
+
+	resp := userAttendanceResponse{
+		ChatID:   attendance.ChatID,
+		Attended: attendance.Attended,
+		NoShows:  attendance.NoShows,
+		History:  make([]attendanceRecordResponse, 0, len(attendance.Records)),
+	}
+	for _, rec := range attendance.Records {
+		resp.History = append(resp.History, attendanceRecordResponse{
+			EventID:       rec.EventID,
+			EventTitle:    rec.EventTitle,
+			EventStartsAt: rec.EventStartsAt.UTC(),
+			SessionID:     rec.SessionID,
+			Status:        string(rec.Status),
+			RecordedAt:    rec.RecordedAt.UTC(),
+		})
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// getEventAttendance возвращает статистику посещения события
+func (h *handler) getEventAttendance(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.Attendance.GetEventAttendance(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opGetEventAttendance, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, eventAttendanceResponse{
+		EventID:    stats.EventID,
+		Registered: stats.Registered,
+		Attended:   stats.Attended,
+		NoShows:    stats.NoShows,
+		Unknown:    stats.Unknown,
+	})
+}
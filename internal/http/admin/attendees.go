@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetAttendees = "admin.GetAttendees"
+)
+
+// AttendeeExporter описывает метод выгрузки участников события
+type AttendeeExporter interface {
+	GetAttendees(ctx context.Context, eventID string) (models.AttendeeList, error)
+}
+
+// answerSeparator разделитель нескольких выбранных вариантов в CSV
+const answerSeparator = "; "
+
+// attendeeResponse описывает участника события в ответе API. answers - ответы на анкету
+// по идентификаторам вопросов
+type attendeeResponse struct {
+	RegistrationID string              `json:"registration_id"`
+	ChatID         int64               `json:"chat_id"`
+	Username       string              `json:"username"`
+	FirstName      string              `json:"first_name"`
+	LastName       string              `json:"last_name"`
+	Status         string              `json:"status"`
+	SessionID      *string             `json:"session_id,omitempty"`
+	TicketTypeID   *string             `json:"ticket_type_id,omitempty"`
+	Guests         int                 `json:"guests"`
+	RegisteredAt   time.Time           `json:"registered_at"`
+	CheckedInAt    *time.Time          `json:"checked_in_at"`
+	Answers        map[string][]string `json:"answers"`
+}
+
+// attendeesResponse описывает выгрузку участников события в ответе API
+type attendeesResponse struct {
+	EventID   string             `json:"event_id"`
+	Questions []models.Question  `json:"questions"`
+	Attendees []attendeeResponse `json:"attendees"`
+}
+
+// getAttendees выгружает участников события с ответами на анкету. По умолчанию в JSON,
+// с параметром format=csv - в CSV с отдельной колонкой на каждый вопрос
+func (h *handler) getAttendees(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		response.Error(w, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	list, err := h.Attendees.GetAttendees(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opGetAttendees, err)
+		return
+	}
+	if format == "csv" {
+		h.writeAttendeesCSV(w, list)
+		return
+	}
+
+	resp := attendeesResponse{
+		EventID:   list.EventID,
+		Questions: list.Questions,
+		Attendees: make([]attendeeResponse, 0, len(list.Registrations)),
+	}
+	for _, reg := range list.Registrations {
+		answers := make(map[string][]string, len(reg.Answers))
+		for _, a := range reg.Answers {
+			answers[a.QuestionID] = a.Values
+		}
+		resp.Attendees = append(resp.Attendees, attendeeResponse{
+			RegistrationID: reg.ID,
+			ChatID:         reg.ChatID,
+			Username:       reg.User.Username,
+			FirstName:      reg.User.FirstName,
+			LastName:       reg.User.LastName,
+			Status:         string(reg.Status),
+			SessionID:      reg.SessionID,
+			TicketTypeID:   reg.TicketTypeID,
+			Guests:         reg.Guests,
+			RegisteredAt:   reg.CreatedAt.UTC(),
+			CheckedInAt:    reg.CheckedInAt,
+			Answers:        answers,
+		})
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// writeAttendeesCSV записывает участников события в CSV. Заголовки колонок ответов - тексты вопросов
+func (h *handler) writeAttendeesCSV(w http.ResponseWriter, list models.AttendeeList) {
+	header := []string{"registration_id", "chat_id", "username", "first_name", "last_name", "status", "session_id", "ticket_type_id",
+		"guests", "registered_at", "checked_in_at"}
+	for _, q := range list.Questions {
+		header = append(header, q.Label)
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="attendees-`+list.EventID+`.csv"`)
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	for _, reg := range list.Registrations {
+		answers := make(map[string]string, len(reg.Answers))
+		for _, a := range reg.Answers {
+			answers[a.QuestionID] = strings.Join(a.Values, answerSeparator)
+		}
+		record := []string{
+			reg.ID,
+			strconv.FormatInt(reg.ChatID, 10),
+			reg.User.Username,
+			reg.User.FirstName,
+			reg.User.LastName,
+			string(reg.Status),
+			optionalString(reg.SessionID),
+			optionalString(reg.TicketTypeID),
+			strconv.Itoa(reg.Guests),
+			reg.CreatedAt.UTC().Format(time.RFC3339),
+			optionalTime(reg.CheckedInAt),
+		}
+		for _, q := range list.Questions {
+			record = append(record, answers[q.ID])
+		}
+		_ = cw.Write(record)
+	}
+	cw.Flush()
+}
+
+// optionalString возвращает значение строки или пустую строку для nil
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// optionalTime возвращает время в RFC 3339 или пустую строку для nil
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetBlockedChats = "admin.GetBlockedChats"
+	opBlockChat       = "admin.BlockChat"
+	opUnblockChat     = "admin.UnblockChat"
+)
+
+// BlocklistManager описывает методы управления блокировками пользователей
+type BlocklistManager interface {
+	GetBlockedChats(ctx context.Context) ([]models.BlockedChat, error)
+	BlockChat(ctx context.Context, chatID int64, reason string, until *time.Time) (models.BlockedChat, error)
+	UnblockChat(ctx context.Context, chatID int64) error
+}
+
+// blockChatRequest описывает тело запроса на блокировку пользователя. blocked_until null - бессрочно
+type blockChatRequest struct {
+	Reason       string     `json:"reason"`
+	BlockedUntil *time.Time `json:"blocked_until"`
+}
+
+// blockedChatResponse описывает блокировку пользователя в ответе API
+type blockedChatResponse struct {
+	ChatID       int64      `json:"chat_id"`
+	Reason       string     `json:"reason"`
+	BlockedUntil *time.Time `json:"blocked_until"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// getBlockedChats возвращает действующие блокировки
+func (h *handler) getBlockedChats(w http.ResponseWriter, r *http.Request) {
+	chats, err := h.Blocklist.GetBlockedChats(r.Context())
+	if err != nil {
+		h.writeError(w, opGetBlockedChats, err)
+		return
+	}
+
+	resp := make([]blockedChatResponse, 0, len(chats))
+	for _, c := range chats {
+		resp = append(resp, toBlockedChatResponse(c))
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// blockChat блокирует пользователя или изменяет причину и срок блокировки
+func (h *handler) blockChat(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+	var req blockChatRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	blocked, err := h.Blocklist.BlockChat(r.Context(), chatID, req.Reason, req.BlockedUntil)
+	if err != nil {
+		h.writeError(w, opBlockChat, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, toBlockedChatResponse(blocked))
+}
+
+// unblockChat снимает блокировку пользователя
+func (h *handler) unblockChat(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+
+	if err = h.Blocklist.UnblockChat(r.Context(), chatID); err != nil {
+		h.writeError(w, opUnblockChat, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toBlockedChatResponse преобразует доменную структуру в ответ API
+func toBlockedChatResponse(c models.BlockedChat) blockedChatResponse {
+	resp := blockedChatResponse{
+		ChatID:    c.ChatID,
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt.UTC(),
+	}
+	if c.BlockedUntil != nil {
+		until := c.BlockedUntil.UTC()
+		resp.BlockedUntil = &until
+	}
+	return resp
+}
@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opGetTickets = "admin.GetTickets"
+	opCheckIn    = "admin.CheckIn"
+)
+
+// CheckInManager описывает методы для выдачи билетов и отметки прохода на событие
+type CheckInManager interface {
+	GetTickets(ctx context.Context, eventID string, chatID int64) ([]models.Ticket, error)
+	CheckIn(ctx context.Context, eventID, token string) (models.Registration, error)
+}
+
+// ticketResponse описывает билет в ответе API
+type ticketResponse struct {
+	RegistrationID string     `json:"registration_id"`
+	SessionID      *string    `json:"session_id,omitempty"`
+	Token          string     `json:"token"`
+	QRCodePath     string     `json:"qr_code_path"`
+	CheckedInAt    *time.Time `json:"checked_in_at"`
+}
+
+// checkInRequest описывает тело запроса на отметку прохода
+type checkInRequest struct {
+	Token string `json:"token"`
+}
+
+// checkInResponse описывает результат проверки билета на входе
+type checkInResponse struct {
+	RegistrationID string    `json:"registration_id"`
+	SessionID      *string   `json:"session_id,omitempty"`
+	ChatID         int64     `json:"chat_id"`
+	Username       string    `json:"username"`
+	CheckedInAt    time.Time `json:"checked_in_at"`
+}
+
+// getTickets возвращает билеты чата на событие для отправки пользователю
+func (h *handler) getTickets(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+
+	tickets, err := h.CheckIns.GetTickets(r.Context(), r.PathValue("id"), chatID)
+	if err != nil {
+		h.writeError(w, opGetTickets, err)
+		return
+	}
+
+	resp := make([]ticketResponse, 0, len(tickets))
+	for _, t := range tickets {
+		resp = append(resp, ticketResponse{
+			RegistrationID: t.RegistrationID,
+			SessionID:      t.SessionID,
+			Token:          t.Token,
+			QRCodePath:     "/tickets/" + t.Token + ".png",
+			CheckedInAt:    t.CheckedInAt,
+		})
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// checkIn проверяет билет, предъявленный на входе на событие, и отмечает проход.
+// Повторный проход по тому же билету отклоняется с кодом 409 и временем первого прохода
+func (h *handler) checkIn(w http.ResponseWriter, r *http.Request) {
+	var req checkInRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	reg, err := h.CheckIns.CheckIn(r.Context(), r.PathValue("id"), req.Token)
+	if errors.Is(err, storage.ErrAlreadyCheckedIn) && reg.CheckedInAt != nil {
+		response.JSON(w, http.StatusConflict, map[string]any{
+			"error":         response.Message(err, storage.ErrAlreadyCheckedIn),
+			"checked_in_at": reg.CheckedInAt.UTC(),
+		})
+		return
+	}
+	if err != nil {
+		h.writeError(w, opCheckIn, err)
+		return
+	}
+
+	resp := checkInResponse{
+		RegistrationID: reg.ID,
+		SessionID:      reg.SessionID,
+		ChatID:         reg.ChatID,
+		Username:       reg.User.Username,
+	}
+	if reg.CheckedInAt != nil {
+		resp.CheckedInAt = reg.CheckedInAt.UTC()
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opCreateDeepLink = "admin.CreateDeepLink"
+	opGetDeepLinks   = "admin.GetDeepLinks"
+)
+
+// DeepLinkManager описывает методы управления ссылками на бота
+type DeepLinkManager interface {
+	CreateDeepLink(ctx context.Context, eventID, campaign, source string) (models.DeepLink, error)
+	GetDeepLinks(ctx context.Context, eventID string) ([]models.DeepLink, error)
+}
+
+// deepLinkRequest описывает тело запроса на создание ссылки на бота
+type deepLinkRequest struct {
+	Campaign string `json:"campaign"`
+	Source   string `json:"source"`
+}
+
+// deepLinkResponse описывает ссылку на бота со статистикой в ответе API. conversion - доля пользователей,
+// открывших ссылку и зарегистрировавшихся по ней
+type deepLinkResponse struct {
+	ID            int64     `json:"id"`
+	Token         string    `json:"token"`
+	Link          string    `json:"link,omitempty"`
+	Campaign      string    `json:"campaign"`
+	Source        string    `json:"source"`
+	Clicks        int       `json:"clicks"`
+	Visitors      int       `json:"visitors"`
+	Registrations int       `json:"registrations"`
+	Conversion    float64   `json:"conversion"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// createDeepLink создаёт ссылку на бота для события
+func (h *handler) createDeepLink(w http.ResponseWriter, r *http.Request) {
+	var req deepLinkRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	link, err := h.DeepLinks.CreateDeepLink(r.Context(), r.PathValue("id"), req.Campaign, req.Source)
+	if err != nil {
+		h.writeError(w, opCreateDeepLink, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, toDeepLinkResponse(link))
+}
+
+// getDeepLinks возвращает ссылки на событие с конверсией из открытий в регистрации
+func (h *handler) getDeepLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.DeepLinks.GetDeepLinks(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opGetDeepLinks, err)
+		return
+	}
+	resp := make([]deepLinkResponse, 0, len(links))
+	for _, link := range links {
+		resp = append(resp, toDeepLinkResponse(link))
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// toDeepLinkResponse преобразует ссылку на бота в ответ API
+func toDeepLinkResponse(l models.DeepLink) deepLinkResponse {
+	return deepLinkResponse{
+		ID:            l.ID,
+		Token:         l.Token,
+		Link:          l.Link,
+		Campaign:      l.Campaign,
+		Source:        l.Source,
+		Clicks:        l.Clicks,
+		Visitors:      l.Visitors,
+		Registrations: l.Registrations,
+		Conversion:    l.Conversion(),
+		CreatedAt:     l.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opIssueFeedToken = "admin.IssueFeedToken"
+)
+
+// FeedIssuer описывает метод для выпуска токена календарной подписки
+type FeedIssuer interface {
+	IssueFeedToken(ctx context.Context, chatID int64) (string, error)
+}
+
+// feedTokenResponse описывает ответ с токеном календарной подписки
+type feedTokenResponse struct {
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+// issueFeedToken выпускает (или перевыпускает) токен календарной подписки для чата
+func (h *handler) issueFeedToken(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+
+	token, err := h.Feeds.IssueFeedToken(r.Context(), chatID)
+	if err != nil {
+		h.writeError(w, opIssueFeedToken, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, feedTokenResponse{
+		Token: token,
+		Path:  "/calendar/feeds/" + token + ".ics",
+	})
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opSetEventMaxGuests = "admin.SetEventMaxGuests"
+	opUpdateGuests      = "admin.UpdateGuests"
+)
+
+// GuestManager описывает методы управления гостями регистраций
+type GuestManager interface {
+	SetEventMaxGuests(ctx context.Context, eventID string, maxGuests int) error
+	UpdateGuests(ctx context.Context, eventID string, chatID int64, sessionID *string, guests int) (models.Registration, error)
+}
+
+// eventMaxGuestsRequest описывает тело запроса на изменение максимума гостей события
+type eventMaxGuestsRequest struct {
+	MaxGuests int `json:"max_guests"`
+}
+
+// guestsRequest описывает тело запроса на изменение количества гостей. session_id указывается
+// для регистрации на сессию события
+type guestsRequest struct {
+	Guests    int     `json:"guests"`
+	SessionID *string `json:"session_id"`
+}
+
+// guestsResponse описывает регистрацию с гостями в ответе API
+type guestsResponse struct {
+	RegistrationID string  `json:"registration_id"`
+	SessionID      *string `json:"session_id,omitempty"`
+	ChatID         int64   `json:"chat_id"`
+	Guests         int     `json:"guests"`
+	Seats          int     `json:"seats"`
+}
+
+// setEventMaxGuests задаёт, сколько гостей пользователь может привести на событие
+func (h *handler) setEventMaxGuests(w http.ResponseWriter, r *http.Request) {
+	var req eventMaxGuestsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.Guests.SetEventMaxGuests(r.Context(), r.PathValue("id"), req.MaxGuests); err != nil {
+		h.writeError(w, opSetEventMaxGuests, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateGuests изменяет количество гостей в регистрации пользователя. Регистрация и её место
+// в очереди сохраняются
+func (h *handler) updateGuests(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+	var req guestsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	reg, err := h.Guests.UpdateGuests(r.Context(), r.PathValue("id"), chatID, req.SessionID, req.Guests)
+	if err != nil {
+		h.writeError(w, opUpdateGuests, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, guestsResponse{
+		RegistrationID: reg.ID,
+		SessionID:      reg.SessionID,
+		ChatID:         reg.ChatID,
+		Guests:         reg.Guests,
+		Seats:          1 + reg.Guests,
+	})
+}
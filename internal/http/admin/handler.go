@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ticket"
+)
+
+// maxBodySize максимальный размер JSON-тела запроса
+const maxBodySize = 1 << 20
+
+// Services описывает зависимости административного API
+type Services struct {
+	Importer     Importer
+	Feeds        FeedIssuer
+	Venues       VenueManager
+	Taxonomy     TaxonomyManager
+	Series       SeriesManager
+	Sessions     SessionManager
+	TicketTypes  TicketTypeManager
+	CheckIns     CheckInManager
+	Attendance   AttendanceReporter
+	Guests       GuestManager
+	Questions    QuestionManager
+	Attendees    AttendeeExporter
+	Approvals    ApprovalManager
+	Windows      RegistrationWindowManager
+	Invites      InviteManager
+	DeepLinks    DeepLinkManager
+	Users        UserReader
+	Translations TranslationManager
+	Blocklist    BlocklistManager
+}
+
+// handler описывает административное HTTP API
+type handler struct {
+	log *slog.Logger
+	Services
+}
+
+// Register регистрирует административные обработчики. Все они требуют заголовок Authorization: Bearer <token>
+func Register(mux *http.ServeMux, log *slog.Logger, token string, services Services) {
+	h := &handler{log: log, Services: services}
+	handle := func(pattern string, fn http.HandlerFunc) {
+		mux.Handle(pattern, authorize(token, fn))
+	}
+
+	handle("POST /admin/events/import", h.importEvents)
+	handle("POST /admin/calendar/feeds/{chat_id}", h.issueFeedToken)
+	handle("GET /admin/venues", h.getVenues)
+	handle("POST /admin/venues", h.createVenue)
+	handle("PUT /admin/venues/{id}", h.updateVenue)
+	handle("PUT /admin/events/{id}/venue", h.setEventVenue)
+	handle("GET /admin/categories", h.getCategories)
+	handle("POST /admin/categories", h.createCategory)
+	handle("PUT /admin/categories/{id}", h.updateCategory)
+	handle("DELETE /admin/categories/{id}", h.deleteCategory)
+	handle("PUT /admin/events/{id}/categories", h.setEventCategories)
+	handle("PUT /admin/events/{id}/tags", h.setEventTags)
+	handle("GET /admin/series", h.getSeries)
+	handle("POST /admin/series", h.createSeries)
+	handle("PUT /admin/series/{id}", h.updateSeries)
+	handle("POST /admin/events/{id}/sessions", h.createSession)
+	handle("PUT /admin/sessions/{id}", h.updateSession)
+	handle("DELETE /admin/sessions/{id}", h.deleteSession)
+	handle("POST /admin/events/{id}/ticket-types", h.createTicketType)
+	handle("PUT /admin/ticket-types/{id}", h.updateTicketType)
+	handle("DELETE /admin/ticket-types/{id}", h.deleteTicketType)
+	handle("GET /admin/events/{id}/tickets/{chat_id}", h.getTickets)
+	handle("POST /admin/events/{id}/check-in", h.checkIn)
+	handle("GET /admin/events/{id}/attendance", h.getEventAttendance)
+	handle("GET /admin/users/{chat_id}", h.getUser)
+	handle("GET /admin/users/{chat_id}/attendance", h.getUserAttendance)
+	handle("PUT /admin/events/{id}/max-guests", h.setEventMaxGuests)
+	handle("PUT /admin/events/{id}/registrations/{chat_id}/guests", h.updateGuests)
+	handle("POST /admin/events/{id}/questions", h.createQuestion)
+	handle("PUT /admin/questions/{id}", h.updateQuestion)
+	handle("DELETE /admin/questions/{id}", h.deleteQuestion)
+	handle("GET /admin/events/{id}/attendees", h.getAttendees)
+	handle("PUT /admin/events/{id}/approval", h.setRequiresApproval)
+	handle("GET /admin/events/{id}/applications", h.getApplications)
+	handle("POST /admin/registrations/{id}/approve", h.approve)
+	handle("POST /admin/registrations/{id}/reject", h.reject)
+	handle("PUT /admin/events/{id}/registration-window", h.setRegistrationWindow)
+	handle("PUT /admin/events/{id}/visibility", h.setVisibility)
+	handle("GET /admin/events/{id}/invites", h.getInvites)
+	handle("POST /admin/events/{id}/invites", h.createInvite)
+	handle("DELETE /admin/invites/{id}", h.revokeInvite)
+	handle("GET /admin/events/{id}/links", h.getDeepLinks)
+	handle("POST /admin/events/{id}/links", h.createDeepLink)
+	handle("GET /admin/events/{id}/translations", h.getTranslations)
+	handle("PUT /admin/events/{id}/translations/{language}", h.setTranslation)
+	handle("DELETE /admin/events/{id}/translations/{language}", h.deleteTranslation)
+	handle("GET /admin/blocklist", h.getBlockedChats)
+	handle("PUT /admin/blocklist/{chat_id}", h.blockChat)
+	handle("DELETE /admin/blocklist/{chat_id}", h.unblockChat)
+}
+
+// authorize проверяет токен администратора
+func authorize(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			response.Error(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeJSON читает JSON-тело запроса
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// errorStatuses сопоставляет известные ошибки сервисного и repo-слоя HTTP-статусам
+var errorStatuses = []struct {
+	status int
+	errs   []error
+}{
+	{http.StatusBadRequest, []error{service.ErrInvalidArgument}},
+	{http.StatusForbidden, []error{service.ErrTicketForOtherEvent, storage.ErrRegistrationNotConfirmed}},
+	{http.StatusNotFound, []error{
+		storage.ErrEventNotFound, storage.ErrVenueNotFound, storage.ErrCategoryNotFound, storage.ErrSeriesNotFound,
+		storage.ErrSessionNotFound, storage.ErrTicketTypeNotFound, storage.ErrRegistrationNotFound,
+		storage.ErrQuestionNotFound, storage.ErrInviteCodeNotFound, storage.ErrUserNotFound,
+		storage.ErrTranslationNotFound, storage.ErrChatNotBlocked,
+	}},
+	{http.StatusConflict, []error{
+		storage.ErrCategoryExists, storage.ErrSessionInUse, storage.ErrTicketTypeExists, storage.ErrTicketTypeInUse,
+		storage.ErrQuestionInUse, storage.ErrApplicationDecided, storage.ErrAlreadyCheckedIn, storage.ErrTooManyGuests,
+		storage.ErrGuestsPaidTicket, storage.ErrSessionFull, storage.ErrTicketTypeSoldOut,
+	}},
+}
+
+// writeError преобразует ошибку сервисного слоя в HTTP-ответ. Клиент получает текст известной ошибки
+// без цепочки операций, остальные ошибки логируются и возвращаются как внутренние
+func (h *handler) writeError(w http.ResponseWriter, op string, err error) {
+	if errors.Is(err, ticket.ErrInvalidToken) {
+		response.Error(w, http.StatusForbidden, "invalid ticket")
+		return
+	}
+	for _, s := range errorStatuses {
+		for _, target := range s.errs {
+			if errors.Is(err, target) {
+				response.Error(w, s.status, response.Message(err, target))
+				return
+			}
+		}
+	}
+	h.log.Error("error", err.Error(), slog.String("operation", op))
+	response.Error(w, http.StatusInternalServerError, "internal error")
+}
@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ticket"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "not found",
+			err:        fmt.Errorf("service.CheckIn: %w", fmt.Errorf("postgres.checkIn: %w", storage.ErrRegistrationNotFound)),
+			wantStatus: http.StatusNotFound,
+			wantError:  storage.ErrRegistrationNotFound.Error(),
+		},
+		{
+			name:       "invalid argument keeps details",
+			err:        fmt.Errorf("service.Reject: %w: rejection reason is required", service.ErrInvalidArgument),
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid argument: rejection reason is required",
+		},
+		{
+			name:       "conflict",
+			err:        fmt.Errorf("service.Approve: %w", fmt.Errorf("postgres.approve: %w", storage.ErrApplicationDecided)),
+			wantStatus: http.StatusConflict,
+			wantError:  storage.ErrApplicationDecided.Error(),
+		},
+		{
+			name:       "invalid ticket",
+			err:        fmt.Errorf("service.CheckIn: %w", ticket.ErrInvalidToken),
+			wantStatus: http.StatusForbidden,
+			wantError:  "invalid ticket",
+		},
+		{
+			name:       "internal",
+			err:        fmt.Errorf("postgres.checkIn: %w", errors.New("connection refused")),
+			wantStatus: http.StatusInternalServerError,
+			wantError:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handler{log: slog.New(slog.DiscardHandler)}
+			w := httptest.NewRecorder()
+			h.writeError(w, "test", tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Error != tt.wantError {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantError)
+			}
+		})
+	}
+}
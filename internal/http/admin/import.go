@@ -0,0 +1,69 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/importer"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+)
+
+// Константы для описания операций
+const (
+	opImportEvents = "admin.ImportEvents"
+)
+
+// maxImportSize максимальный размер импортируемого файла
+const maxImportSize = 10 << 20
+
+// Importer описывает метод для импорта событий из файла
+type Importer interface {
+	Import(ctx context.Context, format importer.Format, r io.Reader) (*models.ImportReport, error)
+}
+
+// importEvents обрабатывает загрузку файла с событиями. Формат берётся из параметра format,
+// а при его отсутствии - из Content-Type
+func (h *handler) importEvents(w http.ResponseWriter, r *http.Request) {
+	format, err := importFormat(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	report, err := h.Importer.Import(r.Context(), format, body)
+	switch {
+	case err == nil:
+		response.JSON(w, http.StatusOK, report)
+	case errors.Is(err, service.ErrInvalidImport):
+		response.JSON(w, http.StatusUnprocessableEntity, report)
+	case errors.Is(err, importer.ErrMalformedFile):
+		response.Error(w, http.StatusBadRequest, response.Message(err, importer.ErrMalformedFile))
+	default:
+		h.log.Error("error", err.Error(), slog.String("operation", opImportEvents))
+		response.Error(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+// importFormat определяет формат импортируемого файла
+func importFormat(r *http.Request) (importer.Format, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return importer.ParseFormat(f)
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return importer.FormatCSV, nil
+	case "application/json":
+		return importer.FormatJSON, nil
+	case "text/calendar":
+		return importer.FormatICS, nil
+	}
+	return "", errors.New("format query parameter or Content-Type is required")
+}
@@ -0,0 +1,119 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opSetVisibility = "admin.SetVisibility"
+	opCreateInvite  = "admin.CreateInvite"
+	opGetInvites    = "admin.GetInvites"
+	opRevokeInvite  = "admin.RevokeInvite"
+)
+
+// InviteManager описывает методы управления видимостью событий и кодами приглашения
+type InviteManager interface {
+	SetVisibility(ctx context.Context, eventID string, visibility models.Visibility) error
+	CreateInvite(ctx context.Context, eventID string, maxUses *int, expiresAt *time.Time) (models.InviteCode, error)
+	GetInvites(ctx context.Context, eventID string) ([]models.InviteCode, error)
+	RevokeInvite(ctx context.Context, inviteID string) (models.InviteCode, error)
+}
+
+// visibilityRequest описывает тело запроса на изменение видимости события
+type visibilityRequest struct {
+	Visibility models.Visibility `json:"visibility"`
+}
+
+// inviteRequest описывает тело запроса на создание кода приглашения. max_uses: 1 - одноразовый код,
+// null - без ограничения; expires_at: null - бессрочный код
+type inviteRequest struct {
+	MaxUses   *int       `json:"max_uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// inviteResponse описывает код приглашения в ответе API. link - ссылка на бота с кодом,
+// передаётся, если задано имя бота
+type inviteResponse struct {
+	ID        string     `json:"id"`
+	Code      string     `json:"code"`
+	Link      string     `json:"link,omitempty"`
+	MaxUses   *int       `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	Valid     bool       `json:"valid"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// setVisibility задаёт видимость события
+func (h *handler) setVisibility(w http.ResponseWriter, r *http.Request) {
+	var req visibilityRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.Invites.SetVisibility(r.Context(), r.PathValue("id"), req.Visibility); err != nil {
+		h.writeError(w, opSetVisibility, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createInvite создаёт код приглашения на событие
+func (h *handler) createInvite(w http.ResponseWriter, r *http.Request) {
+	var req inviteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	invite, err := h.Invites.CreateInvite(r.Context(), r.PathValue("id"), req.MaxUses, req.ExpiresAt)
+	if err != nil {
+		h.writeError(w, opCreateInvite, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, toInviteResponse(invite, time.Now()))
+}
+
+// getInvites возвращает коды приглашения на событие
+func (h *handler) getInvites(w http.ResponseWriter, r *http.Request) {
+	invites, err := h.Invites.GetInvites(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opGetInvites, err)
+		return
+	}
+	now := time.Now()
+	resp := make([]inviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		resp = append(resp, toInviteResponse(invite, now))
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// revokeInvite отзывает код приглашения
+func (h *handler) revokeInvite(w http.ResponseWriter, r *http.Request) {
+	invite, err := h.Invites.RevokeInvite(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opRevokeInvite, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, toInviteResponse(invite, time.Now()))
+}
+
+// toInviteResponse преобразует код приглашения в ответ API
+func toInviteResponse(c models.InviteCode, now time.Time) inviteResponse {
+	return inviteResponse{
+		ID:        c.ID,
+		Code:      c.Code,
+		Link:      c.Link,
+		MaxUses:   c.MaxUses,
+		Uses:      c.Uses,
+		ExpiresAt: c.ExpiresAt,
+		RevokedAt: c.RevokedAt,
+		Valid:     c.Valid(now),
+		CreatedAt: c.CreatedAt.UTC(),
+	}
+}
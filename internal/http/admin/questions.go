@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opCreateQuestion = "admin.CreateQuestion"
+	opUpdateQuestion = "admin.UpdateQuestion"
+	opDeleteQuestion = "admin.DeleteQuestion"
+)
+
+// QuestionManager описывает методы для управления вопросами анкеты регистрации
+type QuestionManager interface {
+	CreateQuestion(ctx context.Context, q models.Question) (models.Question, error)
+	UpdateQuestion(ctx context.Context, q models.Question) (models.Question, error)
+	DeleteQuestion(ctx context.Context, questionID string) error
+}
+
+// questionRequest описывает тело запроса на создание или изменение вопроса анкеты
+type questionRequest struct {
+	Type     string   `json:"type"`
+	Label    string   `json:"label"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+	Position int      `json:"position"`
+}
+
+// toModel преобразует запрос в доменную структуру
+func (req questionRequest) toModel(id, eventID string) models.Question {
+	return models.Question{
+		ID:       id,
+		EventID:  eventID,
+		Type:     models.QuestionType(req.Type),
+		Label:    req.Label,
+		Options:  req.Options,
+		Required: req.Required,
+		Position: req.Position,
+	}
+}
+
+// createQuestion создаёт вопрос анкеты события
+func (h *handler) createQuestion(w http.ResponseWriter, r *http.Request) {
+	var req questionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	question, err := h.Questions.CreateQuestion(r.Context(), req.toModel("", r.PathValue("id")))
+	if err != nil {
+		h.writeError(w, opCreateQuestion, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, question)
+}
+
+// updateQuestion изменяет вопрос анкеты
+func (h *handler) updateQuestion(w http.ResponseWriter, r *http.Request) {
+	var req questionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	question, err := h.Questions.UpdateQuestion(r.Context(), req.toModel(r.PathValue("id"), ""))
+	if err != nil {
+		h.writeError(w, opUpdateQuestion, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, question)
+}
+
+// deleteQuestion удаляет вопрос анкеты без ответов
+func (h *handler) deleteQuestion(w http.ResponseWriter, r *http.Request) {
+	if err := h.Questions.DeleteQuestion(r.Context(), r.PathValue("id")); err != nil {
+		h.writeError(w, opDeleteQuestion, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetSeries    = "admin.GetSeries"
+	opCreateSeries = "admin.CreateSeries"
+	opUpdateSeries = "admin.UpdateSeries"
+)
+
+// SeriesManager описывает методы для управления сериями повторяющихся событий
+type SeriesManager interface {
+	CreateSeries(ctx context.Context, sr models.Series) (models.Series, error)
+	UpdateSeries(ctx context.Context, sr models.Series) (models.Series, error)
+	GetSeries(ctx context.Context) ([]models.Series, error)
+}
+
+// seriesRequest описывает тело запроса на создание или изменение серии
+type seriesRequest struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	StartsAt    time.Time `json:"starts_at"`
+	TimeZone    string    `json:"time_zone"`
+	RRule       string    `json:"rrule"`
+	VenueID     *string   `json:"venue_id"`
+	Exceptions  []string  `json:"exceptions"`
+}
+
+// toModel преобразует запрос в доменную структуру
+func (req seriesRequest) toModel(id string) models.Series {
+	return models.Series{
+		ID:          id,
+		Title:       req.Title,
+		Description: req.Description,
+		StartsAt:    req.StartsAt,
+		TimeZone:    req.TimeZone,
+		RRule:       req.RRule,
+		VenueID:     req.VenueID,
+		Exceptions:  req.Exceptions,
+	}
+}
+
+// getSeries возвращает все серии событий
+func (h *handler) getSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := h.Series.GetSeries(r.Context())
+	if err != nil {
+		h.writeError(w, opGetSeries, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, series)
+}
+
+// createSeries создаёт серию и её ближайшие повторения
+func (h *handler) createSeries(w http.ResponseWriter, r *http.Request) {
+	var req seriesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	series, err := h.Series.CreateSeries(r.Context(), req.toModel(""))
+	if err != nil {
+		h.writeError(w, opCreateSeries, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, series)
+}
+
+// updateSeries изменяет серию. Изменения применяются к будущим повторениям
+func (h *handler) updateSeries(w http.ResponseWriter, r *http.Request) {
+	var req seriesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	series, err := h.Series.UpdateSeries(r.Context(), req.toModel(r.PathValue("id")))
+	if err != nil {
+		h.writeError(w, opUpdateSeries, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, series)
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opCreateSession = "admin.CreateSession"
+	opUpdateSession = "admin.UpdateSession"
+	opDeleteSession = "admin.DeleteSession"
+)
+
+// SessionManager описывает методы для управления сессиями событий
+type SessionManager interface {
+	CreateSession(ctx context.Context, session models.Session) (models.Session, error)
+	UpdateSession(ctx context.Context, session models.Session) (models.Session, error)
+	DeleteSession(ctx context.Context, sessionID string) error
+}
+
+// sessionRequest описывает тело запроса на создание или изменение сессии
+type sessionRequest struct {
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Capacity *int      `json:"capacity"`
+}
+
+// toModel преобразует запрос в доменную структуру
+func (req sessionRequest) toModel(id, eventID string) models.Session {
+	return models.Session{
+		ID:       id,
+		EventID:  eventID,
+		Title:    req.Title,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Capacity: req.Capacity,
+	}
+}
+
+// createSession создаёт сессию события
+func (h *handler) createSession(w http.ResponseWriter, r *http.Request) {
+	var req sessionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	session, err := h.Sessions.CreateSession(r.Context(), req.toModel("", r.PathValue("id")))
+	if err != nil {
+		h.writeError(w, opCreateSession, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, session)
+}
+
+// updateSession изменяет сессию
+func (h *handler) updateSession(w http.ResponseWriter, r *http.Request) {
+	var req sessionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	session, err := h.Sessions.UpdateSession(r.Context(), req.toModel(r.PathValue("id"), ""))
+	if err != nil {
+		h.writeError(w, opUpdateSession, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, session)
+}
+
+// deleteSession удаляет сессию без регистраций
+func (h *handler) deleteSession(w http.ResponseWriter, r *http.Request) {
+	if err := h.Sessions.DeleteSession(r.Context(), r.PathValue("id")); err != nil {
+		h.writeError(w, opDeleteSession, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetCategories      = "admin.GetCategories"
+	opCreateCategory     = "admin.CreateCategory"
+	opUpdateCategory     = "admin.UpdateCategory"
+	opDeleteCategory     = "admin.DeleteCategory"
+	opSetEventCategories = "admin.SetEventCategories"
+	opSetEventTags       = "admin.SetEventTags"
+)
+
+// TaxonomyManager описывает методы для управления категориями и тегами
+type TaxonomyManager interface {
+	GetCategories(ctx context.Context) ([]models.Category, error)
+	CreateCategory(ctx context.Context, c models.Category) (models.Category, error)
+	UpdateCategory(ctx context.Context, c models.Category) (models.Category, error)
+	DeleteCategory(ctx context.Context, categoryID string) error
+	SetEventCategories(ctx context.Context, eventID string, categoryIDs []string) error
+	SetEventTags(ctx context.Context, eventID string, tags []string) error
+}
+
+// categoryRequest описывает тело запроса на создание или изменение категории
+type categoryRequest struct {
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+// eventCategoriesRequest описывает тело запроса на замену категорий события
+type eventCategoriesRequest struct {
+	CategoryIDs []string `json:"category_ids"`
+}
+
+// eventTagsRequest описывает тело запроса на замену тегов события
+type eventTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// getCategories возвращает все категории
+func (h *handler) getCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.Taxonomy.GetCategories(r.Context())
+	if err != nil {
+		h.writeError(w, opGetCategories, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, categories)
+}
+
+// createCategory создаёт категорию
+func (h *handler) createCategory(w http.ResponseWriter, r *http.Request) {
+	var req categoryRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	category, err := h.Taxonomy.CreateCategory(r.Context(), models.Category{Slug: req.Slug, Name: req.Name, Position: req.Position})
+	if err != nil {
+		h.writeError(w, opCreateCategory, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, category)
+}
+
+// updateCategory изменяет категорию
+func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
+	var req categoryRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	category, err := h.Taxonomy.UpdateCategory(r.Context(), models.Category{
+		ID:       r.PathValue("id"),
+		Slug:     req.Slug,
+		Name:     req.Name,
+		Position: req.Position,
+	})
+	if err != nil {
+		h.writeError(w, opUpdateCategory, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, category)
+}
+
+// deleteCategory удаляет категорию
+func (h *handler) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	if err := h.Taxonomy.DeleteCategory(r.Context(), r.PathValue("id")); err != nil {
+		h.writeError(w, opDeleteCategory, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setEventCategories заменяет категории события
+func (h *handler) setEventCategories(w http.ResponseWriter, r *http.Request) {
+	var req eventCategoriesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.Taxonomy.SetEventCategories(r.Context(), r.PathValue("id"), req.CategoryIDs); err != nil {
+		h.writeError(w, opSetEventCategories, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setEventTags заменяет теги события
+func (h *handler) setEventTags(w http.ResponseWriter, r *http.Request) {
+	var req eventTagsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.Taxonomy.SetEventTags(r.Context(), r.PathValue("id"), req.Tags); err != nil {
+		h.writeError(w, opSetEventTags, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
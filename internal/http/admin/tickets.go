@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opCreateTicketType = "admin.CreateTicketType"
+	opUpdateTicketType = "admin.UpdateTicketType"
+	opDeleteTicketType = "admin.DeleteTicketType"
+)
+
+// TicketTypeManager описывает методы для управления типами билетов
+type TicketTypeManager interface {
+	CreateTicketType(ctx context.Context, t models.TicketType) (models.TicketType, error)
+	UpdateTicketType(ctx context.Context, t models.TicketType) (models.TicketType, error)
+	DeleteTicketType(ctx context.Context, ticketTypeID string) error
+}
+
+// ticketTypeRequest описывает тело запроса на создание или изменение типа билета
+type ticketTypeRequest struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	PriceAmount  int64      `json:"price_amount"`
+	Currency     string     `json:"currency"`
+	Capacity     *int       `json:"capacity"`
+	SalesStartAt *time.Time `json:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at"`
+	Position     int        `json:"position"`
+}
+
+// toModel преобразует запрос в доменную структуру
+func (req ticketTypeRequest) toModel(id, eventID string) models.TicketType {
+	return models.TicketType{
+		ID:           id,
+		EventID:      eventID,
+		Name:         req.Name,
+		Description:  req.Description,
+		PriceAmount:  req.PriceAmount,
+		Currency:     req.Currency,
+		Capacity:     req.Capacity,
+		SalesStartAt: req.SalesStartAt,
+		SalesEndAt:   req.SalesEndAt,
+		Position:     req.Position,
+	}
+}
+
+// createTicketType создаёт тип билета события
+func (h *handler) createTicketType(w http.ResponseWriter, r *http.Request) {
+	var req ticketTypeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	ticketType, err := h.TicketTypes.CreateTicketType(r.Context(), req.toModel("", r.PathValue("id")))
+	if err != nil {
+		h.writeError(w, opCreateTicketType, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, ticketType)
+}
+
+// updateTicketType изменяет тип билета
+func (h *handler) updateTicketType(w http.ResponseWriter, r *http.Request) {
+	var req ticketTypeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	ticketType, err := h.TicketTypes.UpdateTicketType(r.Context(), req.toModel(r.PathValue("id"), ""))
+	if err != nil {
+		h.writeError(w, opUpdateTicketType, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, ticketType)
+}
+
+// deleteTicketType удаляет тип билета без регистраций
+func (h *handler) deleteTicketType(w http.ResponseWriter, r *http.Request) {
+	if err := h.TicketTypes.DeleteTicketType(r.Context(), r.PathValue("id")); err != nil {
+		h.writeError(w, opDeleteTicketType, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetTranslations   = "admin.GetTranslations"
+	opSetTranslation    = "admin.SetTranslation"
+	opDeleteTranslation = "admin.DeleteTranslation"
+)
+
+// TranslationManager описывает методы управления переводами событий
+type TranslationManager interface {
+	GetEventTranslations(ctx context.Context, eventID string) ([]models.EventTranslation, error)
+	SetEventTranslation(ctx context.Context, t models.EventTranslation) (models.EventTranslation, error)
+	DeleteEventTranslation(ctx context.Context, eventID, language string) error
+}
+
+// translationRequest описывает тело запроса на создание или замену перевода
+type translationRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// translationResponse описывает перевод события в ответе API
+type translationResponse struct {
+	Language    string    `json:"language"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// getTranslations возвращает переводы события
+func (h *handler) getTranslations(w http.ResponseWriter, r *http.Request) {
+	translations, err := h.Translations.GetEventTranslations(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, opGetTranslations, err)
+		return
+	}
+
+	resp := make([]translationResponse, 0, len(translations))
+	for _, t := range translations {
+		resp = append(resp, toTranslationResponse(t))
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// setTranslation создаёт или заменяет перевод события на язык из пути запроса
+func (h *handler) setTranslation(w http.ResponseWriter, r *http.Request) {
+	var req translationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	t, err := h.Translations.SetEventTranslation(r.Context(), models.EventTranslation{
+		EventID:     r.PathValue("id"),
+		Language:    r.PathValue("language"),
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.writeError(w, opSetTranslation, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, toTranslationResponse(t))
+}
+
+// deleteTranslation удаляет перевод события
+func (h *handler) deleteTranslation(w http.ResponseWriter, r *http.Request) {
+	if err := h.Translations.DeleteEventTranslation(r.Context(), r.PathValue("id"), r.PathValue("language")); err != nil {
+		h.writeError(w, opDeleteTranslation, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toTranslationResponse преобразует доменную структуру в ответ API
+func toTranslationResponse(t models.EventTranslation) translationResponse {
+	return translationResponse{
+		Language:    t.Language,
+		Title:       t.Title,
+		Description: t.Description,
+		UpdatedAt:   t.UpdatedAt.UTC(),
+	}
+}
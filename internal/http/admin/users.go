@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetUser = "admin.GetUser"
+)
+
+// UserReader описывает метод получения профиля пользователя
+type UserReader interface {
+	GetUser(ctx context.Context, chatID int64) (models.UserProfile, error)
+}
+
+// userResponse описывает профиль пользователя в ответе API
+type userResponse struct {
+	ChatID       int64     `json:"chat_id"`
+	Username     string    `json:"username"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	LanguageCode string    `json:"language_code"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// getUser возвращает профиль пользователя
+func (h *handler) getUser(w http.ResponseWriter, r *http.Request) {
+	chatID, err := strconv.ParseInt(r.PathValue("chat_id"), 10, 64)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid chat_id")
+		return
+	}
+
+	user, err := h.Users.GetUser(r.Context(), chatID)
+	if err != nil {
+		h.writeError(w, opGetUser, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, userResponse{
+		ChatID:       user.ChatID,
+		Username:     user.Username,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		LanguageCode: user.LanguageCode,
+		CreatedAt:    user.CreatedAt.UTC(),
+		UpdatedAt:    user.UpdatedAt.UTC(),
+	})
+}
@@ -0,0 +1,106 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opGetVenues     = "admin.GetVenues"
+	opCreateVenue   = "admin.CreateVenue"
+	opUpdateVenue   = "admin.UpdateVenue"
+	opSetEventVenue = "admin.SetEventVenue"
+)
+
+// VenueManager описывает методы для управления площадками
+type VenueManager interface {
+	CreateVenue(ctx context.Context, venue models.Venue) (models.Venue, error)
+	UpdateVenue(ctx context.Context, venue models.Venue) (models.Venue, error)
+	GetVenues(ctx context.Context) ([]models.Venue, error)
+	SetEventVenue(ctx context.Context, eventID string, venueID *string) error
+}
+
+// venueRequest описывает тело запроса на создание или изменение площадки
+type venueRequest struct {
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	City      string  `json:"city"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Capacity  *int    `json:"capacity"`
+	TimeZone  *string `json:"time_zone"`
+}
+
+// eventVenueRequest описывает тело запроса на привязку события к площадке
+type eventVenueRequest struct {
+	VenueID *string `json:"venue_id"`
+}
+
+// toModel преобразует запрос в доменную структуру
+func (req venueRequest) toModel(id string) models.Venue {
+	return models.Venue{
+		ID:        id,
+		Name:      req.Name,
+		Address:   req.Address,
+		City:      req.City,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Capacity:  req.Capacity,
+		TimeZone:  req.TimeZone,
+	}
+}
+
+// getVenues возвращает все площадки
+func (h *handler) getVenues(w http.ResponseWriter, r *http.Request) {
+	venues, err := h.Venues.GetVenues(r.Context())
+	if err != nil {
+		h.writeError(w, opGetVenues, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, venues)
+}
+
+// createVenue создаёт площадку
+func (h *handler) createVenue(w http.ResponseWriter, r *http.Request) {
+	var req venueRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	venue, err := h.Venues.CreateVenue(r.Context(), req.toModel(""))
+	if err != nil {
+		h.writeError(w, opCreateVenue, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, venue)
+}
+
+// updateVenue изменяет площадку
+func (h *handler) updateVenue(w http.ResponseWriter, r *http.Request) {
+	var req venueRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	venue, err := h.Venues.UpdateVenue(r.Context(), req.toModel(r.PathValue("id")))
+	if err != nil {
+		h.writeError(w, opUpdateVenue, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, venue)
+}
+
+// setEventVenue привязывает событие к площадке. venue_id: null отвязывает событие
+func (h *handler) setEventVenue(w http.ResponseWriter, r *http.Request) {
+	var req eventVenueRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.Venues.SetEventVenue(r.Context(), r.PathValue("id"), req.VenueID); err != nil {
+		h.writeError(w, opSetEventVenue, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Константы для описания операций
+const (
+	opSetRegistrationWindow = "admin.SetRegistrationWindow"
+)
+
+// RegistrationWindowManager описывает метод задания окна регистрации на событие
+type RegistrationWindowManager interface {
+	SetRegistrationWindow(ctx context.Context, eventID string, opensAt, closesAt *time.Time, closesBefore *time.Duration) error
+}
+
+// registrationWindowRequest описывает тело запроса на задание окна регистрации. closes_before_start -
+// длительность в формате Go (например, 2h), null - без ограничения
+type registrationWindowRequest struct {
+	OpensAt           *time.Time `json:"opens_at"`
+	ClosesAt          *time.Time `json:"closes_at"`
+	ClosesBeforeStart *string    `json:"closes_before_start"`
+}
+
+// setRegistrationWindow задаёт окно регистрации на событие
+func (h *handler) setRegistrationWindow(w http.ResponseWriter, r *http.Request) {
+	var req registrationWindowRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	var closesBefore *time.Duration
+	if req.ClosesBeforeStart != nil {
+		d, err := time.ParseDuration(*req.ClosesBeforeStart)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid closes_before_start: "+err.Error())
+			return
+		}
+		closesBefore = &d
+	}
+
+	err := h.Windows.SetRegistrationWindow(r.Context(), r.PathValue("id"), req.OpensAt, req.ClosesAt, closesBefore)
+	if err != nil {
+		h.writeError(w, opSetRegistrationWindow, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
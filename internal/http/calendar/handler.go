@@ -0,0 +1,168 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ical"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opServeCalendar = "calendar.Serve"
+)
+
+// Параметры календаря
+const (
+	productID = "-//Telegram-bot-for-register-on-events//event-service//RU"
+	uidDomain = "event-service"
+	// refreshInterval как часто календарным приложениям рекомендуется обновлять подписку
+	refreshInterval = "PT1H"
+	fileExtension   = ".ics"
+)
+
+// FeedService описывает методы для получения событий для календарей
+type FeedService interface {
+	Event(ctx context.Context, eventID string) (models.Event, error)
+	Upcoming(ctx context.Context) ([]models.Event, error)
+	Feed(ctx context.Context, token string) ([]models.Event, error)
+}
+
+// handler описывает HTTP API экспорта календарей
+type handler struct {
+	log   *slog.Logger
+	feeds FeedService
+}
+
+// Register регистрирует обработчики календарей
+func Register(mux *http.ServeMux, log *slog.Logger, feeds FeedService) {
+	h := &handler{log: log, feeds: feeds}
+	mux.HandleFunc("GET /calendar/events.ics", h.upcoming)
+	mux.HandleFunc("GET /calendar/events/{file}", h.event)
+	mux.HandleFunc("GET /calendar/feeds/{file}", h.feed)
+}
+
+// event отдаёт календарь с одним событием
+func (h *handler) event(w http.ResponseWriter, r *http.Request) {
+	eventID, ok := strings.CutSuffix(r.PathValue("file"), fileExtension)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	e, err := h.feeds.Event(r.Context(), eventID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeCalendar(w, e.Title, []models.Event{e})
+}
+
+// upcoming отдаёт календарь со всеми предстоящими событиями
+func (h *handler) upcoming(w http.ResponseWriter, r *http.Request) {
+	events, err := h.feeds.Upcoming(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeCalendar(w, "Все события", events)
+}
+
+// feed отдаёт персональный календарь с событиями, на которые зарегистрирован владелец токена
+func (h *handler) feed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), fileExtension)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	events, err := h.feeds.Feed(r.Context(), token)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeCalendar(w, "Мои события", events)
+}
+
+// writeCalendar формирует и отправляет календарь
+func (h *handler) writeCalendar(w http.ResponseWriter, name string, events []models.Event) {
+	now := time.Now()
+	cal := &ical.Component{
+		Name: "VCALENDAR",
+		Properties: []ical.Property{
+			{Name: "VERSION", Value: "2.0"},
+			{Name: "PRODID", Value: productID},
+			{Name: "CALSCALE", Value: "GREGORIAN"},
+			{Name: "METHOD", Value: "PUBLISH"},
+			ical.Text("NAME", name),
+			ical.Text("X-WR-CALNAME", name),
+			{Name: "REFRESH-INTERVAL", Params: map[string]string{"VALUE": "DURATION"}, Value: refreshInterval},
+			{Name: "X-PUBLISHED-TTL", Value: refreshInterval},
+		},
+	}
+	for _, e := range events {
+		cal.Components = append(cal.Components, eventComponent(e, now))
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := ical.Encode(w, cal); err != nil {
+		h.log.Error("error", err.Error(), slog.String("operation", opServeCalendar))
+	}
+}
+
+// eventComponent преобразует событие в компонент VEVENT. SEQUENCE и LAST-MODIFIED позволяют
+// календарным приложениям обнаружить перенос события
+func eventComponent(e models.Event, now time.Time) *ical.Component {
+	c := &ical.Component{
+		Name: "VEVENT",
+		Properties: []ical.Property{
+			{Name: "UID", Value: e.ID + "@" + uidDomain},
+			ical.DateTime("DTSTAMP", now),
+			ical.DateTime("DTSTART", e.StartsAt),
+			{Name: "SEQUENCE", Value: strconv.Itoa(e.Sequence)},
+			ical.Text("SUMMARY", e.Title),
+		},
+	}
+	if !e.UpdatedAt.IsZero() {
+		c.Properties = append(c.Properties, ical.DateTime("LAST-MODIFIED", e.UpdatedAt))
+	}
+	if e.Description != "" {
+		c.Properties = append(c.Properties, ical.Text("DESCRIPTION", e.Description))
+	}
+	if v := e.Venue; v != nil {
+		location := strings.Join(nonEmpty(v.Name, v.Address, v.City), ", ")
+		c.Properties = append(c.Properties,
+			ical.Text("LOCATION", location),
+			ical.Property{Name: "GEO", Value: strconv.FormatFloat(v.Latitude, 'f', 6, 64) + ";" + strconv.FormatFloat(v.Longitude, 'f', 6, 64)},
+		)
+	}
+	return c
+}
+
+// nonEmpty возвращает непустые строки
+func nonEmpty(values ...string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// writeError преобразует ошибку сервисного слоя в HTTP-ответ
+func (h *handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, storage.ErrEventNotFound) || errors.Is(err, storage.ErrFeedNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	h.log.Error("error", err.Error(), slog.String("operation", opServeCalendar))
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
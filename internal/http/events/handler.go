@@ -0,0 +1,347 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opGetEvents     = "events.GetEvents"
+	opGetEvent      = "events.GetEvent"
+	opNearby        = "events.Nearby"
+	opGetCategories = "events.GetCategories"
+)
+
+// EventService описывает методы для получения событий
+type EventService interface {
+	GetEvents(ctx context.Context, filter models.EventFilter) ([]models.Event, error)
+	GetEvent(ctx context.Context, eventID string) (models.Event, error)
+	Localize(ctx context.Context, language string, chatID *int64, events ...*models.Event)
+}
+
+// CategoryLister описывает метод для получения списка категорий
+type CategoryLister interface {
+	GetCategories(ctx context.Context) ([]models.Category, error)
+}
+
+// NearbyFinder описывает метод для поиска событий рядом с точкой
+type NearbyFinder interface {
+	EventsNearby(ctx context.Context, lat, lon, radiusKm float64) ([]models.NearbyEvent, error)
+}
+
+// eventResponse описывает событие в ответе API. starts_at всегда в UTC,
+// local_starts_at - то же время в часовом поясе события
+type eventResponse struct {
+	ID               string               `json:"id"`
+	Title            string               `json:"title"`
+	Description      string               `json:"description"`
+	Language         string               `json:"language"`
+	StartsAt         time.Time            `json:"starts_at"`
+	TimeZone         string               `json:"time_zone"`
+	LocalStartsAt    string               `json:"local_starts_at"`
+	Venue            *venueResponse       `json:"venue,omitempty"`
+	Categories       []categoryResponse   `json:"categories"`
+	Tags             []string             `json:"tags"`
+	DistanceKm       *float64             `json:"distance_km,omitempty"`
+	SeriesID         *string              `json:"series_id,omitempty"`
+	MaxGuests        int                  `json:"max_guests"`
+	RequiresApproval bool                 `json:"requires_approval"`
+	Visibility       string               `json:"visibility"`
+	Registration     registrationResponse `json:"registration"`
+	Sessions         []sessionResponse    `json:"sessions,omitempty"`
+	TicketTypes      []ticketTypeResponse `json:"ticket_types,omitempty"`
+	Questions        []questionResponse   `json:"questions,omitempty"`
+}
+
+// registrationResponse описывает окно регистрации на событие в ответе API. closes_at - итоговый срок
+// окончания регистрации с учётом закрытия за время до начала, null - без ограничения
+type registrationResponse struct {
+	Status   string     `json:"status"`
+	OpensAt  *time.Time `json:"opens_at"`
+	ClosesAt *time.Time `json:"closes_at"`
+}
+
+// questionResponse описывает вопрос анкеты регистрации в ответе API
+type questionResponse struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Label    string   `json:"label"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+// ticketTypeResponse описывает тип билета в ответе API. remaining - количество оставшихся билетов,
+// null при неограниченном количестве
+type ticketTypeResponse struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	PriceAmount  int64      `json:"price_amount"`
+	Currency     string     `json:"currency"`
+	Capacity     *int       `json:"capacity"`
+	Remaining    *int       `json:"remaining"`
+	SalesStartAt *time.Time `json:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at"`
+	OnSale       bool       `json:"on_sale"`
+}
+
+// sessionResponse описывает сессию события в ответе API. remaining - количество свободных мест,
+// null при неограниченной вместимости
+type sessionResponse struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Capacity  *int      `json:"capacity"`
+	Remaining *int      `json:"remaining"`
+}
+
+// categoryResponse описывает категорию в ответе API
+type categoryResponse struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// venueResponse описывает площадку в ответе API
+type venueResponse struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	City      string  `json:"city"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Capacity  *int    `json:"capacity,omitempty"`
+}
+
+// Services описывает зависимости публичного API событий
+type Services struct {
+	Events     EventService
+	Nearby     NearbyFinder
+	Categories CategoryLister
+}
+
+// handler описывает публичное HTTP API событий
+type handler struct {
+	log *slog.Logger
+	Services
+}
+
+// Register регистрирует обработчики событий
+func Register(mux *http.ServeMux, log *slog.Logger, services Services) {
+	h := &handler{log: log, Services: services}
+	mux.HandleFunc("GET /categories", h.getCategories)
+	mux.HandleFunc("GET /events", h.getEvents)
+	mux.HandleFunc("GET /events/nearby", h.getNearby)
+	mux.HandleFunc("GET /events/{id}", h.getEvent)
+}
+
+// getCategories возвращает категории для меню бота
+func (h *handler) getCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.Categories.GetCategories(r.Context())
+	if err != nil {
+		h.log.Error("error", err.Error(), slog.String("operation", opGetCategories))
+		response.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	resp := make([]categoryResponse, 0, len(categories))
+	for _, c := range categories {
+		resp = append(resp, categoryResponse{Slug: c.Slug, Name: c.Name})
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// getEvents возвращает список событий. Параметры фильтрации: category (slug) и tag (можно указать несколько раз).
+// Тексты событий переводятся на язык запроса
+func (h *handler) getEvents(w http.ResponseWriter, r *http.Request) {
+	filter := models.EventFilter{
+		Category: r.URL.Query().Get("category"),
+		Tags:     r.URL.Query()["tag"],
+	}
+	events, err := h.Events.GetEvents(r.Context(), filter)
+	if err != nil {
+		h.log.Error("error", err.Error(), slog.String("operation", opGetEvents))
+		response.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	ptrs := make([]*models.Event, 0, len(events))
+	for i := range events {
+		ptrs = append(ptrs, &events[i])
+	}
+	h.Events.Localize(r.Context(), requestLanguage(r), nil, ptrs...)
+
+	resp := make([]eventResponse, 0, len(events))
+	for _, e := range events {
+		resp = append(resp, toEventResponse(e))
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// getEvent возвращает одно событие с текстами на языке запроса
+func (h *handler) getEvent(w http.ResponseWriter, r *http.Request) {
+	e, err := h.Events.GetEvent(r.Context(), r.PathValue("id"))
+	if errors.Is(err, storage.ErrEventNotFound) {
+		response.Error(w, http.StatusNotFound, "event not found")
+		return
+	}
+	if err != nil {
+		h.log.Error("error", err.Error(), slog.String("operation", opGetEvent))
+		response.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	h.Events.Localize(r.Context(), requestLanguage(r), nil, &e)
+	response.JSON(w, http.StatusOK, toEventResponse(e))
+}
+
+// getNearby возвращает предстоящие события рядом с точкой, которой пользователь поделился в Telegram.
+// Параметры: lat, lon и необязательный radius_km. Тексты событий переводятся на язык запроса
+func (h *handler) getNearby(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lat, errLat := strconv.ParseFloat(query.Get("lat"), 64)
+	lon, errLon := strconv.ParseFloat(query.Get("lon"), 64)
+	if errLat != nil || errLon != nil {
+		response.Error(w, http.StatusBadRequest, "lat and lon query parameters are required")
+		return
+	}
+	var radius float64
+	if s := query.Get("radius_km"); s != "" {
+		var err error
+		if radius, err = strconv.ParseFloat(s, 64); err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid radius_km")
+			return
+		}
+	}
+
+	events, err := h.Nearby.EventsNearby(r.Context(), lat, lon, radius)
+	if errors.Is(err, service.ErrInvalidArgument) {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.Error("error", err.Error(), slog.String("operation", opNearby))
+		response.Error(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	ptrs := make([]*models.Event, 0, len(events))
+	for i := range events {
+		ptrs = append(ptrs, &events[i].Event)
+	}
+	h.Events.Localize(r.Context(), requestLanguage(r), nil, ptrs...)
+
+	resp := make([]eventResponse, 0, len(events))
+	for _, e := range events {
+		item := toEventResponse(e.Event)
+		distance := e.DistanceKm
+		item.DistanceKm = &distance
+		resp = append(resp, item)
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// toEventResponse преобразует доменную структуру в ответ API
+func toEventResponse(e models.Event) eventResponse {
+	resp := eventResponse{
+		ID:               e.ID,
+		Title:            e.Title,
+		Description:      e.Description,
+		Language:         e.Language,
+		StartsAt:         e.StartsAt.UTC(),
+		TimeZone:         e.TimeZone,
+		LocalStartsAt:    e.LocalStartsAt().Format(time.RFC3339),
+		Categories:       make([]categoryResponse, 0, len(e.Categories)),
+		Tags:             e.Tags,
+		SeriesID:         e.SeriesID,
+		MaxGuests:        e.MaxGuests,
+		RequiresApproval: e.RequiresApproval,
+		Visibility:       string(e.Visibility),
+	}
+	if resp.Tags == nil {
+		resp.Tags = []string{}
+	}
+	now := time.Now()
+	window := e.RegistrationWindow()
+	state := e.RegistrationState
+	if state == "" {
+		state = window.State(now)
+	}
+	resp.Registration = registrationResponse{Status: string(state), OpensAt: window.OpensAt, ClosesAt: window.Deadline()}
+	for _, c := range e.Categories {
+		resp.Categories = append(resp.Categories, categoryResponse{Slug: c.Slug, Name: c.Name})
+	}
+	for _, session := range e.Sessions {
+		resp.Sessions = append(resp.Sessions, sessionResponse{
+			ID:        session.ID,
+			Title:     session.Title,
+			StartsAt:  session.StartsAt.UTC(),
+			EndsAt:    session.EndsAt.UTC(),
+			Capacity:  session.Capacity,
+			Remaining: session.Remaining(),
+		})
+	}
+	for _, t := range e.TicketTypes {
+		// Состояние продаж, как и состояние регистрации, вычисляется сервисным слоем по его часам
+		onSale := t.OnSaleNow
+		if e.RegistrationState == "" {
+			onSale = t.OnSale(now)
+		}
+		resp.TicketTypes = append(resp.TicketTypes, ticketTypeResponse{
+			ID:           t.ID,
+			Name:         t.Name,
+			Description:  t.Description,
+			PriceAmount:  t.PriceAmount,
+			Currency:     t.Currency,
+			Capacity:     t.Capacity,
+			Remaining:    t.Remaining(),
+			SalesStartAt: t.SalesStartAt,
+			SalesEndAt:   t.SalesEndAt,
+			OnSale:       onSale,
+		})
+	}
+	for _, q := range e.Questions {
+		resp.Questions = append(resp.Questions, questionResponse{
+			ID:       q.ID,
+			Type:     string(q.Type),
+			Label:    q.Label,
+			Options:  q.Options,
+			Required: q.Required,
+		})
+	}
+	if v := e.Venue; v != nil {
+		resp.Venue = &venueResponse{
+			ID:        v.ID,
+			Name:      v.Name,
+			Address:   v.Address,
+			City:      v.City,
+			Latitude:  v.Latitude,
+			Longitude: v.Longitude,
+			Capacity:  v.Capacity,
+		}
+	}
+	return resp
+}
+
+// requestLanguage возвращает язык запроса: параметр lang или первый язык из заголовка Accept-Language
+func requestLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return lang
+	}
+	first, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	lang, _, _ := strings.Cut(first, ";")
+	if lang = strings.TrimSpace(lang); lang == "*" {
+		return ""
+	}
+	return lang
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+)
+
+// Состояния сервиса и его компонентов в ответе
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	componentUp       = "up"
+	componentDown     = "down"
+)
+
+// Checker описывает компонент, состояние которого входит в проверку сервиса
+type Checker interface {
+	Healthy() bool
+}
+
+// healthResponse описывает ответ проверки состояния
+type healthResponse struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components"`
+}
+
+// Register регистрирует обработчик проверки состояния сервиса. components - компоненты по именам
+func Register(mux *http.ServeMux, components map[string]Checker) {
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		resp := healthResponse{Status: statusOK, Components: make(map[string]string, len(components))}
+		for name, c := range components {
+			if c.Healthy() {
+				resp.Components[name] = componentUp
+				continue
+			}
+			resp.Components[name] = componentDown
+			resp.Status = statusUnavailable
+		}
+		if resp.Status != statusOK {
+			response.JSON(w, http.StatusServiceUnavailable, resp)
+			return
+		}
+		response.JSON(w, http.StatusOK, resp)
+	})
+}
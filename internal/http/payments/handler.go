@@ -0,0 +1,68 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/http/response"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/payment"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/service"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opWebhook = "payments.Webhook"
+)
+
+// maxBodySize максимальный размер тела уведомления
+const maxBodySize = 1 << 20
+
+// WebhookHandler описывает метод обработки уведомлений платёжных провайдеров
+type WebhookHandler interface {
+	HandleWebhook(ctx context.Context, provider string, header http.Header, body []byte) error
+}
+
+// handler описывает HTTP API приёма уведомлений платёжных провайдеров
+type handler struct {
+	log      *slog.Logger
+	webhooks WebhookHandler
+}
+
+// Register регистрирует обработчики уведомлений. Подлинность уведомлений проверяет провайдер
+func Register(mux *http.ServeMux, log *slog.Logger, webhooks WebhookHandler) {
+	h := &handler{log: log, webhooks: webhooks}
+	mux.HandleFunc("POST /payments/webhooks/{provider}", h.webhook)
+}
+
+// webhook принимает уведомление провайдера о результате платежа. Повторное уведомление
+// об уже обработанном платеже подтверждается, чтобы провайдер прекратил повторную доставку
+func (h *handler) webhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	err = h.webhooks.HandleWebhook(r.Context(), r.PathValue("provider"), r.Header, body)
+	switch {
+	case err == nil:
+		response.JSON(w, http.StatusOK, map[string]string{"status": "processed"})
+	case errors.Is(err, storage.ErrPaymentProcessed):
+		response.JSON(w, http.StatusOK, map[string]string{"status": "already processed"})
+	case errors.Is(err, service.ErrInvalidArgument), errors.Is(err, payment.ErrMalformedNotification):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, payment.ErrInvalidSignature):
+		response.Error(w, http.StatusUnauthorized, "invalid signature")
+	case errors.Is(err, storage.ErrPaymentNotFound):
+		response.Error(w, http.StatusNotFound, "payment not found")
+	case errors.Is(err, storage.ErrPaymentExpired):
+		response.Error(w, http.StatusConflict, "registration payment has expired")
+	default:
+		h.log.Error("error", err.Error(), slog.String("operation", opWebhook))
+		response.Error(w, http.StatusInternalServerError, "internal error")
+	}
+}
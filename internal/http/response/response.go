@@ -0,0 +1,29 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// JSON сериализует ответ в JSON
+func JSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Error отправляет ошибку в JSON-формате
+func Error(w http.ResponseWriter, status int, message string) {
+	JSON(w, status, map[string]string{"error": message})
+}
+
+// Message возвращает текст ошибки err для клиента, начиная с известной ошибки target: цепочка операций,
+// через которую прошла ошибка, отбрасывается, а пояснения после target сохраняются
+func Message(err, target error) string {
+	msg := err.Error()
+	if i := strings.Index(msg, target.Error()); i >= 0 {
+		return msg[i:]
+	}
+	return target.Error()
+}
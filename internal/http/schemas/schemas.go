@@ -0,0 +1,12 @@
+package schemas
+
+import (
+	"io/fs"
+	"net/http"
+)
+
+// Register регистрирует обработчик JSON Schema сообщений NATS. Схемы доступны по адресу,
+// указанному в поле dataschema конверта сообщения: GET /schemas/<файл>
+func Register(mux *http.ServeMux, schemas fs.FS) {
+	mux.Handle("GET /schemas/{file}", http.FileServerFS(schemas))
+}
@@ -0,0 +1,60 @@
+package tickets
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ticket"
+)
+
+// Константы для описания операций
+const (
+	opQRCode = "tickets.QRCode"
+)
+
+// fileExtension расширение изображения билета
+const fileExtension = ".png"
+
+// QRCodeRenderer описывает метод для получения QR-кода билета
+type QRCodeRenderer interface {
+	QRCode(token string) ([]byte, error)
+}
+
+// handler описывает HTTP API билетов
+type handler struct {
+	log     *slog.Logger
+	tickets QRCodeRenderer
+}
+
+// Register регистрирует обработчики билетов
+func Register(mux *http.ServeMux, log *slog.Logger, tickets QRCodeRenderer) {
+	h := &handler{log: log, tickets: tickets}
+	mux.HandleFunc("GET /tickets/{file}", h.qrCode)
+}
+
+// qrCode отдаёт QR-код билета в формате PNG. Токен подписан, поэтому изображение
+// выдаётся только для билетов, выпущенных сервисом
+func (h *handler) qrCode(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), fileExtension)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	png, err := h.tickets.QRCode(token)
+	if errors.Is(err, ticket.ErrInvalidToken) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		h.log.Error("error", err.Error(), slog.String("operation", opQRCode))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	_, _ = w.Write(png)
+}
@@ -0,0 +1,228 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Константы для описания операций
+const (
+	opDecode = "ical.Decode"
+)
+
+// Форматы дат и времени по RFC 5545
+const (
+	layoutDateTimeUTC = "20060102T150405Z"
+	layoutDateTime    = "20060102T150405"
+	layoutDate        = "20060102"
+)
+
+// Property описывает свойство компонента календаря
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+	// Line номер строки, с которой начинается свойство
+	Line int
+}
+
+// Component описывает компонент календаря (VEVENT, VTIMEZONE и т.д.)
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+	// Line номер строки BEGIN компонента
+	Line int
+}
+
+// Get возвращает первое свойство с указанным именем
+func (c *Component) Get(name string) (Property, bool) {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// Text возвращает значение текстового свойства с раскрытыми escape-последовательностями
+func (c *Component) Text(name string) string {
+	p, ok := c.Get(name)
+	if !ok {
+		return ""
+	}
+	return unescapeText(p.Value)
+}
+
+// Time разбирает значение свойства типа DATE или DATE-TIME. Время без TZID и без суффикса Z
+// ("плавающее") интерпретируется в часовом поясе floating
+func (p Property) Time(floating *time.Location) (time.Time, error) {
+	value := p.Value
+	loc := floating
+	if p.Params["VALUE"] == "DATE" || len(value) == len(layoutDate) {
+		return time.ParseInLocation(layoutDate, value, loc)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(layoutDateTimeUTC, value)
+	}
+	if tzid := p.Params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown TZID %q: %w", tzid, err)
+		}
+		loc = l
+	}
+	return time.ParseInLocation(layoutDateTime, value, loc)
+}
+
+// Decode разбирает поток в формате iCalendar и возвращает компоненты верхнего уровня (обычно VCALENDAR)
+func Decode(r io.Reader) ([]*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opDecode, err)
+	}
+
+	var (
+		roots []*Component
+		stack []*Component
+	)
+	for _, l := range lines {
+		p, err := parseLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: %w", opDecode, l.number, err)
+		}
+		p.Line = l.number
+
+		switch p.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(p.Value), Line: l.number}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			} else {
+				roots = append(roots, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("%s: line %d: unexpected END:%s", opDecode, l.number, p.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%s: line %d: property %s outside of component", opDecode, l.number, p.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%s: component %s is not closed", opDecode, stack[len(stack)-1].Name)
+	}
+	return roots, nil
+}
+
+// Events возвращает все компоненты VEVENT из разобранного календаря
+func Events(components []*Component) []*Component {
+	var events []*Component
+	for _, c := range components {
+		if c.Name == "VEVENT" {
+			events = append(events, c)
+		}
+		events = append(events, Events(c.Components)...)
+	}
+	return events
+}
+
+// line описывает логическую строку календаря после склейки переносов
+type line struct {
+	number int
+	text   string
+}
+
+// unfold склеивает строки, перенесённые по правилам RFC 5545 (продолжение начинается с пробела или табуляции)
+func unfold(r io.Reader) ([]line, error) {
+	var lines []line
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, line{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseLine разбирает строку вида NAME;PARAM=VALUE:VALUE
+func parseLine(text string) (Property, error) {
+	// Двоеточие внутри кавычек в параметрах не является разделителем
+	inQuotes := false
+	colon := -1
+	for i, r := range text {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return Property{}, errors.New("missing ':' in content line")
+	}
+
+	head, value := text[:colon], text[colon+1:]
+	parts := strings.Split(head, ";")
+	p := Property{Name: strings.ToUpper(parts[0]), Value: value}
+	if p.Name == "" {
+		return Property{}, errors.New("empty property name")
+	}
+	for _, param := range parts[1:] {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok {
+			return Property{}, fmt.Errorf("malformed parameter %q", param)
+		}
+		if p.Params == nil {
+			p.Params = make(map[string]string)
+		}
+		p.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+// unescapeText раскрывает escape-последовательности текстовых значений
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
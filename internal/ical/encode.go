@@ -0,0 +1,101 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Константы для описания операций
+const (
+	opEncode = "ical.Encode"
+)
+
+// maxLineOctets максимальная длина строки по RFC 5545 без учёта CRLF
+const maxLineOctets = 75
+
+// Text создаёт текстовое свойство, экранируя специальные символы
+func Text(name, value string) Property {
+	return Property{Name: name, Value: escapeText(value)}
+}
+
+// DateTime создаёт свойство типа DATE-TIME в UTC
+func DateTime(name string, t time.Time) Property {
+	return Property{Name: name, Value: t.UTC().Format(layoutDateTimeUTC)}
+}
+
+// Encode записывает компонент календаря в формате RFC 5545
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	writeComponent(bw, c)
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("%s: %w", opEncode, err)
+	}
+	return nil
+}
+
+// writeComponent рекурсивно записывает компонент и его вложенные компоненты
+func writeComponent(w *bufio.Writer, c *Component) {
+	writeLine(w, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		writeLine(w, formatProperty(p))
+	}
+	for _, child := range c.Components {
+		writeComponent(w, child)
+	}
+	writeLine(w, "END:"+c.Name)
+}
+
+// formatProperty формирует строку свойства вида NAME;PARAM=VALUE:VALUE
+func formatProperty(p Property) string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+
+	// Сортируем параметры для детерминированного вывода
+	keys := make([]string, 0, len(p.Params))
+	for k := range p.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := p.Params[k]
+		if strings.ContainsAny(v, ":;,") {
+			v = `"` + v + `"`
+		}
+		b.WriteString(";" + k + "=" + v)
+	}
+
+	b.WriteString(":" + p.Value)
+	return b.String()
+}
+
+// writeLine записывает строку, перенося её по 75 октетов без разрыва многобайтовых символов
+func writeLine(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		_, _ = w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Строка продолжения начинается с пробела, который тоже занимает октет
+		limit = maxLineOctets - 1
+	}
+	_, _ = w.WriteString(s + "\r\n")
+}
+
+// escapeText экранирует обратный слеш, точку с запятой, запятую и переводы строк
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
@@ -0,0 +1,184 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		// wantEvents свойства каждого VEVENT в формате NAME=значение, текстовые значения раскрыты
+		wantEvents [][]string
+		wantErr    string
+	}{
+		{
+			name: "events",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:a\r\n" +
+				"SUMMARY:Line\\nbreak\\, comma\\; semicolon\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"uid:b\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			wantEvents: [][]string{
+				{"UID=a", "SUMMARY=Line\nbreak, comma; semicolon"},
+				{"UID=b"},
+			},
+		},
+		{
+			name: "folded lines",
+			input: "BEGIN:VEVENT\n" +
+				"SUMMARY:Long\n" +
+				"  title\n" +
+				"\tcontinued\n" +
+				"END:VEVENT\n",
+			wantEvents: [][]string{{"SUMMARY=Long titlecontinued"}},
+		},
+		{
+			name:       "quoted parameter with colon",
+			input:      "BEGIN:VEVENT\nLOCATION;ALTREP=\"http://example.com\":Hall\nEND:VEVENT\n",
+			wantEvents: [][]string{{"LOCATION=Hall"}},
+		},
+		{
+			name:    "missing colon",
+			input:   "BEGIN:VEVENT\nSUMMARY\nEND:VEVENT\n",
+			wantErr: "line 2: missing ':'",
+		},
+		{
+			name:    "malformed parameter",
+			input:   "BEGIN:VEVENT\nDTSTART;TZID:20261101T180000\nEND:VEVENT\n",
+			wantErr: "line 2: malformed parameter",
+		},
+		{
+			name:    "unexpected end",
+			input:   "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+			wantErr: "line 3: unexpected END:VCALENDAR",
+		},
+		{
+			name:    "property outside of component",
+			input:   "UID:a\n",
+			wantErr: "line 1: property UID outside of component",
+		},
+		{
+			name:    "not closed",
+			input:   "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VEVENT\n",
+			wantErr: "component VCALENDAR is not closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components, err := Decode(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Decode error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			events := Events(components)
+			if len(events) != len(tt.wantEvents) {
+				t.Fatalf("got %d events, want %d", len(events), len(tt.wantEvents))
+			}
+			for i, want := range tt.wantEvents {
+				var got []string
+				for _, p := range events[i].Properties {
+					got = append(got, p.Name+"="+events[i].Text(p.Name))
+				}
+				if strings.Join(got, "|") != strings.Join(want, "|") {
+					t.Errorf("event %d properties = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPropertyTime(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	tests := []struct {
+		name    string
+		params  map[string]string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "utc", value: "20261101T180000Z", want: time.Date(2026, 11, 1, 18, 0, 0, 0, time.UTC)},
+		{name: "tzid", params: map[string]string{"TZID": "Europe/Berlin"}, value: "20261101T180000", want: time.Date(2026, 11, 1, 18, 0, 0, 0, berlin)},
+		{name: "floating", value: "20261101T180000", want: time.Date(2026, 11, 1, 18, 0, 0, 0, moscow)},
+		{name: "date", params: map[string]string{"VALUE": "DATE"}, value: "20261101", want: time.Date(2026, 11, 1, 0, 0, 0, 0, moscow)},
+		{name: "date without value type", value: "20261101", want: time.Date(2026, 11, 1, 0, 0, 0, 0, moscow)},
+		{name: "unknown tzid", params: map[string]string{"TZID": "Mars/Olympus"}, value: "20261101T180000", wantErr: true},
+		{name: "malformed", value: "2026-11-01 18:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Property{Name: "DTSTART", Params: tt.params, Value: tt.value}.Time(moscow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Time error = %v, want error %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("Time = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		property Property
+	}{
+		{name: "escaped text", property: Text("SUMMARY", "Line\nbreak, comma; semicolon \\ slash")},
+		{name: "long ascii", property: Text("DESCRIPTION", strings.Repeat("a", 200))},
+		{name: "long multibyte", property: Text("DESCRIPTION", strings.Repeat("событие ", 40))},
+		{name: "quoted parameter", property: Property{Name: "LOCATION", Params: map[string]string{"ALTREP": "http://example.com"}, Value: "Hall"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Encode(&b, &Component{Name: "VEVENT", Properties: []Property{tt.property}}); err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			for _, l := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+				if len(l) > maxLineOctets {
+					t.Errorf("line is %d octets long, want at most %d", len(l), maxLineOctets)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %q splits a multibyte character", l)
+				}
+			}
+
+			components, err := Decode(strings.NewReader(b.String()))
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			got, ok := components[0].Get(tt.property.Name)
+			if !ok {
+				t.Fatalf("property %s is lost", tt.property.Name)
+			}
+			if got.Value != tt.property.Value {
+				t.Errorf("value = %q, want %q", got.Value, tt.property.Value)
+			}
+			for k, v := range tt.property.Params {
+				if got.Params[k] != v {
+					t.Errorf("param %s = %q, want %q", k, got.Params[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ical"
+)
+
+// Константы для описания операций
+const (
+	opParse = "importer.Parse"
+)
+
+// Format описывает формат импортируемого файла
+type Format string
+
+// Поддерживаемые форматы
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatICS  Format = "ics"
+)
+
+// Ошибки разбора файла
+var (
+	// ErrUnknownFormat возвращается, если формат файла не поддерживается
+	ErrUnknownFormat = errors.New("unknown import format")
+	// ErrMalformedFile возвращается, если файл не удалось разобрать целиком
+	ErrMalformedFile = errors.New("malformed import file")
+)
+
+// Колонки CSV-файла
+const (
+	columnExternalID  = "external_id"
+	columnTitle       = "title"
+	columnDescription = "description"
+	columnStartsAt    = "starts_at"
+	columnTimeZone    = "time_zone"
+)
+
+// localLayouts форматы времени без смещения, которые интерпретируются в часовом поясе события
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Row описывает одну разобранную строку файла
+type Row struct {
+	// Number номер строки в CSV, порядковый номер объекта в JSON или номер строки BEGIN:VEVENT в iCalendar
+	Number int
+	Event  models.Event
+	// Err ошибка разбора строки, если она есть
+	Err error
+}
+
+// ParseFormat проверяет название формата
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimPrefix(s, "."))); f {
+	case FormatCSV, FormatJSON, FormatICS:
+		return f, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+}
+
+// FormatFromFilename определяет формат по расширению файла
+func FormatFromFilename(name string) (Format, error) {
+	return ParseFormat(filepath.Ext(name))
+}
+
+// Parse разбирает файл указанного формата в набор строк. Ошибки отдельных строк
+// возвращаются внутри Row, ошибка функции означает, что файл не удалось прочитать целиком.
+// defaultZone используется для строк, в которых часовой пояс не указан
+func Parse(format Format, r io.Reader, defaultZone *time.Location) ([]Row, error) {
+	var (
+		rows []Row
+		err  error
+	)
+	switch format {
+	case FormatCSV:
+		rows, err = parseCSV(r, defaultZone)
+	case FormatJSON:
+		rows, err = parseJSON(r, defaultZone)
+	case FormatICS:
+		rows, err = parseICS(r, defaultZone)
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", opParse, ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", opParse, ErrMalformedFile, err)
+	}
+	return rows, nil
+}
+
+// parseCSV разбирает CSV-файл с заголовком external_id,title,description,starts_at[,time_zone]
+func parseCSV(r io.Reader, defaultZone *time.Location) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{columnExternalID, columnTitle, columnStartsAt} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	get := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, Row{Number: parseErr.Line, Err: parseErr.Err})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Number: line}
+		row.Event.ExternalID = stringPtr(get(record, columnExternalID))
+		row.Event.Title = get(record, columnTitle)
+		row.Event.Description = get(record, columnDescription)
+		row.Event.TimeZone, row.Event.StartsAt, row.Err = parseTime(get(record, columnStartsAt), get(record, columnTimeZone), defaultZone)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// jsonEvent описывает событие в JSON-файле
+type jsonEvent struct {
+	ExternalID  string `json:"external_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	StartsAt    string `json:"starts_at"`
+	TimeZone    string `json:"time_zone"`
+}
+
+// parseJSON разбирает JSON-массив событий
+func parseJSON(r io.Reader, defaultZone *time.Location) ([]Row, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode json array: %w", err)
+	}
+
+	rows := make([]Row, 0, len(raw))
+	for i, msg := range raw {
+		row := Row{Number: i + 1}
+		var e jsonEvent
+		if err := json.Unmarshal(msg, &e); err != nil {
+			row.Err = err
+			rows = append(rows, row)
+			continue
+		}
+		row.Event.ExternalID = stringPtr(strings.TrimSpace(e.ExternalID))
+		row.Event.Title = strings.TrimSpace(e.Title)
+		row.Event.Description = strings.TrimSpace(e.Description)
+		row.Event.TimeZone, row.Event.StartsAt, row.Err = parseTime(strings.TrimSpace(e.StartsAt), strings.TrimSpace(e.TimeZone), defaultZone)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseICS разбирает компоненты VEVENT из файла iCalendar. UID используется как внешний идентификатор,
+// часовой пояс берётся из параметра TZID у DTSTART
+func parseICS(r io.Reader, defaultZone *time.Location) ([]Row, error) {
+	components, err := ical.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	vevents := ical.Events(components)
+	rows := make([]Row, 0, len(vevents))
+	for _, ve := range vevents {
+		row := Row{Number: ve.Line}
+		row.Event.ExternalID = stringPtr(strings.TrimSpace(ve.Text("UID")))
+		row.Event.Title = strings.TrimSpace(ve.Text("SUMMARY"))
+		row.Event.Description = strings.TrimSpace(ve.Text("DESCRIPTION"))
+		row.Event.TimeZone = defaultZone.String()
+		if dtstart, ok := ve.Get("DTSTART"); ok {
+			if tzid := dtstart.Params["TZID"]; tzid != "" {
+				row.Event.TimeZone = tzid
+			}
+			row.Event.StartsAt, row.Err = dtstart.Time(defaultZone)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseTime разбирает время начала события и его часовой пояс. Время со смещением (RFC 3339) задаёт
+// момент однозначно, время без смещения интерпретируется в часовом поясе события
+func parseTime(s, zone string, defaultZone *time.Location) (string, time.Time, error) {
+	loc := defaultZone
+	if zone != "" {
+		l, err := time.LoadLocation(zone)
+		if err != nil {
+			return zone, time.Time{}, fmt.Errorf("unknown time_zone %q", zone)
+		}
+		loc = l
+	}
+	if s == "" {
+		return loc.String(), time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return loc.String(), t, nil
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return loc.String(), t, nil
+		}
+	}
+	return loc.String(), time.Time{}, fmt.Errorf("starts_at must be in RFC 3339 format or local time like 2006-01-02T15:04, got %q", s)
+}
+
+// stringPtr возвращает указатель на строку или nil для пустой строки
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,205 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wantRow ожидаемый результат разбора строки
+type wantRow struct {
+	number     int
+	externalID string
+	title      string
+	startsAt   time.Time
+	timeZone   string
+	// err у строки есть ошибка разбора
+	err bool
+}
+
+func TestParse(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	tests := []struct {
+		name    string
+		format  Format
+		input   string
+		want    []wantRow
+		wantErr error
+	}{
+		{
+			name:   "csv",
+			format: FormatCSV,
+			input: "external_id,title,description,starts_at,time_zone\n" +
+				"a,Meetup,Talks,2026-11-01T18:00:00+03:00,\n" +
+				"b, Workshop ,,2026-11-02 10:00,Europe/Berlin\n" +
+				"c,Party,,2026-11-03T20:00,\n",
+			want: []wantRow{
+				{number: 2, externalID: "a", title: "Meetup", startsAt: time.Date(2026, 11, 1, 15, 0, 0, 0, time.UTC), timeZone: "Europe/Moscow"},
+				{number: 3, externalID: "b", title: "Workshop", startsAt: time.Date(2026, 11, 2, 10, 0, 0, 0, berlin), timeZone: "Europe/Berlin"},
+				{number: 4, externalID: "c", title: "Party", startsAt: time.Date(2026, 11, 3, 20, 0, 0, 0, moscow), timeZone: "Europe/Moscow"},
+			},
+		},
+		{
+			name:   "csv columns in any order without optional ones",
+			format: FormatCSV,
+			input:  "Starts_At,Title,External_ID\n2026-11-01T18:00,Meetup,a\n",
+			want: []wantRow{
+				{number: 2, externalID: "a", title: "Meetup", startsAt: time.Date(2026, 11, 1, 18, 0, 0, 0, moscow), timeZone: "Europe/Moscow"},
+			},
+		},
+		{
+			name:   "csv malformed rows",
+			format: FormatCSV,
+			input: "external_id,title,starts_at,time_zone\n" +
+				"a,Meetup,2026-11-01T18:00,\n" +
+				"b,Too,many,fields,here\n" +
+				"c,Bad time,tomorrow,\n" +
+				"d,Bad zone,2026-11-01T18:00,Mars/Olympus\n" +
+				"e,No time,,\n",
+			want: []wantRow{
+				{number: 2, externalID: "a", title: "Meetup", startsAt: time.Date(2026, 11, 1, 18, 0, 0, 0, moscow), timeZone: "Europe/Moscow"},
+				{number: 3, err: true},
+				{number: 4, externalID: "c", title: "Bad time", timeZone: "Europe/Moscow", err: true},
+				{number: 5, externalID: "d", title: "Bad zone", timeZone: "Mars/Olympus", err: true},
+				{number: 6, externalID: "e", title: "No time", timeZone: "Europe/Moscow"},
+			},
+		},
+		{
+			name:    "csv missing column",
+			format:  FormatCSV,
+			input:   "external_id,title\na,Meetup\n",
+			wantErr: ErrMalformedFile,
+		},
+		{
+			name:    "csv empty",
+			format:  FormatCSV,
+			wantErr: ErrMalformedFile,
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			input: `[
+				{"external_id": "a", "title": "Meetup", "starts_at": "2026-11-01T18:00:00Z"},
+				{"external_id": " b ", "title": "Workshop", "starts_at": "2026-11-02T10:00", "time_zone": "Europe/Berlin"}
+			]`,
+			want: []wantRow{
+				{number: 1, externalID: "a", title: "Meetup", startsAt: time.Date(2026, 11, 1, 18, 0, 0, 0, time.UTC), timeZone: "Europe/Moscow"},
+				{number: 2, externalID: "b", title: "Workshop", startsAt: time.Date(2026, 11, 2, 10, 0, 0, 0, berlin), timeZone: "Europe/Berlin"},
+			},
+		},
+		{
+			name:   "json malformed objects",
+			format: FormatJSON,
+			input:  `[{"external_id": 1, "title": "Meetup"}, "event", {"external_id": "c", "title": "Party", "starts_at": "soon"}]`,
+			want: []wantRow{
+				{number: 1, err: true},
+				{number: 2, err: true},
+				{number: 3, externalID: "c", title: "Party", timeZone: "Europe/Moscow", err: true},
+			},
+		},
+		{
+			name:    "json not an array",
+			format:  FormatJSON,
+			input:   `{"external_id": "a"}`,
+			wantErr: ErrMalformedFile,
+		},
+		{
+			name:   "ics",
+			format: FormatICS,
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:a\r\n" +
+				"SUMMARY:Meetup\\, talks\r\n" +
+				"DTSTART;TZID=Europe/Berlin:20261101T180000\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:b\r\n" +
+				"SUMMARY:Workshop\r\n" +
+				"DTSTART:20261102T100000Z\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:c\r\n" +
+				"SUMMARY:Bad zone\r\n" +
+				"DTSTART;TZID=Mars/Olympus:20261103T100000\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []wantRow{
+				{number: 2, externalID: "a", title: "Meetup, talks", startsAt: time.Date(2026, 11, 1, 18, 0, 0, 0, berlin), timeZone: "Europe/Berlin"},
+				{number: 7, externalID: "b", title: "Workshop", startsAt: time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC), timeZone: "Europe/Moscow"},
+				{number: 12, externalID: "c", title: "Bad zone", timeZone: "Mars/Olympus", err: true},
+			},
+		},
+		{
+			name:    "ics not closed",
+			format:  FormatICS,
+			input:   "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nEND:VCALENDAR\r\n",
+			wantErr: ErrMalformedFile,
+		},
+		{
+			name:    "unknown format",
+			format:  Format("xml"),
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(tt.format, strings.NewReader(tt.input), moscow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse error = %v, want %v", err, tt.wantErr)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				row := rows[i]
+				var externalID string
+				if row.Event.ExternalID != nil {
+					externalID = *row.Event.ExternalID
+				}
+				if row.Number != want.number || externalID != want.externalID || row.Event.Title != want.title ||
+					row.Event.TimeZone != want.timeZone || (row.Err != nil) != want.err {
+					t.Errorf("row %d = {%d %q %q %q err=%v}, want {%d %q %q %q err=%v}", i,
+						row.Number, externalID, row.Event.Title, row.Event.TimeZone, row.Err,
+						want.number, want.externalID, want.title, want.timeZone, want.err)
+				}
+				if !row.Event.StartsAt.Equal(want.startsAt) {
+					t.Errorf("row %d starts_at = %v, want %v", i, row.Event.StartsAt, want.startsAt)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Format
+		wantErr error
+	}{
+		{name: "name", in: "csv", want: FormatCSV},
+		{name: "upper case", in: "JSON", want: FormatJSON},
+		{name: "extension", in: ".ics", want: FormatICS},
+		{name: "unknown", in: "xml", wantErr: ErrUnknownFormat},
+		{name: "empty", wantErr: ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFormat(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseFormat(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseFormat(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job описывает фоновую задачу, которая выполняется сразу после запуска и затем с заданным интервалом
+type Job struct {
+	log      *slog.Logger
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// New конструктор для Job
+func New(log *slog.Logger, name string, interval time.Duration, run func(ctx context.Context) error) *Job {
+	return &Job{
+		log:      log,
+		name:     name,
+		interval: interval,
+		run:      run,
+	}
+}
+
+// Start запускает задачу в отдельной горутине
+func (j *Job) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done = make(chan struct{})
+
+	j.log.Info("starting background job", slog.String("job", j.name), slog.Duration("interval", j.interval))
+	go j.loop(ctx)
+}
+
+// Stop останавливает задачу и дожидается завершения текущего запуска
+func (j *Job) Stop() {
+	if j.cancel == nil {
+		return
+	}
+	j.log.Info("background job is stopping", slog.String("job", j.name))
+	j.cancel()
+	<-j.done
+}
+
+// loop выполняет задачу до отмены контекста
+func (j *Job) loop(ctx context.Context) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.run(ctx); err != nil && ctx.Err() == nil {
+			j.log.Error("error", err.Error(), slog.String("job", j.name))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
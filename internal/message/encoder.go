@@ -0,0 +1,94 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Поддерживаемые форматы публикуемых сообщений
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+// Заголовки сообщения JetStream. headerMsgID - идентификатор сообщения, по которому JetStream
+// отбрасывает повторные публикации в пределах окна дедупликации потока
+const (
+	headerContentType = "Content-Type"
+	headerMsgID       = "Nats-Msg-Id"
+)
+
+// Заголовки атрибутов CloudEvents в бинарном режиме (protobuf): атрибуты передаются в заголовках, данные - в теле
+const (
+	headerSpecVersion = "ce-specversion"
+	headerID          = "ce-id"
+	headerType        = "ce-type"
+	headerSource      = "ce-source"
+	headerTime        = "ce-time"
+	headerDataSchema  = "ce-dataschema"
+)
+
+// protoTypeURLPrefix префикс ссылки на protobuf-сообщение в ce-dataschema
+const protoTypeURLPrefix = "type.googleapis.com/"
+
+// Encoder описывает кодирование данных сообщения вместе с атрибутами конверта.
+// Возвращает тело сообщения и заголовки JetStream, в том числе Content-Type
+type Encoder interface {
+	Encode(k Kind, attributes Envelope, payload any) ([]byte, map[string]string, error)
+}
+
+// NewEncoder возвращает кодировщик для формата encoding: json или protobuf
+func NewEncoder(encoding string) (Encoder, error) {
+	switch encoding {
+	case EncodingJSON:
+		return jsonEncoder{}, nil
+	case EncodingProtobuf:
+		return protobufEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown message encoding %q", encoding)
+	}
+}
+
+// jsonEncoder публикует конверт CloudEvents в структурированном JSON-формате, данные - в поле data
+type jsonEncoder struct{}
+
+// Encode кодирует данные и конверт в JSON
+func (jsonEncoder) Encode(k Kind, attributes Envelope, payload any) ([]byte, map[string]string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	attributes.DataContentType = ContentTypeJSON
+	attributes.DataSchema = k.DataSchema()
+	attributes.Data = data
+	body, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, map[string]string{headerContentType: ContentTypeCloudEventsJSON}, nil
+}
+
+// protobufEncoder публикует данные protobuf-сообщением из pkg/message в теле, а атрибуты конверта -
+// в заголовках ce-* (бинарный режим CloudEvents)
+type protobufEncoder struct{}
+
+// Encode кодирует данные в protobuf
+func (protobufEncoder) Encode(k Kind, attributes Envelope, payload any) ([]byte, map[string]string, error) {
+	msg := k.toProto(payload)
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, map[string]string{
+		headerContentType: ContentTypeProtobuf,
+		headerSpecVersion: attributes.SpecVersion,
+		headerID:          attributes.ID,
+		headerType:        attributes.Type,
+		headerSource:      attributes.Source,
+		headerTime:        attributes.Time.Format(time.RFC3339Nano),
+		headerDataSchema:  protoTypeURLPrefix + string(msg.ProtoReflect().Descriptor().FullName()),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package message
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// registrationConfirmedKey возвращает ключ подтверждённой регистрации. Токен билета подписывает идентификатор
+// регистрации, поэтому повторная регистрация после отмены получает другой ключ
+func registrationConfirmedKey(u models.User) string {
+	return joinKey(u.EventID, optional(u.SessionID), strconv.FormatInt(u.ChatID, 10), u.TicketToken)
+}
+
+// paymentConfirmedKey возвращает ключ подтверждённой оплаты. Платёж подтверждается один раз
+func paymentConfirmedKey(p models.PaymentConfirmed) string {
+	return p.PaymentID
+}
+
+// registrationCheckedInKey возвращает ключ прохода по билету: регистрация и сессия, на которую выполнен проход
+func registrationCheckedInKey(c models.CheckIn) string {
+	return joinKey(c.RegistrationID, optional(c.SessionID))
+}
+
+// registrationDecisionKey возвращает ключ решения по заявке
+func registrationDecisionKey(d models.RegistrationDecision) string {
+	return joinKey(d.RegistrationID, string(d.Decision))
+}
+
+// registrationOpenedKey возвращает ключ открытия регистрации. Если окно регистрации перенесли,
+// открытие в новое время получает другой ключ
+func registrationOpenedKey(o models.RegistrationOpened) string {
+	return joinKey(o.EventID, o.OpensAt.UTC().Format(time.RFC3339Nano))
+}
+
+// joinKey объединяет части ключа
+func joinKey(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// optional возвращает значение необязательного поля, пустую строку для nil
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,196 @@
+package message
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Константы для описания операций
+const (
+	opPublish = "message.Publish"
+)
+
+// SpecVersion версия спецификации CloudEvents, которой соответствует конверт
+const SpecVersion = "1.0"
+
+// Source источник сообщений микросервиса в конверте
+const Source = "/event-service"
+
+// typePrefix префикс типа сообщения
+const typePrefix = "event-service."
+
+// Типы содержимого. ContentTypeJSON - тип data в JSON-конверте
+const (
+	ContentTypeJSON            = "application/json"
+	ContentTypeCloudEventsJSON = "application/cloudevents+json"
+	ContentTypeProtobuf        = "application/protobuf"
+)
+
+// ErrUnknownPayload возвращается при публикации данных, тип которых не зарегистрирован в Kinds
+var ErrUnknownPayload = errors.New("unknown message payload")
+
+// Envelope описывает конверт исходящего сообщения в структурированном JSON-формате CloudEvents
+type Envelope struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	DataSchema      string          `json:"dataschema"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// Kind описывает вид сообщения: имя, версию формата данных и Go-тип данных
+type Kind struct {
+	// Name имя вида сообщения (например, registration.confirmed)
+	Name string
+	// Version версия формата данных. Увеличивается при любом изменении полей данных
+	Version int
+	payload reflect.Type
+	toProto func(any) proto.Message
+	key     func(any) string
+}
+
+// Type возвращает тип сообщения в конверте (например, event-service.registration.confirmed.v1)
+func (k Kind) Type() string {
+	return fmt.Sprintf("%s%s.v%d", typePrefix, k.Name, k.Version)
+}
+
+// SchemaFile возвращает имя файла JSON Schema данных сообщения
+func (k Kind) SchemaFile() string {
+	return fmt.Sprintf("%s.v%d.json", k.Name, k.Version)
+}
+
+// DataSchema возвращает путь к JSON Schema данных сообщения в HTTP API
+func (k Kind) DataSchema() string {
+	return "/schemas/" + k.SchemaFile()
+}
+
+// kind конструктор для Kind с данными типа T. toProto преобразует данные в protobuf-сообщение
+// из pkg/message для публикации в формате protobuf, key возвращает ключ доменного события, из которого
+// строится идентификатор сообщения: повторная публикация того же события получает тот же идентификатор
+func kind[T any](name string, version int, toProto func(T) proto.Message, key func(T) string) Kind {
+	return Kind{
+		Name:    name,
+		Version: version,
+		payload: reflect.TypeFor[T](),
+		toProto: func(payload any) proto.Message { return toProto(payload.(T)) },
+		key:     func(payload any) string { return key(payload.(T)) },
+	}
+}
+
+// kinds виды исходящих сообщений. При изменении полей данных увеличьте версию, сгенерируйте схему
+// (go run ./cmd/msgschema -write) и добавьте protobuf-сообщение новой версии в pkg/message
+var kinds = []Kind{
+	kind("registration.confirmed", 1, registrationConfirmedV1, registrationConfirmedKey),
+	kind("payment.confirmed", 1, paymentConfirmedV1, paymentConfirmedKey),
+	kind("registration.checked_in", 1, registrationCheckedInV1, registrationCheckedInKey),
+	kind("registration.decision", 1, registrationDecisionV1, registrationDecisionKey),
+	kind("registration.opened", 1, registrationOpenedV1, registrationOpenedKey),
+}
+
+// Kinds возвращает виды исходящих сообщений
+func Kinds() []Kind {
+	return append([]Kind(nil), kinds...)
+}
+
+// kindOf возвращает вид сообщения по типу данных и сами данные. Указатель на данные
+// равнозначен самим данным, nil-указатель не соответствует ни одному виду
+func kindOf(payload any) (Kind, any, bool) {
+	v := reflect.ValueOf(payload)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return Kind{}, nil, false
+		}
+		v = v.Elem()
+		payload = v.Interface()
+	}
+	if !v.IsValid() {
+		return Kind{}, nil, false
+	}
+	for _, k := range kinds {
+		if k.payload == v.Type() {
+			return k, payload, true
+		}
+	}
+	return Kind{}, nil, false
+}
+
+// RawPublisher описывает метод публикации готового сообщения с заголовками в NATS
+type RawPublisher interface {
+	PublishMessage(topic string, data []byte, header map[string]string) error
+}
+
+// Publisher публикует данные в конверте CloudEvents
+type Publisher struct {
+	log     *slog.Logger
+	raw     RawPublisher
+	encoder Encoder
+	now     func() time.Time
+}
+
+// NewPublisher конструктор для Publisher. now - источник текущего времени, nil - time.Now
+func NewPublisher(log *slog.Logger, raw RawPublisher, encoder Encoder, now func() time.Time) *Publisher {
+	if now == nil {
+		now = time.Now
+	}
+	return &Publisher{
+		log:     log,
+		raw:     raw,
+		encoder: encoder,
+		now:     now,
+	}
+}
+
+// Publish кодирует данные вместе с атрибутами конверта и публикует их в топик.
+// Тип данных должен быть зарегистрирован в Kinds
+func (p *Publisher) Publish(topic string, payload any) error {
+	k, data, ok := kindOf(payload)
+	if !ok {
+		err := fmt.Errorf("%w: %T", ErrUnknownPayload, payload)
+		p.log.Error("error", err.Error(), slog.String("operation", opPublish))
+		return fmt.Errorf("%s: %w", opPublish, err)
+	}
+	attributes := p.attributes(k, data)
+	body, header, err := p.encoder.Encode(k, attributes, data)
+	if err != nil {
+		p.log.Error("error", err.Error(), slog.String("operation", opPublish))
+		return fmt.Errorf("%s: %w", opPublish, err)
+	}
+	// Идентификатор конверта совпадает с идентификатором сообщения JetStream, поэтому повторная
+	// публикация того же доменного события не создаёт дубликат в потоке
+	header[headerMsgID] = attributes.ID
+	if err = p.raw.PublishMessage(topic, body, header); err != nil {
+		return fmt.Errorf("%s: %w", opPublish, err)
+	}
+	return nil
+}
+
+// attributes возвращает атрибуты конверта с идентификатором доменного события. Данные и их формат заполняет Encoder
+func (p *Publisher) attributes(k Kind, payload any) Envelope {
+	return Envelope{
+		SpecVersion: SpecVersion,
+		ID:          messageID(k, payload),
+		Type:        k.Type(),
+		Source:      Source,
+		Time:        p.now().UTC(),
+	}
+}
+
+// messageID возвращает идентификатор сообщения: UUID версии 5 от типа сообщения и ключа доменного события.
+// Один и тот же вид и ключ всегда дают один идентификатор
+func messageID(k Kind, payload any) string {
+	h := sha1.Sum([]byte(k.Type() + "\x00" + k.key(payload)))
+	b := h[:16]
+	b[6] = b[6]&0x0f | 0x50
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
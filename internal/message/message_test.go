@@ -0,0 +1,313 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	messagev1 "github.com/Telegram-bot-for-register-on-events/event-service/pkg/message/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// published сообщение, переданное в RawPublisher
+type published struct {
+	topic  string
+	data   []byte
+	header map[string]string
+}
+
+// recorder запоминает опубликованные сообщения
+type recorder struct {
+	messages []published
+}
+
+func (r *recorder) PublishMessage(topic string, data []byte, header map[string]string) error {
+	r.messages = append(r.messages, published{topic: topic, data: data, header: header})
+	return nil
+}
+
+// publishedAt время публикации сообщений в тестах
+var publishedAt = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func TestSchemasMatchKinds(t *testing.T) {
+	for _, k := range Kinds() {
+		t.Run(k.Type(), func(t *testing.T) {
+			schema, err := k.Schema()
+			if err != nil {
+				t.Fatalf("Schema: %v", err)
+			}
+			saved, err := Schemas.ReadFile("schemas/" + k.SchemaFile())
+			if err != nil {
+				t.Fatalf("schema of %s is missing, run go run ./cmd/msgschema -write: %v", k.Type(), err)
+			}
+			if !bytes.Equal(saved, schema) {
+				t.Fatalf("payload of %s changed without a version bump, increase the version in message.kinds", k.Type())
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		encoding    string
+		contentType string
+		// check проверяет данные сообщения с полезной нагрузкой value вида k
+		check func(t *testing.T, k Kind, value any, msg published)
+	}{
+		{
+			encoding:    EncodingJSON,
+			contentType: ContentTypeCloudEventsJSON,
+			check: func(t *testing.T, k Kind, value any, msg published) {
+				var envelope Envelope
+				if err := json.Unmarshal(msg.data, &envelope); err != nil {
+					t.Fatalf("unmarshal envelope: %v", err)
+				}
+				if envelope.Type != k.Type() || envelope.DataSchema != k.DataSchema() || envelope.SpecVersion != SpecVersion {
+					t.Errorf("envelope = %+v, want type %s and schema %s", envelope, k.Type(), k.DataSchema())
+				}
+				if envelope.ID == "" || msg.header[headerMsgID] != envelope.ID {
+					t.Errorf("Nats-Msg-Id = %q, envelope id = %q", msg.header[headerMsgID], envelope.ID)
+				}
+				if !envelope.Time.Equal(publishedAt) {
+					t.Errorf("envelope time = %s, want %s", envelope.Time, publishedAt)
+				}
+				want, _ := json.Marshal(value)
+				if !bytes.Equal(envelope.Data, want) {
+					t.Errorf("data = %s, want %s", envelope.Data, want)
+				}
+			},
+		},
+		{
+			encoding:    EncodingProtobuf,
+			contentType: ContentTypeProtobuf,
+			check: func(t *testing.T, k Kind, value any, msg published) {
+				if got := msg.header[headerType]; got != k.Type() {
+					t.Errorf("ce-type = %q, want %q", got, k.Type())
+				}
+				if msg.header[headerID] == "" || msg.header[headerMsgID] != msg.header[headerID] {
+					t.Errorf("Nats-Msg-Id = %q, ce-id = %q", msg.header[headerMsgID], msg.header[headerID])
+				}
+				decoded := k.toProto(value).ProtoReflect().New().Interface()
+				if err := proto.Unmarshal(msg.data, decoded); err != nil {
+					t.Fatalf("unmarshal %s: %v", k.Type(), err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		for _, k := range Kinds() {
+			value := reflect.New(k.payload)
+			payloads := []struct {
+				name    string
+				payload any
+			}{
+				{name: "value", payload: value.Elem().Interface()},
+				{name: "pointer", payload: value.Interface()},
+			}
+			for _, pl := range payloads {
+				t.Run(tt.encoding+"/"+k.Type()+"/"+pl.name, func(t *testing.T) {
+					encoder, err := NewEncoder(tt.encoding)
+					if err != nil {
+						t.Fatalf("NewEncoder: %v", err)
+					}
+					raw := &recorder{}
+					p := NewPublisher(slog.New(slog.DiscardHandler), raw, encoder, func() time.Time { return publishedAt })
+					if err = p.Publish("topic", pl.payload); err != nil {
+						t.Fatalf("Publish: %v", err)
+					}
+					msg := raw.messages[0]
+					if msg.topic != "topic" {
+						t.Errorf("topic = %q, want topic", msg.topic)
+					}
+					if got := msg.header[headerContentType]; got != tt.contentType {
+						t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+					}
+					tt.check(t, k, value.Elem().Interface(), msg)
+				})
+			}
+		}
+	}
+}
+
+func TestPublishProtobufFields(t *testing.T) {
+	sessionID := "session"
+	closesAt := time.Date(2026, 11, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		payload any
+		want    proto.Message
+	}{
+		{
+			name:    "registration confirmed",
+			payload: models.User{ChatID: 42, Username: "user", EventID: "event", SessionID: &sessionID, Guests: 2},
+			want: &messagev1.RegistrationConfirmed{
+				ChatId: 42, Username: "user", EventId: "event", SessionId: &sessionID, Guests: 2,
+			},
+		},
+		{
+			name:    "registration opened without close",
+			payload: models.RegistrationOpened{EventID: "event", StartsAt: closesAt, OpensAt: closesAt},
+			want: &messagev1.RegistrationOpened{
+				EventId: "event", StartsAt: timestamppb.New(closesAt), OpensAt: timestamppb.New(closesAt),
+			},
+		},
+		{
+			name:    "registration opened with close",
+			payload: models.RegistrationOpened{EventID: "event", StartsAt: closesAt, OpensAt: closesAt, ClosesAt: &closesAt},
+			want: &messagev1.RegistrationOpened{
+				EventId: "event", StartsAt: timestamppb.New(closesAt), OpensAt: timestamppb.New(closesAt),
+				ClosesAt: timestamppb.New(closesAt),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := NewEncoder(EncodingProtobuf)
+			if err != nil {
+				t.Fatalf("NewEncoder: %v", err)
+			}
+			raw := &recorder{}
+			p := NewPublisher(slog.New(slog.DiscardHandler), raw, encoder, func() time.Time { return publishedAt })
+			if err = p.Publish("topic", tt.payload); err != nil {
+				t.Fatalf("Publish: %v", err)
+			}
+			decoded := tt.want.ProtoReflect().New().Interface()
+			if err := proto.Unmarshal(raw.messages[0].data, decoded); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !proto.Equal(decoded, tt.want) {
+				t.Errorf("decoded = %v, want %v", decoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageIDFromDomainEvent(t *testing.T) {
+	sessionID, otherSessionID := "session", "other"
+	opensAt := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		payload     any
+		same, other any
+	}{
+		{
+			name:    "registration confirmed",
+			payload: models.User{ChatID: 42, EventID: "event", SessionID: &sessionID, TicketToken: "token"},
+			same:    &models.User{ChatID: 42, Username: "renamed", EventID: "event", SessionID: &sessionID, TicketToken: "token"},
+			other:   models.User{ChatID: 42, EventID: "event", SessionID: &sessionID, TicketToken: "reissued"},
+		},
+		{
+			name:    "payment confirmed",
+			payload: models.PaymentConfirmed{PaymentID: "payment", RegistrationID: "registration"},
+			same:    models.PaymentConfirmed{PaymentID: "payment", RegistrationID: "registration", Amount: 100},
+			other:   models.PaymentConfirmed{PaymentID: "retry", RegistrationID: "registration"},
+		},
+		{
+			name:    "checked in",
+			payload: models.CheckIn{RegistrationID: "registration", SessionID: &sessionID},
+			same:    models.CheckIn{RegistrationID: "registration", SessionID: &sessionID, CheckedInAt: opensAt},
+			other:   models.CheckIn{RegistrationID: "registration", SessionID: &otherSessionID},
+		},
+		{
+			name:    "decision",
+			payload: models.RegistrationDecision{RegistrationID: "registration", Decision: models.DecisionApproved},
+			same:    models.RegistrationDecision{RegistrationID: "registration", Decision: models.DecisionApproved, DecidedAt: opensAt},
+			other:   models.RegistrationDecision{RegistrationID: "registration", Decision: models.DecisionRejected},
+		},
+		{
+			name:    "registration opened",
+			payload: models.RegistrationOpened{EventID: "event", OpensAt: opensAt},
+			same:    models.RegistrationOpened{EventID: "event", OpensAt: opensAt.In(time.FixedZone("MSK", 3*60*60)), Title: "renamed"},
+			other:   models.RegistrationOpened{EventID: "event", OpensAt: opensAt.Add(time.Hour)},
+		},
+		{
+			name:    "kind",
+			payload: models.PaymentConfirmed{PaymentID: "id"},
+			same:    models.PaymentConfirmed{PaymentID: "id", RegistrationID: "registration"},
+			other:   models.RegistrationDecision{RegistrationID: "id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := NewEncoder(EncodingJSON)
+			if err != nil {
+				t.Fatalf("NewEncoder: %v", err)
+			}
+			raw := &recorder{}
+			p := NewPublisher(slog.New(slog.DiscardHandler), raw, encoder, func() time.Time { return publishedAt })
+			for _, payload := range []any{tt.payload, tt.payload, tt.same, tt.other} {
+				if err := p.Publish("topic", payload); err != nil {
+					t.Fatalf("Publish: %v", err)
+				}
+			}
+			ids := make([]string, len(raw.messages))
+			for i, msg := range raw.messages {
+				ids[i] = msg.header[headerMsgID]
+			}
+			if len(ids[0]) != 36 || ids[0][14] != '5' {
+				t.Errorf("id = %q, want UUID version 5", ids[0])
+			}
+			if ids[1] != ids[0] {
+				t.Errorf("republished id = %q, want %q", ids[1], ids[0])
+			}
+			if ids[2] != ids[0] {
+				t.Errorf("id of the same event = %q, want %q", ids[2], ids[0])
+			}
+			if ids[3] == ids[0] {
+				t.Errorf("id of another event = %q, want it to differ", ids[3])
+			}
+		})
+	}
+}
+
+func TestPublishUnknownPayload(t *testing.T) {
+	var nilUser *models.User
+	tests := []struct {
+		name    string
+		payload any
+	}{
+		{name: "unregistered type", payload: struct{ ID string }{ID: "id"}},
+		{name: "nil"},
+		{name: "nil pointer", payload: nilUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := NewEncoder(EncodingJSON)
+			if err != nil {
+				t.Fatalf("NewEncoder: %v", err)
+			}
+			raw := &recorder{}
+			p := NewPublisher(slog.New(slog.DiscardHandler), raw, encoder, func() time.Time { return publishedAt })
+			if err = p.Publish("topic", tt.payload); !errors.Is(err, ErrUnknownPayload) {
+				t.Fatalf("Publish error = %v, want ErrUnknownPayload", err)
+			}
+			if len(raw.messages) != 0 {
+				t.Fatalf("published %d messages, want 0", len(raw.messages))
+			}
+		})
+	}
+}
+
+func TestNewEncoder(t *testing.T) {
+	tests := []struct {
+		encoding string
+		wantErr  bool
+	}{
+		{encoding: EncodingJSON},
+		{encoding: EncodingProtobuf},
+		{encoding: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			if _, err := NewEncoder(tt.encoding); (err != nil) != tt.wantErr {
+				t.Fatalf("NewEncoder(%q) error = %v, want error %v", tt.encoding, err, tt.wantErr)
+			}
+		})
+	}
+}
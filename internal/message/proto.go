@@ -0,0 +1,89 @@
+package message
+
+import (
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	messagev1 "github.com/Telegram-bot-for-register-on-events/event-service/pkg/message/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// registrationConfirmedV1 преобразует подтверждённую регистрацию в protobuf-сообщение версии 1
+func registrationConfirmedV1(u models.User) proto.Message {
+	return &messagev1.RegistrationConfirmed{
+		ChatId:       u.ChatID,
+		Username:     u.Username,
+		EventId:      u.EventID,
+		SessionId:    u.SessionID,
+		TicketTypeId: u.TicketTypeID,
+		TicketToken:  u.TicketToken,
+		Guests:       int32(u.Guests),
+	}
+}
+
+// paymentConfirmedV1 преобразует подтверждённую оплату в protobuf-сообщение версии 1
+func paymentConfirmedV1(p models.PaymentConfirmed) proto.Message {
+	return &messagev1.PaymentConfirmed{
+		RegistrationId: p.RegistrationID,
+		PaymentId:      p.PaymentID,
+		EventId:        p.EventID,
+		ChatId:         p.ChatID,
+		Username:       p.Username,
+		SessionId:      p.SessionID,
+		TicketTypeId:   p.TicketTypeID,
+		Amount:         p.Amount,
+		Currency:       p.Currency,
+		TicketToken:    p.TicketToken,
+	}
+}
+
+// registrationCheckedInV1 преобразует проход по билету в protobuf-сообщение версии 1
+func registrationCheckedInV1(c models.CheckIn) proto.Message {
+	return &messagev1.RegistrationCheckedIn{
+		RegistrationId: c.RegistrationID,
+		EventId:        c.EventID,
+		SessionId:      c.SessionID,
+		ChatId:         c.ChatID,
+		Username:       c.Username,
+		CheckedInAt:    timestamppb.New(c.CheckedInAt),
+	}
+}
+
+// registrationDecisionV1 преобразует решение по заявке в protobuf-сообщение версии 1
+func registrationDecisionV1(d models.RegistrationDecision) proto.Message {
+	return &messagev1.RegistrationDecision{
+		RegistrationId:   d.RegistrationID,
+		EventId:          d.EventID,
+		SessionId:        d.SessionID,
+		ChatId:           d.ChatID,
+		Username:         d.Username,
+		Decision:         string(d.Decision),
+		Status:           string(d.Status),
+		Reason:           d.Reason,
+		TicketToken:      d.TicketToken,
+		PaymentUrl:       d.PaymentURL,
+		PaymentExpiresAt: timestampOrNil(d.PaymentExpiresAt),
+		DecidedAt:        timestamppb.New(d.DecidedAt),
+	}
+}
+
+// registrationOpenedV1 преобразует открытие регистрации в protobuf-сообщение версии 1
+func registrationOpenedV1(o models.RegistrationOpened) proto.Message {
+	return &messagev1.RegistrationOpened{
+		EventId:  o.EventID,
+		Title:    o.Title,
+		StartsAt: timestamppb.New(o.StartsAt),
+		TimeZone: o.TimeZone,
+		OpensAt:  timestamppb.New(o.OpensAt),
+		ClosesAt: timestampOrNil(o.ClosesAt),
+	}
+}
+
+// timestampOrNil преобразует необязательное время в Timestamp. nil остаётся незаданным полем
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
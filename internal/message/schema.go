@@ -0,0 +1,99 @@
+package message
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schemas сгенерированные JSON Schema данных всех версий сообщений. Схемы прежних версий сохраняются,
+// пока их могут получать потребители
+//
+//go:embed schemas/*.json
+var Schemas embed.FS
+
+// jsonSchemaDraft версия JSON Schema, по которой генерируются схемы
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// timeType тип времени, которое кодируется строкой RFC 3339
+var timeType = reflect.TypeFor[time.Time]()
+
+// Schema генерирует JSON Schema данных сообщения по их Go-типу. Обязательными считаются поля без omitempty
+func (k Kind) Schema() ([]byte, error) {
+	schema := typeSchema(k.payload)
+	schema["$schema"] = jsonSchemaDraft
+	schema["$id"] = k.DataSchema()
+	schema["title"] = k.Type()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(schema); err != nil {
+		return nil, fmt.Errorf("schema %s: %w", k.Type(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// typeSchema возвращает JSON Schema значения Go-типа t в кодировке encoding/json
+func typeSchema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := typeSchema(t.Elem())
+		schema["type"] = []any{schema["type"], "null"}
+		return schema
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": []any{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": []any{"object", "null"}, "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	}
+	return map[string]any{}
+}
+
+// structSchema возвращает JSON Schema структуры. Неизвестные поля запрещены, чтобы любое изменение
+// набора полей меняло схему
+func structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = typeSchema(f.Type)
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
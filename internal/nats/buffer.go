@@ -0,0 +1,62 @@
+package nats
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ErrBufferFull возвращается, если NATS недоступен, а локальный буфер сообщений заполнен
+var ErrBufferFull = errors.New("nats publish buffer is full")
+
+// buffer ограниченная очередь сообщений, ожидающих публикации
+type buffer struct {
+	mu       sync.Mutex
+	size     int
+	messages []*nats.Msg
+}
+
+// newBuffer конструктор для buffer на size сообщений
+func newBuffer(size int) *buffer {
+	return &buffer{size: size}
+}
+
+// push добавляет сообщение в конец очереди
+func (b *buffer) push(msg *nats.Msg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.messages) >= b.size {
+		return ErrBufferFull
+	}
+	b.messages = append(b.messages, msg)
+	return nil
+}
+
+// peek возвращает первое сообщение очереди, не удаляя его
+func (b *buffer) peek() (*nats.Msg, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.messages) == 0 {
+		return nil, false
+	}
+	return b.messages[0], true
+}
+
+// pop удаляет первое сообщение очереди
+func (b *buffer) pop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.messages) == 0 {
+		return
+	}
+	b.messages[0] = nil
+	b.messages = b.messages[1:]
+}
+
+// len возвращает количество сообщений в очереди
+func (b *buffer) len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.messages)
+}
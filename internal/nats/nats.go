@@ -1,8 +1,13 @@
 package nats
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"github.com/nats-io/nats.go"
 )
@@ -13,18 +18,59 @@ const (
 	opCreateJetStream = "nats.CreateJetStreamContext"
 	opCreateStream    = "nats.CreateStream"
 	opPubMessage      = "nats.PublishMessage"
+	opFlush           = "nats.Flush"
+	opClose           = "nats.Close"
 )
 
+// Options описывает настройки подключения к NATS и буфера публикаций
+type Options struct {
+	// ReconnectWait пауза между попытками переподключения, она же начальная пауза между попытками
+	// подключения при запуске
+	ReconnectWait time.Duration
+	// MaxReconnects сколько раз подряд клиент пытается переподключиться, прежде чем закрыть соединение.
+	// 0 - без ограничения
+	MaxReconnects int
+	// ConnectAttempts сколько раз сервис пытается подключиться при запуске
+	ConnectAttempts int
+	// ConnectBackoffMax максимальная пауза между попытками подключения при запуске
+	ConnectBackoffMax time.Duration
+	// BufferSize сколько сообщений хранится в локальном буфере, пока NATS недоступен. 0 - буфер отключён
+	BufferSize int
+	// RetryInterval как часто повторяется публикация сообщений из буфера
+	RetryInterval time.Duration
+}
+
 // Nats описывает брокер сообщений
 type Nats struct {
 	log  *slog.Logger
 	Conn *nats.Conn
 	js   nats.JetStreamContext
+	// connected состояние соединения, обновляется обработчиками отключения и переподключения
+	connected atomic.Bool
+	buffer    *buffer
+	// dial и sleep подключаются к NATS и выдерживают паузу между попытками подключения при запуске
+	dial  func(url string, options ...nats.Option) (*nats.Conn, error)
+	sleep func(time.Duration)
+	// wake запускает публикацию сообщений из буфера, не дожидаясь RetryInterval
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
 }
 
-// NewNats конструктор для Nats
-func NewNats(log *slog.Logger, url string) (*Nats, error) {
-	nc, err := nats.Connect(url)
+// NewNats конструктор для Nats. Если NATS недоступен при запуске, повторяет подключение
+// opts.ConnectAttempts раз с экспоненциально растущей паузой
+func NewNats(log *slog.Logger, url string, opts Options) (*Nats, error) {
+	n := &Nats{
+		log:    log,
+		buffer: newBuffer(opts.BufferSize),
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		dial:   nats.Connect,
+		sleep:  time.Sleep,
+	}
+
+	nc, err := n.connect(url, opts)
 	if err != nil {
 		log.Error("error", err.Error(), slog.String("operation", opConn))
 		return nil, fmt.Errorf("%s: %w", opConn, err)
@@ -33,43 +79,303 @@ func NewNats(log *slog.Logger, url string) (*Nats, error) {
 
 	js, err := nc.JetStream()
 	if err != nil {
+		nc.Close()
 		log.Error("error", err.Error(), slog.String("operation", opCreateJetStream))
 		return nil, fmt.Errorf("%s: %w", opCreateJetStream, err)
 	}
 	log.Info("create jetstream context", slog.String("operation", opCreateJetStream))
 
-	return &Nats{
-		log:  log,
-		Conn: nc,
-		js:   js,
-	}, nil
+	n.Conn = nc
+	n.js = js
+	go n.flushLoop(opts.RetryInterval)
+
+	return n, nil
+}
+
+// connect подключается к NATS, повторяя попытки при ошибке
+func (n *Nats) connect(url string, opts Options) (*nats.Conn, error) {
+	maxReconnects := opts.MaxReconnects
+	if maxReconnects == 0 {
+		maxReconnects = -1
+	}
+	options := []nats.Option{
+		nats.ReconnectWait(opts.ReconnectWait),
+		nats.MaxReconnects(maxReconnects),
+		nats.DisconnectErrHandler(n.onDisconnect),
+		nats.ReconnectHandler(n.onReconnect),
+		nats.ClosedHandler(n.onClosed),
+	}
+
+	backoff := opts.ReconnectWait
+	for attempt := 1; ; attempt++ {
+		// Соединение считается установленным до вызова dial: обработчики, сработавшие сразу после подключения,
+		// выполняются позже и не перезаписываются
+		n.connected.Store(true)
+		nc, err := n.dial(url, options...)
+		if err == nil {
+			return nc, nil
+		}
+		n.connected.Store(false)
+		if attempt >= opts.ConnectAttempts {
+			return nil, err
+		}
+		n.log.Warn("nats is unavailable, retrying", slog.String("operation", opConn), slog.String("error", err.Error()),
+			slog.Int("attempt", attempt), slog.Duration("backoff", backoff))
+		n.sleep(backoff)
+		backoff = min(backoff*2, opts.ConnectBackoffMax)
+	}
+}
+
+// onDisconnect вызывается клиентом NATS при потере соединения
+func (n *Nats) onDisconnect(_ *nats.Conn, err error) {
+	n.connected.Store(false)
+	if err != nil {
+		n.log.Warn("disconnected from nats", slog.String("operation", opConn), slog.String("error", err.Error()))
+		return
+	}
+	n.log.Warn("disconnected from nats", slog.String("operation", opConn))
+}
+
+// onReconnect вызывается клиентом NATS после восстановления соединения и запускает публикацию из буфера
+func (n *Nats) onReconnect(nc *nats.Conn) {
+	n.connected.Store(true)
+	n.log.Info("reconnected to "+nc.ConnectedUrl(), slog.String("operation", opConn))
+	n.signal()
+}
+
+// onClosed вызывается клиентом NATS, когда соединение закрыто окончательно: при остановке сервиса
+// или после исчерпания попыток переподключения
+func (n *Nats) onClosed(_ *nats.Conn) {
+	n.connected.Store(false)
+	n.log.Info("nats connection closed", slog.String("operation", opConn))
+}
+
+// Healthy сообщает, есть ли соединение с NATS
+func (n *Nats) Healthy() bool {
+	return n.connected.Load()
 }
 
-// CreateStream создаёт поток и топики
-func (n *Nats) CreateStream(name string, subjects []string) (*nats.StreamInfo, error) {
-	res, err := n.js.AddStream(&nats.StreamConfig{
-		Name:     name,
-		Subjects: subjects,
-	})
+// Close останавливает публикацию из буфера, в последний раз пытается опубликовать оставшиеся
+// в буфере сообщения и закрывает соединение
+func (n *Nats) Close() {
+	close(n.stop)
+	<-n.done
+	n.flush()
+	if lost := n.buffer.len(); lost > 0 {
+		n.log.Error("buffered messages are lost", slog.String("operation", opClose), slog.Int("count", lost))
+	}
+	n.Conn.Close()
+}
+
+// Политики хранения сообщений потока
+const (
+	RetentionLimits    = "limits"
+	RetentionInterest  = "interest"
+	RetentionWorkQueue = "workqueue"
+)
+
+// Типы хранилища потока
+const (
+	StorageFile   = "file"
+	StorageMemory = "memory"
+)
+
+// StreamConfig описывает настройки потока JetStream
+type StreamConfig struct {
+	Name     string
+	Subjects []string
+	// Retention политика хранения: limits, interest или workqueue
+	Retention string
+	// Storage тип хранилища: file или memory
+	Storage string
+	// MaxAge максимальный возраст сообщений, 0 - без ограничения
+	MaxAge time.Duration
+	// MaxBytes максимальный размер потока в байтах, 0 - без ограничения
+	MaxBytes int64
+	// Replicas количество реплик потока в кластере
+	Replicas int
+	// DuplicateWindow окно, в течение которого сообщения с уже опубликованным Nats-Msg-Id отбрасываются
+	DuplicateWindow time.Duration
+}
+
+// CreateStream создаёт поток с заданными настройками. Если поток уже существует и его настройки отличаются,
+// обновляет их, если совпадают - ничего не меняет
+func (n *Nats) CreateStream(cfg StreamConfig) (*nats.StreamInfo, error) {
+	streamCfg, err := streamConfig(cfg)
+	if err != nil {
+		n.log.Error("error", err.Error(), slog.String("operation", opCreateStream))
+		return nil, fmt.Errorf("%s: %w", opCreateStream, err)
+	}
+
+	res, err := n.js.StreamInfo(cfg.Name)
+	switch {
+	case errors.Is(err, nats.ErrStreamNotFound):
+		res, err = n.js.AddStream(streamCfg)
+		if errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+			res, err = n.js.UpdateStream(streamCfg)
+		}
+	case err == nil && sameStreamConfig(res.Config, *streamCfg):
+		n.log.Info("stream "+cfg.Name+" is up to date", slog.String("operation", opCreateStream))
+		return res, nil
+	case err == nil:
+		res, err = n.js.UpdateStream(streamCfg)
+	}
 	if err != nil {
 		n.log.Error("error", err.Error(), slog.String("operation", opCreateStream))
 		return nil, fmt.Errorf("%s: %w", opCreateStream, err)
 	}
 
-	n.log.Info("create stream "+name, slog.String("operation", opCreateStream))
+	n.log.Info("create stream "+cfg.Name, slog.String("operation", opCreateStream))
 
 	return res, nil
 }
 
-// PublishMessage публикует сообщений в соответствующий топик
-func (n *Nats) PublishMessage(topic string, data []byte) error {
-	_, err := n.js.Publish(topic, data)
-	if err != nil {
+// streamConfig преобразует настройки потока в конфигурацию JetStream
+func streamConfig(cfg StreamConfig) (*nats.StreamConfig, error) {
+	var retention nats.RetentionPolicy
+	switch cfg.Retention {
+	case RetentionLimits:
+		retention = nats.LimitsPolicy
+	case RetentionInterest:
+		retention = nats.InterestPolicy
+	case RetentionWorkQueue:
+		retention = nats.WorkQueuePolicy
+	default:
+		return nil, fmt.Errorf("unknown stream retention policy %q", cfg.Retention)
+	}
+
+	var storage nats.StorageType
+	switch cfg.Storage {
+	case StorageFile:
+		storage = nats.FileStorage
+	case StorageMemory:
+		storage = nats.MemoryStorage
+	default:
+		return nil, fmt.Errorf("unknown stream storage type %q", cfg.Storage)
+	}
+
+	// В JetStream отсутствие ограничения размера обозначается -1
+	maxBytes := cfg.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = -1
+	}
+
+	return &nats.StreamConfig{
+		Name:       cfg.Name,
+		Subjects:   cfg.Subjects,
+		Retention:  retention,
+		Storage:    storage,
+		MaxAge:     cfg.MaxAge,
+		MaxBytes:   maxBytes,
+		Replicas:   cfg.Replicas,
+		Duplicates: cfg.DuplicateWindow,
+	}, nil
+}
+
+// sameStreamConfig проверяет, совпадают ли настраиваемые параметры существующего потока с заданными
+func sameStreamConfig(current, wanted nats.StreamConfig) bool {
+	currentSubjects := slices.Sorted(slices.Values(current.Subjects))
+	wantedSubjects := slices.Sorted(slices.Values(wanted.Subjects))
+	return slices.Equal(currentSubjects, wantedSubjects) &&
+		current.Retention == wanted.Retention &&
+		current.Storage == wanted.Storage &&
+		current.MaxAge == wanted.MaxAge &&
+		current.MaxBytes == wanted.MaxBytes &&
+		current.Replicas == wanted.Replicas &&
+		current.Duplicates == wanted.Duplicates
+}
+
+// PublishMessage публикует сообщение с заголовками в соответствующий топик. Если NATS недоступен,
+// сообщение сохраняется в буфер и публикуется после восстановления соединения. Ошибка возвращается,
+// только если буфер заполнен или сообщение отклонено NATS
+func (n *Nats) PublishMessage(topic string, data []byte, header map[string]string) error {
+	msg := nats.NewMsg(topic)
+	msg.Data = data
+	for key, value := range header {
+		msg.Header.Set(key, value)
+	}
+
+	// Пока в буфере есть сообщения, новые встают за ними, чтобы сохранить порядок публикации
+	if n.connected.Load() && n.buffer.len() == 0 {
+		_, err := n.js.PublishMsg(msg)
+		if err == nil {
+			n.log.Info("pub to "+topic, slog.String("operation", opPubMessage))
+			return nil
+		}
+		if !retryable(err) {
+			n.log.Error("error", err.Error(), slog.String("operation", opPubMessage))
+			return fmt.Errorf("%s: %w", opPubMessage, err)
+		}
+		n.log.Warn("failed to publish, buffering", slog.String("operation", opPubMessage), slog.String("error", err.Error()))
+	}
+
+	if err := n.buffer.push(msg); err != nil {
 		n.log.Error("error", err.Error(), slog.String("operation", opPubMessage))
 		return fmt.Errorf("%s: %w", opPubMessage, err)
 	}
-
-	n.log.Info("pub to "+topic, slog.String("operation", opPubMessage))
+	n.log.Warn("nats is unavailable, message buffered to "+topic, slog.String("operation", opPubMessage),
+		slog.Int("buffered", n.buffer.len()))
+	n.signal()
 
 	return nil
 }
+
+// signal запускает публикацию сообщений из буфера
+func (n *Nats) signal() {
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// flushLoop публикует сообщения из буфера при восстановлении соединения и каждые interval
+func (n *Nats) flushLoop(interval time.Duration) {
+	defer close(n.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-n.wake:
+		case <-ticker.C:
+		}
+		n.flush()
+	}
+}
+
+// flush публикует сообщения из буфера по порядку, пока есть соединение. Повторная публикация сообщения,
+// которое уже дошло до JetStream, отбрасывается потоком по заголовку Nats-Msg-Id
+func (n *Nats) flush() {
+	for n.connected.Load() {
+		msg, ok := n.buffer.peek()
+		if !ok {
+			return
+		}
+		_, err := n.js.PublishMsg(msg)
+		if err != nil && retryable(err) {
+			n.log.Warn("failed to publish buffered message", slog.String("operation", opFlush), slog.String("error", err.Error()),
+				slog.Int("buffered", n.buffer.len()))
+			return
+		}
+		n.buffer.pop()
+		if err != nil {
+			n.log.Error("error", err.Error(), slog.String("operation", opFlush), slog.String("topic", msg.Subject))
+			continue
+		}
+		n.log.Info("pub buffered message to "+msg.Subject, slog.String("operation", opFlush))
+	}
+}
+
+// retryable проверяет, вызвана ли ошибка публикации недоступностью NATS или JetStream
+func retryable(err error) bool {
+	return errors.Is(err, nats.ErrTimeout) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, nats.ErrNoResponders) ||
+		errors.Is(err, nats.ErrNoStreamResponse) ||
+		errors.Is(err, nats.ErrConnectionClosed) ||
+		errors.Is(err, nats.ErrConnectionReconnecting) ||
+		errors.Is(err, nats.ErrConnectionDraining) ||
+		errors.Is(err, nats.ErrDisconnected)
+}
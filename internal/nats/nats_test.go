@@ -0,0 +1,249 @@
+package nats
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// jetStreamStub запоминает топики опубликованных сообщений. errs - ошибки публикации по порядку вызовов,
+// после них публикация выполняется успешно
+type jetStreamStub struct {
+	nats.JetStreamContext
+	errs      []error
+	calls     int
+	published []string
+}
+
+func (j *jetStreamStub) PublishMsg(msg *nats.Msg, _ ...nats.PubOpt) (*nats.PubAck, error) {
+	j.calls++
+	if j.calls <= len(j.errs) && j.errs[j.calls-1] != nil {
+		return nil, j.errs[j.calls-1]
+	}
+	j.published = append(j.published, msg.Subject)
+	return &nats.PubAck{}, nil
+}
+
+func TestPublishMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		connected bool
+		// errs ошибки публикации по порядку вызовов
+		errs          []error
+		topics        []string
+		wantErr       []error
+		wantPublished []string
+		wantBuffered  int
+	}{
+		{
+			name:          "connected",
+			size:          2,
+			connected:     true,
+			topics:        []string{"a", "b"},
+			wantErr:       []error{nil, nil},
+			wantPublished: []string{"a", "b"},
+		},
+		{
+			name:         "disconnected",
+			size:         2,
+			topics:       []string{"a", "b"},
+			wantErr:      []error{nil, nil},
+			wantBuffered: 2,
+		},
+		{
+			name:         "buffer overflow",
+			size:         2,
+			topics:       []string{"a", "b", "c"},
+			wantErr:      []error{nil, nil, ErrBufferFull},
+			wantBuffered: 2,
+		},
+		{
+			name:         "buffer disabled",
+			topics:       []string{"a"},
+			wantErr:      []error{ErrBufferFull},
+			wantBuffered: 0,
+		},
+		{
+			name:      "unavailable, then queued behind buffer",
+			size:      2,
+			connected: true,
+			errs:      []error{nats.ErrNoResponders},
+			topics:    []string{"a", "b"},
+			wantErr:   []error{nil, nil},
+			// Второе сообщение встаёт в буфер за первым, хотя соединение есть
+			wantBuffered: 2,
+		},
+		{
+			name:         "rejected",
+			size:         2,
+			connected:    true,
+			errs:         []error{nats.ErrBadSubject},
+			topics:       []string{"a"},
+			wantErr:      []error{nats.ErrBadSubject},
+			wantBuffered: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			js := &jetStreamStub{errs: tt.errs}
+			n := &Nats{log: slog.New(slog.DiscardHandler), js: js, buffer: newBuffer(tt.size), wake: make(chan struct{}, 1)}
+			n.connected.Store(tt.connected)
+
+			for i, topic := range tt.topics {
+				if err := n.PublishMessage(topic, nil, nil); !errors.Is(err, tt.wantErr[i]) {
+					t.Fatalf("PublishMessage(%s) error = %v, want %v", topic, err, tt.wantErr[i])
+				}
+			}
+			if !slices.Equal(js.published, tt.wantPublished) {
+				t.Errorf("published = %v, want %v", js.published, tt.wantPublished)
+			}
+			if got := n.buffer.len(); got != tt.wantBuffered {
+				t.Errorf("buffered = %d, want %d", got, tt.wantBuffered)
+			}
+		})
+	}
+}
+
+func TestFlush(t *testing.T) {
+	tests := []struct {
+		name      string
+		connected bool
+		// errs ошибки публикации по порядку вызовов
+		errs          []error
+		wantPublished []string
+		wantBuffered  []string
+	}{
+		{
+			name:          "in order",
+			connected:     true,
+			wantPublished: []string{"a", "b", "c"},
+		},
+		{
+			name:         "disconnected",
+			wantBuffered: []string{"a", "b", "c"},
+		},
+		{
+			name:          "stops on unavailable",
+			connected:     true,
+			errs:          []error{nil, nats.ErrTimeout},
+			wantPublished: []string{"a"},
+			wantBuffered:  []string{"b", "c"},
+		},
+		{
+			name:          "drops rejected",
+			connected:     true,
+			errs:          []error{nil, nats.ErrBadSubject},
+			wantPublished: []string{"a", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			js := &jetStreamStub{errs: tt.errs}
+			n := &Nats{log: slog.New(slog.DiscardHandler), js: js, buffer: newBuffer(3)}
+			for _, topic := range []string{"a", "b", "c"} {
+				if err := n.buffer.push(nats.NewMsg(topic)); err != nil {
+					t.Fatalf("push: %v", err)
+				}
+			}
+			n.connected.Store(tt.connected)
+
+			n.flush()
+			if !slices.Equal(js.published, tt.wantPublished) {
+				t.Errorf("published = %v, want %v", js.published, tt.wantPublished)
+			}
+			var buffered []string
+			for _, msg := range n.buffer.messages {
+				buffered = append(buffered, msg.Subject)
+			}
+			if !slices.Equal(buffered, tt.wantBuffered) {
+				t.Errorf("buffered = %v, want %v", buffered, tt.wantBuffered)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	unavailable := errors.New("no servers available for connection")
+	tests := []struct {
+		name     string
+		attempts int
+		// failures сколько первых попыток подключения завершаются ошибкой
+		failures int
+		// disconnect обработчик отключения срабатывает до того, как подключение вернуло соединение
+		disconnect    bool
+		wantErr       error
+		wantBackoffs  []time.Duration
+		wantConnected bool
+	}{
+		{
+			name:          "first attempt",
+			attempts:      3,
+			wantConnected: true,
+		},
+		{
+			name:          "after retries",
+			attempts:      4,
+			failures:      3,
+			wantBackoffs:  []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			wantConnected: true,
+		},
+		{
+			name:         "attempts exhausted",
+			attempts:     2,
+			failures:     2,
+			wantErr:      unavailable,
+			wantBackoffs: []time.Duration{time.Second},
+		},
+		{
+			name:       "disconnected during connect",
+			attempts:   1,
+			disconnect: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var backoffs []time.Duration
+			calls := 0
+			n := &Nats{
+				log:   slog.New(slog.DiscardHandler),
+				sleep: func(d time.Duration) { backoffs = append(backoffs, d) },
+				dial: func(_ string, options ...nats.Option) (*nats.Conn, error) {
+					calls++
+					if calls <= tt.failures {
+						return nil, unavailable
+					}
+					if tt.disconnect {
+						opts := nats.GetDefaultOptions()
+						for _, o := range options {
+							if err := o(&opts); err != nil {
+								t.Fatalf("apply option: %v", err)
+							}
+						}
+						opts.DisconnectedErrCB(nil, unavailable)
+					}
+					return &nats.Conn{}, nil
+				},
+			}
+
+			_, err := n.connect("nats://localhost:4222", Options{
+				ReconnectWait:     time.Second,
+				ConnectAttempts:   tt.attempts,
+				ConnectBackoffMax: 3 * time.Second,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("connect error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(backoffs, tt.wantBackoffs) {
+				t.Errorf("backoffs = %v, want %v", backoffs, tt.wantBackoffs)
+			}
+			if n.Healthy() != tt.wantConnected {
+				t.Errorf("Healthy = %v, want %v", n.Healthy(), tt.wantConnected)
+			}
+		})
+	}
+}
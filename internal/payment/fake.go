@@ -0,0 +1,90 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// FakeProviderName название фейкового провайдера
+const FakeProviderName = "fake"
+
+// FakeSignatureHeader заголовок с подписью уведомления фейкового провайдера: HMAC-SHA256 тела в hex
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// fakeNotification описывает тело уведомления фейкового провайдера
+type fakeNotification struct {
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+}
+
+// Fake платёжный провайдер для тестов и локального запуска. Платёж ничего не списывает, а его результат
+// передаётся уведомлением вида {"payment_id": "...", "status": "succeeded"} на адрес приёма уведомлений
+type Fake struct {
+	checkoutURL string
+	secret      []byte
+}
+
+// NewFake конструктор для Fake. checkoutURL - адрес страницы оплаты, к которому добавляется идентификатор платежа,
+// secret - ключ подписи уведомлений. С пустым ключом провайдер отклоняет все уведомления
+func NewFake(checkoutURL, secret string) *Fake {
+	return &Fake{
+		checkoutURL: strings.TrimRight(checkoutURL, "/"),
+		secret:      []byte(secret),
+	}
+}
+
+// Name возвращает название провайдера
+func (f *Fake) Name() string {
+	return FakeProviderName
+}
+
+// CreatePayment создаёт платёж со случайным идентификатором
+func (f *Fake) CreatePayment(_ context.Context, _ models.Payment) (models.ProviderPayment, error) {
+	id := "fake_" + rand.Text()
+	return models.ProviderPayment{
+		ID:              id,
+		ConfirmationURL: f.checkoutURL + "/" + url.PathEscape(id),
+	}, nil
+}
+
+// ParseWebhook проверяет подпись уведомления и извлекает из него результат платежа.
+// Уведомления без подписи отклоняются
+func (f *Fake) ParseWebhook(header http.Header, body []byte) (models.PaymentNotification, error) {
+	if len(f.secret) == 0 {
+		return models.PaymentNotification{}, ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(got, f.Sign(body)) {
+		return models.PaymentNotification{}, ErrInvalidSignature
+	}
+
+	var n fakeNotification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return models.PaymentNotification{}, fmt.Errorf("%w: %v", ErrMalformedNotification, err)
+	}
+	if n.PaymentID == "" {
+		return models.PaymentNotification{}, fmt.Errorf("%w: payment_id is required", ErrMalformedNotification)
+	}
+	status := models.PaymentStatus(n.Status)
+	if status != models.PaymentSucceeded && status != models.PaymentFailed {
+		return models.PaymentNotification{}, fmt.Errorf("%w: status must be succeeded or failed", ErrMalformedNotification)
+	}
+	return models.PaymentNotification{ProviderPaymentID: n.PaymentID, Status: status}, nil
+}
+
+// Sign возвращает подпись тела уведомления
+func (f *Fake) Sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
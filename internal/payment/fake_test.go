@@ -0,0 +1,139 @@
+package payment
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+const testSecret = "webhook-secret"
+
+// signedHeader возвращает заголовки с подписью тела ключом secret
+func signedHeader(secret string, body []byte) http.Header {
+	header := http.Header{}
+	header.Set(FakeSignatureHeader, hex.EncodeToString(NewFake("", secret).Sign(body)))
+	return header
+}
+
+func TestFakeParseWebhook(t *testing.T) {
+	succeeded := []byte(`{"payment_id": "fake_1", "status": "succeeded"}`)
+	tests := []struct {
+		name    string
+		secret  string
+		header  http.Header
+		body    []byte
+		want    models.PaymentNotification
+		wantErr error
+	}{
+		{
+			name:   "succeeded",
+			secret: testSecret,
+			header: signedHeader(testSecret, succeeded),
+			body:   succeeded,
+			want:   models.PaymentNotification{ProviderPaymentID: "fake_1", Status: models.PaymentSucceeded},
+		},
+		{
+			name:   "failed",
+			secret: testSecret,
+			header: signedHeader(testSecret, []byte(`{"payment_id": "fake_1", "status": "failed"}`)),
+			body:   []byte(`{"payment_id": "fake_1", "status": "failed"}`),
+			want:   models.PaymentNotification{ProviderPaymentID: "fake_1", Status: models.PaymentFailed},
+		},
+		{
+			name:    "unsigned",
+			secret:  testSecret,
+			header:  http.Header{},
+			body:    succeeded,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "signature is not hex",
+			secret:  testSecret,
+			header:  http.Header{FakeSignatureHeader: []string{"not-hex"}},
+			body:    succeeded,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "signed with another key",
+			secret:  testSecret,
+			header:  signedHeader("another-secret", succeeded),
+			body:    succeeded,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "body changed after signing",
+			secret:  testSecret,
+			header:  signedHeader(testSecret, succeeded),
+			body:    []byte(`{"payment_id": "fake_2", "status": "succeeded"}`),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "empty secret rejects everything",
+			secret:  "",
+			header:  signedHeader("", succeeded),
+			body:    succeeded,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "malformed json",
+			secret:  testSecret,
+			header:  signedHeader(testSecret, []byte(`{`)),
+			body:    []byte(`{`),
+			wantErr: ErrMalformedNotification,
+		},
+		{
+			name:    "missing payment id",
+			secret:  testSecret,
+			header:  signedHeader(testSecret, []byte(`{"status": "succeeded"}`)),
+			body:    []byte(`{"status": "succeeded"}`),
+			wantErr: ErrMalformedNotification,
+		},
+		{
+			name:    "unknown status",
+			secret:  testSecret,
+			header:  signedHeader(testSecret, []byte(`{"payment_id": "fake_1", "status": "pending"}`)),
+			body:    []byte(`{"payment_id": "fake_1", "status": "pending"}`),
+			wantErr: ErrMalformedNotification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFake("http://localhost/pay", tt.secret).ParseWebhook(tt.header, tt.body)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseWebhook error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWebhook: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseWebhook = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakeCreatePayment(t *testing.T) {
+	f := NewFake("http://localhost/pay/", testSecret)
+	first, err := f.CreatePayment(context.Background(), models.Payment{})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	second, err := f.CreatePayment(context.Background(), models.Payment{})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	if !strings.HasPrefix(first.ID, "fake_") || first.ID == second.ID {
+		t.Errorf("payment ids = %q, %q, want unique fake_ ids", first.ID, second.ID)
+	}
+	if want := "http://localhost/pay/" + first.ID; first.ConfirmationURL != want {
+		t.Errorf("ConfirmationURL = %q, want %q", first.ConfirmationURL, want)
+	}
+}
@@ -0,0 +1,11 @@
+package payment
+
+import "errors"
+
+// Ошибки обработки уведомлений провайдеров
+var (
+	// ErrInvalidSignature подпись уведомления не совпадает с ожидаемой
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrMalformedNotification уведомление не удалось разобрать
+	ErrMalformedNotification = errors.New("malformed webhook notification")
+)
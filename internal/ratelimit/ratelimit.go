@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter ограничивает частоту запросов по ключу алгоритмом token bucket: у каждого ключа есть корзина
+// на burst запросов, которая равномерно пополняется за period. Состояние хранится в памяти экземпляра сервиса
+type Limiter struct {
+	mu      sync.Mutex
+	burst   float64
+	rate    float64
+	buckets map[string]*bucket
+	// lastSweep момент последнего удаления заполненных корзин
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// bucket описывает корзину токенов одного ключа
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// New конструктор для Limiter, пропускающего requests запросов за period на ключ.
+// При requests = 0 ограничение отключено и возвращается nil
+func New(requests int, period time.Duration) *Limiter {
+	if requests <= 0 || period <= 0 {
+		return nil
+	}
+	return &Limiter{
+		burst:   float64(requests),
+		rate:    float64(requests) / period.Seconds(),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow расходует токен ключа key и сообщает, можно ли выполнить запрос. nil Limiter пропускает все запросы
+func (l *Limiter) Allow(key string) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill возвращает количество токенов в корзине на момент now
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	return min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+}
+
+// sweep удаляет заполненные корзины: они не отличаются от новых. Выполняется не чаще, чем корзина
+// заполняется с нуля, чтобы память не росла с числом ключей
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep).Seconds()*l.rate < l.burst {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
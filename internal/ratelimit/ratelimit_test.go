@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// request запрос к Limiter через after после предыдущего
+type request struct {
+	after time.Duration
+	key   string
+	want  bool
+}
+
+func TestAllow(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		requests int
+		period   time.Duration
+		// disabled New возвращает nil, ограничение отключено
+		disabled bool
+		steps    []request
+		// wantBuckets ключи корзин, оставшихся после запросов, nil - не проверяются
+		wantBuckets []string
+	}{
+		{
+			name:     "burst",
+			requests: 3,
+			period:   time.Minute,
+			steps: []request{
+				{key: "key", want: true},
+				{key: "key", want: true},
+				{key: "key", want: true},
+				{key: "key", want: false},
+				{key: "other", want: true},
+			},
+		},
+		{
+			name:     "refill",
+			requests: 2,
+			period:   time.Minute,
+			steps: []request{
+				{key: "key", want: true},
+				{key: "key", want: true},
+				{after: 20 * time.Second, key: "key", want: false},
+				{after: 10 * time.Second, key: "key", want: true},
+				{key: "key", want: false},
+			},
+		},
+		{
+			name:     "refill stops at burst",
+			requests: 2,
+			period:   time.Minute,
+			steps: []request{
+				{key: "key", want: true},
+				{after: time.Hour, key: "key", want: true},
+				{key: "key", want: true},
+				{key: "key", want: false},
+			},
+		},
+		{
+			name:     "sweep full buckets",
+			requests: 2,
+			period:   time.Minute,
+			steps: []request{
+				{key: "idle", want: true},
+				{key: "busy", want: true},
+				{after: time.Minute, key: "busy", want: true},
+			},
+			wantBuckets: []string{"busy"},
+		},
+		{
+			name:     "no requests",
+			period:   time.Minute,
+			disabled: true,
+			steps:    []request{{key: "key", want: true}, {key: "key", want: true}},
+		},
+		{
+			name:     "negative requests",
+			requests: -1,
+			period:   time.Minute,
+			disabled: true,
+			steps:    []request{{key: "key", want: true}},
+		},
+		{
+			name:     "no period",
+			requests: 10,
+			disabled: true,
+			steps:    []request{{key: "key", want: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.requests, tt.period)
+			if (l == nil) != tt.disabled {
+				t.Fatalf("New(%d, %s) = %v, want disabled %v", tt.requests, tt.period, l, tt.disabled)
+			}
+			now := start
+			if l != nil {
+				l.now = func() time.Time { return now }
+			}
+
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				if got := l.Allow(s.key); got != s.want {
+					t.Fatalf("request %d (%s) = %v, want %v", i+1, s.key, got, s.want)
+				}
+			}
+			if tt.wantBuckets == nil {
+				return
+			}
+			var buckets []string
+			for key := range l.buckets {
+				buckets = append(buckets, key)
+			}
+			slices.Sort(buckets)
+			if !slices.Equal(buckets, tt.wantBuckets) {
+				t.Errorf("buckets = %v, want %v", buckets, tt.wantBuckets)
+			}
+		})
+	}
+}
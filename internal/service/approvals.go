@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opSetRequiresApproval = "service.SetRequiresApproval"
+	opGetApplications     = "service.GetApplications"
+	opApprove             = "service.Approve"
+	opReject              = "service.Reject"
+	opPublishDecision     = "service.PublishDecision"
+)
+
+// ApprovalStorage описывает методы repo-слоя для рассмотрения заявок на регистрацию
+type ApprovalStorage interface {
+	SetEventRequiresApproval(ctx context.Context, eventID string, requiresApproval bool) error
+	GetEventRegistrations(ctx context.Context, eventID string) ([]models.Registration, error)
+	ApproveRegistration(ctx context.Context, registrationID string, reason *string, paymentTTL time.Duration, now time.Time) (models.Registration, error)
+	RejectRegistration(ctx context.Context, registrationID, reason string, now time.Time) (models.Registration, error)
+	RevertApproval(ctx context.Context, registrationID string) error
+}
+
+// ApprovalPaymentCreator описывает метод создания платежа за одобренную регистрацию. При ошибке
+// регистрация не меняется
+type ApprovalPaymentCreator interface {
+	CreatePayment(ctx context.Context, reg models.Registration) (models.Payment, error)
+}
+
+// Approvals описывает сервис рассмотрения заявок на регистрацию организатором
+type Approvals struct {
+	log       *slog.Logger
+	storage   ApprovalStorage
+	payments  ApprovalPaymentCreator
+	signer    TicketSigner
+	publisher Publisher
+	// topic топик для сообщений о решениях по заявкам
+	topic string
+	// paymentTTL срок оплаты платного билета после одобрения заявки
+	paymentTTL time.Duration
+}
+
+// NewApprovals конструктор для Approvals
+func NewApprovals(log *slog.Logger, storage ApprovalStorage, payments ApprovalPaymentCreator, signer TicketSigner, publisher Publisher, topic string, paymentTTL time.Duration) *Approvals {
+	return &Approvals{
+		log:        log,
+		storage:    storage,
+		payments:   payments,
+		signer:     signer,
+		publisher:  publisher,
+		topic:      topic,
+		paymentTTL: paymentTTL,
+	}
+}
+
+// SetRequiresApproval включает или выключает одобрение регистраций на событие
+func (a *Approvals) SetRequiresApproval(ctx context.Context, eventID string, requiresApproval bool) error {
+	if err := a.storage.SetEventRequiresApproval(ctx, eventID, requiresApproval); err != nil {
+		return fmt.Errorf("%s: %w", opSetRequiresApproval, err)
+	}
+	return nil
+}
+
+// GetApplications возвращает заявки на событие, ожидающие решения, в порядке подачи вместе с ответами на анкету
+func (a *Approvals) GetApplications(ctx context.Context, eventID string) ([]models.Registration, error) {
+	regs, err := a.storage.GetEventRegistrations(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetApplications, err)
+	}
+	applications := make([]models.Registration, 0, len(regs))
+	for _, reg := range regs {
+		if reg.Status == models.RegistrationPendingApproval {
+			applications = append(applications, reg)
+		}
+	}
+	return applications, nil
+}
+
+// Approve одобряет заявку. Для бесплатной регистрации выпускается токен билета, для платной создаётся платёж.
+// Если платёж создать не удалось, заявка возвращается к рассмотрению и возвращается ошибка.
+// О решении публикуется сообщение в NATS
+func (a *Approvals) Approve(ctx context.Context, registrationID, reason string) (models.Registration, error) {
+	var comment *string
+	if reason = strings.TrimSpace(reason); reason != "" {
+		comment = &reason
+	}
+	reg, err := a.storage.ApproveRegistration(ctx, registrationID, comment, a.paymentTTL, time.Now())
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opApprove, err)
+	}
+	if reg.Status == models.RegistrationPendingPayment {
+		payment, err := a.payments.CreatePayment(ctx, reg)
+		if err != nil {
+			if revertErr := a.storage.RevertApproval(ctx, reg.ID); revertErr != nil {
+				a.log.Error("error", revertErr.Error(), slog.String("operation", opApprove), slog.String("registration_id", reg.ID))
+			}
+			return models.Registration{}, fmt.Errorf("%s: %w", opApprove, err)
+		}
+		reg.Payment = &payment
+	} else {
+		reg.TicketToken = a.signer.Sign(reg.ID, reg.EventID)
+	}
+
+	a.log.Info("registration approved", slog.String("registration_id", reg.ID), slog.String("status", string(reg.Status)))
+	a.publishDecision(reg, models.DecisionApproved)
+	return reg, nil
+}
+
+// Reject отклоняет заявку с обязательным указанием причины. О решении публикуется сообщение в NATS
+func (a *Approvals) Reject(ctx context.Context, registrationID, reason string) (models.Registration, error) {
+	if reason = strings.TrimSpace(reason); reason == "" {
+		return models.Registration{}, fmt.Errorf("%s: %w: rejection reason is required", opReject, ErrInvalidArgument)
+	}
+	reg, err := a.storage.RejectRegistration(ctx, registrationID, reason, time.Now())
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opReject, err)
+	}
+
+	a.log.Info("registration rejected", slog.String("registration_id", reg.ID))
+	a.publishDecision(reg, models.DecisionRejected)
+	return reg, nil
+}
+
+// publishDecision публикует сообщение о решении по заявке. Решение уже сохранено, поэтому ошибка публикации
+// только логируется
+func (a *Approvals) publishDecision(reg models.Registration, decision models.Decision) {
+	msg := models.RegistrationDecision{
+		RegistrationID: reg.ID,
+		EventID:        reg.EventID,
+		SessionID:      reg.SessionID,
+		ChatID:         reg.ChatID,
+		Username:       reg.User.Username,
+		Decision:       decision,
+		Status:         reg.Status,
+		TicketToken:    reg.TicketToken,
+	}
+	if reg.DecisionReason != nil {
+		msg.Reason = *reg.DecisionReason
+	}
+	if reg.DecidedAt != nil {
+		msg.DecidedAt = reg.DecidedAt.UTC()
+	}
+	if p := reg.Payment; p != nil {
+		expiresAt := p.ExpiresAt.UTC()
+		msg.PaymentURL, msg.PaymentExpiresAt = p.ConfirmationURL, &expiresAt
+	}
+
+	if err := a.publisher.Publish(a.topic, msg); err != nil {
+		a.log.Error("error", err.Error(), slog.String("operation", opPublishDecision), slog.String("registration_id", reg.ID))
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opMarkNoShows        = "service.MarkNoShows"
+	opGetUserAttendance  = "service.GetUserAttendance"
+	opGetEventAttendance = "service.GetEventAttendance"
+)
+
+// noShowLookback насколько далеко в прошлое фоновая задача ищет закончившиеся события. Более старые
+// регистрации без отметки прохода не считаются неявками: они могли появиться до включения отметки прохода
+const noShowLookback = 7 * 24 * time.Hour
+
+// AttendanceStorage описывает методы repo-слоя для учёта посещений
+type AttendanceStorage interface {
+	MarkNoShows(ctx context.Context, from, now time.Time, eventDuration time.Duration) (int, error)
+	GetUserAttendance(ctx context.Context, chatID int64) ([]models.AttendanceRecord, error)
+	GetEventAttendance(ctx context.Context, eventID string) (models.EventAttendance, error)
+}
+
+// Attendance описывает сервис учёта посещений
+type Attendance struct {
+	log     *slog.Logger
+	storage AttendanceStorage
+	// eventDuration предполагаемая длительность событий без сессий
+	eventDuration time.Duration
+}
+
+// NewAttendance конструктор для Attendance
+func NewAttendance(log *slog.Logger, storage AttendanceStorage, eventDuration time.Duration) *Attendance {
+	return &Attendance{
+		log:           log,
+		storage:       storage,
+		eventDuration: eventDuration,
+	}
+}
+
+// MarkNoShows отмечает неявку пользователей, которые зарегистрировались на закончившиеся события,
+// но не прошли по билету. Предназначен для периодического запуска в фоне
+func (a *Attendance) MarkNoShows(ctx context.Context) error {
+	now := time.Now()
+	marked, err := a.storage.MarkNoShows(ctx, now.Add(-noShowLookback), now, a.eventDuration)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opMarkNoShows, err)
+	}
+	if marked > 0 {
+		a.log.Info("no-shows marked", slog.Int("count", marked))
+	}
+	return nil
+}
+
+// GetUserAttendance возвращает историю посещений пользователя с количеством посещений и неявок
+func (a *Attendance) GetUserAttendance(ctx context.Context, chatID int64) (models.UserAttendance, error) {
+	records, err := a.storage.GetUserAttendance(ctx, chatID)
+	if err != nil {
+		return models.UserAttendance{}, fmt.Errorf("%s: %w", opGetUserAttendance, err)
+	}
+	attendance := models.UserAttendance{ChatID: chatID, Records: records}
+	for _, r := range records {
+		switch r.Status {
+		case models.AttendanceAttended:
+			attendance.Attended++
+		case models.AttendanceNoShow:
+			attendance.NoShows++
+		}
+	}
+	return attendance, nil
+}
+
+// GetEventAttendance возвращает статистику посещения события
+func (a *Attendance) GetEventAttendance(ctx context.Context, eventID string) (models.EventAttendance, error) {
+	stats, err := a.storage.GetEventAttendance(ctx, eventID)
+	if err != nil {
+		return models.EventAttendance{}, fmt.Errorf("%s: %w", opGetEventAttendance, err)
+	}
+	return stats, nil
+}
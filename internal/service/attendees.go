@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opGetAttendees = "service.GetAttendees"
+)
+
+// AttendeeStorage описывает методы repo-слоя для выгрузки участников события
+type AttendeeStorage interface {
+	GetEventQuestions(ctx context.Context, eventID string) ([]models.Question, error)
+	GetEventRegistrations(ctx context.Context, eventID string) ([]models.Registration, error)
+}
+
+// Attendees описывает сервис выгрузки участников события
+type Attendees struct {
+	log     *slog.Logger
+	storage AttendeeStorage
+}
+
+// NewAttendees конструктор для Attendees
+func NewAttendees(log *slog.Logger, storage AttendeeStorage) *Attendees {
+	return &Attendees{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// GetAttendees возвращает регистрации на событие вместе с вопросами анкеты и ответами на них
+func (a *Attendees) GetAttendees(ctx context.Context, eventID string) (models.AttendeeList, error) {
+	regs, err := a.storage.GetEventRegistrations(ctx, eventID)
+	if err != nil {
+		return models.AttendeeList{}, fmt.Errorf("%s: %w", opGetAttendees, err)
+	}
+	questions, err := a.storage.GetEventQuestions(ctx, eventID)
+	if err != nil {
+		return models.AttendeeList{}, fmt.Errorf("%s: %w", opGetAttendees, err)
+	}
+	return models.AttendeeList{EventID: eventID, Questions: questions, Registrations: regs}, nil
+}
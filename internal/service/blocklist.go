@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opGetBlockedChats = "service.GetBlockedChats"
+	opBlockChat       = "service.BlockChat"
+	opUnblockChat     = "service.UnblockChat"
+)
+
+// BlocklistStorage описывает методы repo-слоя для работы с блокировками пользователей
+type BlocklistStorage interface {
+	GetBlockedChats(ctx context.Context, now time.Time) ([]models.BlockedChat, error)
+	BlockChat(ctx context.Context, b models.BlockedChat) (models.BlockedChat, error)
+	UnblockChat(ctx context.Context, chatID int64) error
+}
+
+// Blocklist описывает сервис блокировки пользователей, которым запрещена регистрация
+type Blocklist struct {
+	log     *slog.Logger
+	storage BlocklistStorage
+	now     func() time.Time
+}
+
+// NewBlocklist конструктор для Blocklist. now - источник текущего времени, nil - time.Now
+func NewBlocklist(log *slog.Logger, storage BlocklistStorage, now func() time.Time) *Blocklist {
+	if now == nil {
+		now = time.Now
+	}
+	return &Blocklist{
+		log:     log,
+		storage: storage,
+		now:     now,
+	}
+}
+
+// GetBlockedChats возвращает действующие блокировки
+func (b *Blocklist) GetBlockedChats(ctx context.Context) ([]models.BlockedChat, error) {
+	chats, err := b.storage.GetBlockedChats(ctx, b.now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetBlockedChats, err)
+	}
+	return chats, nil
+}
+
+// BlockChat запрещает пользователю регистрироваться до момента until, nil - бессрочно.
+// Существующие регистрации пользователя не отменяются
+func (b *Blocklist) BlockChat(ctx context.Context, chatID int64, reason string, until *time.Time) (models.BlockedChat, error) {
+	if until != nil && !until.After(b.now()) {
+		return models.BlockedChat{}, fmt.Errorf("%s: %w: blocked_until must be in the future", opBlockChat, ErrInvalidArgument)
+	}
+	blocked, err := b.storage.BlockChat(ctx, models.BlockedChat{
+		ChatID:       chatID,
+		Reason:       strings.TrimSpace(reason),
+		BlockedUntil: until,
+	})
+	if err != nil {
+		return models.BlockedChat{}, fmt.Errorf("%s: %w", opBlockChat, err)
+	}
+	b.log.Info("chat blocked", slog.Int64("chat_id", chatID))
+	return blocked, nil
+}
+
+// UnblockChat снимает блокировку пользователя
+func (b *Blocklist) UnblockChat(ctx context.Context, chatID int64) error {
+	if err := b.storage.UnblockChat(ctx, chatID); err != nil {
+		return fmt.Errorf("%s: %w", opUnblockChat, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opCalendarEvent    = "service.CalendarEvent"
+	opCalendarUpcoming = "service.CalendarUpcoming"
+	opCalendarFeed     = "service.CalendarFeed"
+	opIssueFeedToken   = "service.IssueFeedToken"
+)
+
+// feedTokenSize длина случайной части токена подписки в байтах
+const feedTokenSize = 32
+
+// CalendarStorage описывает методы repo-слоя, необходимые для экспорта календарей
+type CalendarStorage interface {
+	GetEvent(ctx context.Context, eventID string) (models.Event, error)
+	GetUpcomingEvents(ctx context.Context, from time.Time) ([]models.Event, error)
+	GetChatEvents(ctx context.Context, chatID int64) ([]models.Event, error)
+	GetFeedChatID(ctx context.Context, tokenHash string) (int64, error)
+	SaveFeedToken(ctx context.Context, chatID int64, tokenHash string) error
+}
+
+// Calendar описывает сервис экспорта событий в календари
+type Calendar struct {
+	log     *slog.Logger
+	storage CalendarStorage
+}
+
+// NewCalendar конструктор для Calendar
+func NewCalendar(log *slog.Logger, storage CalendarStorage) *Calendar {
+	return &Calendar{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// Event возвращает одно событие для экспорта. Закрытые события не экспортируются
+func (c *Calendar) Event(ctx context.Context, eventID string) (models.Event, error) {
+	e, err := c.storage.GetEvent(ctx, eventID)
+	if err != nil {
+		return models.Event{}, fmt.Errorf("%s: %w", opCalendarEvent, err)
+	}
+	if e.Visibility == models.VisibilityPrivate {
+		return models.Event{}, fmt.Errorf("%s: %w", opCalendarEvent, storage.ErrEventNotFound)
+	}
+	return e, nil
+}
+
+// Upcoming возвращает все предстоящие события
+func (c *Calendar) Upcoming(ctx context.Context) ([]models.Event, error) {
+	events, err := c.storage.GetUpcomingEvents(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opCalendarUpcoming, err)
+	}
+	return events, nil
+}
+
+// Feed возвращает события, на которые зарегистрирован владелец токена подписки
+func (c *Calendar) Feed(ctx context.Context, token string) ([]models.Event, error) {
+	chatID, err := c.storage.GetFeedChatID(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opCalendarFeed, err)
+	}
+
+	events, err := c.storage.GetChatEvents(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opCalendarFeed, err)
+	}
+	return events, nil
+}
+
+// IssueFeedToken выпускает новый секретный токен подписки для чата. Предыдущий токен перестаёт действовать.
+// В базе хранится только хеш токена, поэтому получить его повторно нельзя - только перевыпустить
+func (c *Calendar) IssueFeedToken(ctx context.Context, chatID int64) (string, error) {
+	raw := make([]byte, feedTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("%s: %w", opIssueFeedToken, err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := c.storage.SaveFeedToken(ctx, chatID, hashFeedToken(token)); err != nil {
+		return "", fmt.Errorf("%s: %w", opIssueFeedToken, err)
+	}
+
+	c.log.Info("calendar feed token issued", slog.Int64("chat_id", chatID), slog.String("operation", opIssueFeedToken))
+	return token, nil
+}
+
+// hashFeedToken возвращает SHA-256 хеш токена подписки
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
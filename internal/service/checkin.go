@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/ticket"
+)
+
+// Константы для описания операций
+const (
+	opGetTickets     = "service.GetTickets"
+	opTicketQRCode   = "service.TicketQRCode"
+	opCheckIn        = "service.CheckIn"
+	opPublishCheckIn = "service.PublishCheckIn"
+)
+
+// qrCodeSize сторона PNG-изображения QR-кода билета в пикселях
+const qrCodeSize = 512
+
+// TicketSigner описывает методы выпуска и проверки токенов билетов
+type TicketSigner interface {
+	Sign(registrationID, eventID string) string
+	Verify(token string) (ticket.Claims, error)
+}
+
+// CheckInStorage описывает методы repo-слоя для выдачи билетов и отметки прохода
+type CheckInStorage interface {
+	GetChatRegistrations(ctx context.Context, eventID string, chatID int64) ([]models.Registration, error)
+	CheckIn(ctx context.Context, registrationID, eventID string, now time.Time) (models.Registration, error)
+}
+
+// Tickets описывает сервис билетов и прохода на событие
+type Tickets struct {
+	log       *slog.Logger
+	storage   CheckInStorage
+	signer    TicketSigner
+	publisher Publisher
+	// topic топик для сообщений о проходе по билету
+	topic string
+}
+
+// NewTickets конструктор для Tickets
+func NewTickets(log *slog.Logger, storage CheckInStorage, signer TicketSigner, publisher Publisher, topic string) *Tickets {
+	return &Tickets{
+		log:       log,
+		storage:   storage,
+		signer:    signer,
+		publisher: publisher,
+		topic:     topic,
+	}
+}
+
+// GetTickets возвращает билеты чата на событие, по одному на каждую подтверждённую регистрацию
+func (t *Tickets) GetTickets(ctx context.Context, eventID string, chatID int64) ([]models.Ticket, error) {
+	regs, err := t.storage.GetChatRegistrations(ctx, eventID, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetTickets, err)
+	}
+	if len(regs) == 0 {
+		return nil, fmt.Errorf("%s: %w", opGetTickets, storage.ErrRegistrationNotFound)
+	}
+
+	tickets := make([]models.Ticket, 0, len(regs))
+	for _, reg := range regs {
+		tickets = append(tickets, models.Ticket{
+			RegistrationID: reg.ID,
+			EventID:        reg.EventID,
+			SessionID:      reg.SessionID,
+			ChatID:         reg.ChatID,
+			Token:          t.signer.Sign(reg.ID, reg.EventID),
+			CheckedInAt:    reg.CheckedInAt,
+		})
+	}
+	return tickets, nil
+}
+
+// QRCode проверяет подпись токена и возвращает PNG-изображение QR-кода билета
+func (t *Tickets) QRCode(token string) ([]byte, error) {
+	if _, err := t.signer.Verify(token); err != nil {
+		return nil, fmt.Errorf("%s: %w", opTicketQRCode, err)
+	}
+	png, err := ticket.QRCode(token, qrCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opTicketQRCode, err)
+	}
+	return png, nil
+}
+
+// CheckIn проверяет билет на входе на событие eventID и отмечает проход. Билет на другое событие
+// отклоняется с ErrTicketForOtherEvent, повторный проход - с storage.ErrAlreadyCheckedIn
+func (t *Tickets) CheckIn(ctx context.Context, eventID, token string) (models.Registration, error) {
+	claims, err := t.signer.Verify(token)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	if claims.EventID != eventID {
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, ErrTicketForOtherEvent)
+	}
+
+	reg, err := t.storage.CheckIn(ctx, claims.RegistrationID, claims.EventID, time.Now())
+	if errors.Is(err, storage.ErrAlreadyCheckedIn) {
+		return reg, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+
+	if err = t.publishCheckIn(reg); err != nil {
+		// Проход уже отмечен, ошибка публикации не должна задерживать пользователя на входе
+		t.log.Error("error", err.Error(), slog.String("operation", opCheckIn), slog.String("registration_id", reg.ID))
+	}
+	return reg, nil
+}
+
+// publishCheckIn публикует сообщение о проходе по билету
+func (t *Tickets) publishCheckIn(reg models.Registration) error {
+	msg := models.CheckIn{
+		RegistrationID: reg.ID,
+		EventID:        reg.EventID,
+		SessionID:      reg.SessionID,
+		ChatID:         reg.ChatID,
+		Username:       reg.User.Username,
+	}
+	if reg.CheckedInAt != nil {
+		msg.CheckedInAt = reg.CheckedInAt.UTC()
+	}
+	if err := t.publisher.Publish(t.topic, msg); err != nil {
+		return fmt.Errorf("%s: %w", opPublishCheckIn, err)
+	}
+	return nil
+}
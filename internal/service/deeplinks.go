@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opCreateDeepLink  = "service.CreateDeepLink"
+	opGetDeepLinks    = "service.GetDeepLinks"
+	opResolveDeepLink = "service.ResolveDeepLink"
+)
+
+// maxLinkLabelLength максимальная длина метки кампании или источника ссылки
+const maxLinkLabelLength = 64
+
+// DeepLinkStorage описывает методы repo-слоя для работы со ссылками на бота
+type DeepLinkStorage interface {
+	CreateDeepLink(ctx context.Context, eventID, campaign, source string) (models.DeepLink, error)
+	GetEventDeepLinks(ctx context.Context, eventID string) ([]models.DeepLink, error)
+	RecordDeepLinkClick(ctx context.Context, linkID int64, chatID *int64, at time.Time) (models.DeepLink, error)
+}
+
+// LinkSigner описывает методы выпуска и проверки токенов ссылок на бота
+type LinkSigner interface {
+	Sign(linkID int64) string
+	Verify(token string) (int64, error)
+}
+
+// DeepLinks описывает сервис ссылок на бота, открывающих конкретное событие
+type DeepLinks struct {
+	log     *slog.Logger
+	storage DeepLinkStorage
+	signer  LinkSigner
+	// botUsername имя Telegram-бота для ссылок, пустое - возвращаются только токены
+	botUsername string
+}
+
+// NewDeepLinks конструктор для DeepLinks
+func NewDeepLinks(log *slog.Logger, storage DeepLinkStorage, signer LinkSigner, botUsername string) *DeepLinks {
+	return &DeepLinks{
+		log:         log,
+		storage:     storage,
+		signer:      signer,
+		botUsername: strings.TrimPrefix(botUsername, "@"),
+	}
+}
+
+// CreateDeepLink создаёт ссылку на бота для события. campaign и source необязательны и нужны для статистики
+func (d *DeepLinks) CreateDeepLink(ctx context.Context, eventID, campaign, source string) (models.DeepLink, error) {
+	campaign, source = strings.TrimSpace(campaign), strings.TrimSpace(source)
+	if len(campaign) > maxLinkLabelLength || len(source) > maxLinkLabelLength {
+		return models.DeepLink{}, fmt.Errorf("%s: %w: campaign and source must be at most %d bytes",
+			opCreateDeepLink, ErrInvalidArgument, maxLinkLabelLength)
+	}
+	link, err := d.storage.CreateDeepLink(ctx, eventID, campaign, source)
+	if err != nil {
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opCreateDeepLink, err)
+	}
+	d.fill(&link)
+	return link, nil
+}
+
+// GetDeepLinks возвращает ссылки на событие с количеством открытий и регистраций
+func (d *DeepLinks) GetDeepLinks(ctx context.Context, eventID string) ([]models.DeepLink, error) {
+	links, err := d.storage.GetEventDeepLinks(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetDeepLinks, err)
+	}
+	for i := range links {
+		d.fill(&links[i])
+	}
+	return links, nil
+}
+
+// ResolveDeepLink проверяет токен ссылки, учитывает её открытие пользователем chatID (nil - неизвестен)
+// и возвращает ссылку
+func (d *DeepLinks) ResolveDeepLink(ctx context.Context, token string, chatID *int64) (models.DeepLink, error) {
+	id, err := d.signer.Verify(token)
+	if err != nil {
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opResolveDeepLink, err)
+	}
+	link, err := d.storage.RecordDeepLinkClick(ctx, id, chatID, time.Now())
+	if err != nil {
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opResolveDeepLink, err)
+	}
+	d.fill(&link)
+	return link, nil
+}
+
+// DeepLinkID возвращает ID ссылки по токену для учёта регистрации по ссылке
+func (d *DeepLinks) DeepLinkID(token string) (int64, error) {
+	return d.signer.Verify(token)
+}
+
+// fill заполняет токен ссылки и ссылку на бота
+func (d *DeepLinks) fill(link *models.DeepLink) {
+	link.Token = d.signer.Sign(link.ID)
+	if d.botUsername != "" {
+		link.Link = fmt.Sprintf("https://t.me/%s?start=%s", d.botUsername, link.Token)
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import "errors"
+
+// ErrInvalidArgument возвращается, если входные данные не прошли валидацию
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrTicketForOtherEvent возвращается, если на входе предъявлен билет на другое событие
+var ErrTicketForOtherEvent = errors.New("ticket is for another event")
+
+// ErrRegistrationNotOpen возвращается, если регистрация на событие ещё не открылась
+var ErrRegistrationNotOpen = errors.New("registration is not open yet")
+
+// ErrRegistrationClosed возвращается, если регистрация на событие уже закрыта
+var ErrRegistrationClosed = errors.New("registration is closed")
+
+// ErrIdempotencyKeyReused возвращается, если ключ идемпотентности передан с другим запросом
+var ErrIdempotencyKeyReused = errors.New("idempotency key is already used with a different request")
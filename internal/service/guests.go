@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opSetEventMaxGuests = "service.SetEventMaxGuests"
+	opUpdateGuests      = "service.UpdateGuests"
+)
+
+// GuestStorage описывает методы repo-слоя для работы с гостями регистраций
+type GuestStorage interface {
+	SetEventMaxGuests(ctx context.Context, eventID string, maxGuests int) error
+	UpdateGuests(ctx context.Context, eventID string, chatID int64, sessionID *string, guests int) (models.Registration, error)
+}
+
+// Guests описывает сервис управления гостями, которых пользователи приводят с собой
+type Guests struct {
+	log     *slog.Logger
+	storage GuestStorage
+}
+
+// NewGuests конструктор для Guests
+func NewGuests(log *slog.Logger, storage GuestStorage) *Guests {
+	return &Guests{log: log, storage: storage}
+}
+
+// SetEventMaxGuests задаёт максимальное количество гостей одного пользователя на событии
+func (g *Guests) SetEventMaxGuests(ctx context.Context, eventID string, maxGuests int) error {
+	if maxGuests < 0 {
+		return fmt.Errorf("%s: %w: max_guests must not be negative", opSetEventMaxGuests, ErrInvalidArgument)
+	}
+	if err := g.storage.SetEventMaxGuests(ctx, eventID, maxGuests); err != nil {
+		return fmt.Errorf("%s: %w", opSetEventMaxGuests, err)
+	}
+	return nil
+}
+
+// UpdateGuests изменяет количество гостей в регистрации пользователя без потери его места
+func (g *Guests) UpdateGuests(ctx context.Context, eventID string, chatID int64, sessionID *string, guests int) (models.Registration, error) {
+	if guests < 0 {
+		return models.Registration{}, fmt.Errorf("%s: %w: guests must not be negative", opUpdateGuests, ErrInvalidArgument)
+	}
+	reg, err := g.storage.UpdateGuests(ctx, eventID, chatID, sessionID, guests)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+	g.log.Info("registration guests updated", slog.String("registration_id", reg.ID), slog.Int("guests", reg.Guests))
+	return reg, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opBeginIdempotent    = "service.BeginIdempotent"
+	opCompleteIdempotent = "service.CompleteIdempotent"
+	opReleaseIdempotent  = "service.ReleaseIdempotent"
+	opDeleteExpiredKeys  = "service.DeleteExpiredIdempotencyKeys"
+)
+
+// maxIdempotencyKeyLength максимальная длина ключа идемпотентности
+const maxIdempotencyKeyLength = 255
+
+// idempotencyStaleAfter через сколько запрос, не сохранивший ответ, считается прерванным,
+// и повтор с тем же ключом выполняется заново
+const idempotencyStaleAfter = time.Minute
+
+// IdempotencyStorage описывает методы repo-слоя для работы с ключами идемпотентности
+type IdempotencyStorage interface {
+	ClaimIdempotencyKey(ctx context.Context, k models.IdempotencyKey, staleBefore time.Time) (models.IdempotencyKey, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, key, requestHash string, response []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key, requestHash string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Idempotency описывает сервис ключей идемпотентности: повтор запроса с тем же ключом получает
+// сохранённый ответ вместо повторного выполнения
+type Idempotency struct {
+	log     *slog.Logger
+	storage IdempotencyStorage
+	// ttl сколько хранится ответ на запрос с ключом
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewIdempotency конструктор для Idempotency. now - источник текущего времени, nil - time.Now
+func NewIdempotency(log *slog.Logger, storage IdempotencyStorage, ttl time.Duration, now func() time.Time) *Idempotency {
+	if now == nil {
+		now = time.Now
+	}
+	return &Idempotency{
+		log:     log,
+		storage: storage,
+		ttl:     ttl,
+		now:     now,
+	}
+}
+
+// Begin занимает ключ идемпотентности для запроса с хешем requestHash. Возвращает nil, если запрос нужно выполнить,
+// или сохранённый ответ на предыдущий запрос с этим ключом. Ключ с другим запросом - ErrIdempotencyKeyReused,
+// ключ запроса, который ещё выполняется, - storage.ErrIdempotencyKeyBusy
+func (i *Idempotency) Begin(ctx context.Context, key, requestHash string) ([]byte, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("%s: %w: idempotency key must not be longer than %d characters",
+			opBeginIdempotent, ErrInvalidArgument, maxIdempotencyKeyLength)
+	}
+	now := i.now()
+	existing, claimed, err := i.storage.ClaimIdempotencyKey(ctx, models.IdempotencyKey{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(i.ttl),
+	}, now.Add(-idempotencyStaleAfter))
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("%s: %w", opBeginIdempotent, err)
+	case claimed:
+		return nil, nil
+	case existing.RequestHash != requestHash:
+		return nil, fmt.Errorf("%s: %w", opBeginIdempotent, ErrIdempotencyKeyReused)
+	case existing.Response == nil:
+		return nil, fmt.Errorf("%s: %w", opBeginIdempotent, storage.ErrIdempotencyKeyBusy)
+	}
+	return existing.Response, nil
+}
+
+// Complete сохраняет ответ на запрос, выполненный с ключом идемпотентности
+func (i *Idempotency) Complete(ctx context.Context, key, requestHash string, response []byte) error {
+	if err := i.storage.CompleteIdempotencyKey(ctx, key, requestHash, response); err != nil {
+		return fmt.Errorf("%s: %w", opCompleteIdempotent, err)
+	}
+	return nil
+}
+
+// Release освобождает ключ запроса, завершившегося ошибкой, чтобы повтор выполнился заново
+func (i *Idempotency) Release(ctx context.Context, key, requestHash string) error {
+	if err := i.storage.ReleaseIdempotencyKey(ctx, key, requestHash); err != nil {
+		return fmt.Errorf("%s: %w", opReleaseIdempotent, err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет истёкшие ключи идемпотентности. Предназначен для периодического запуска в фоне
+func (i *Idempotency) DeleteExpired(ctx context.Context) error {
+	n, err := i.storage.DeleteExpiredIdempotencyKeys(ctx, i.now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", opDeleteExpiredKeys, err)
+	}
+	if n > 0 {
+		i.log.Info("expired idempotency keys deleted", slog.Int64("count", n))
+	}
+	return nil
+}
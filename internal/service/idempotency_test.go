@@ -0,0 +1,164 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// memoryKeys хранит ключи идемпотентности в памяти по тем же правилам, что и repo-слой
+type memoryKeys map[string]models.IdempotencyKey
+
+func (m memoryKeys) ClaimIdempotencyKey(_ context.Context, k models.IdempotencyKey, staleBefore time.Time) (models.IdempotencyKey, bool, error) {
+	existing, ok := m[k.Key]
+	stale := existing.Response == nil && existing.RequestHash == k.RequestHash && !existing.CreatedAt.After(staleBefore)
+	if !ok || !existing.ExpiresAt.After(k.CreatedAt) || stale {
+		m[k.Key] = k
+		return k, true, nil
+	}
+	return existing, false, nil
+}
+
+func (m memoryKeys) CompleteIdempotencyKey(_ context.Context, key, requestHash string, response []byte) error {
+	if k, ok := m[key]; ok && k.RequestHash == requestHash && k.Response == nil {
+		k.Response = response
+		m[key] = k
+	}
+	return nil
+}
+
+func (m memoryKeys) ReleaseIdempotencyKey(_ context.Context, key, requestHash string) error {
+	if k, ok := m[key]; ok && k.RequestHash == requestHash && k.Response == nil {
+		delete(m, key)
+	}
+	return nil
+}
+
+func (m memoryKeys) DeleteExpiredIdempotencyKeys(_ context.Context, now time.Time) (int64, error) {
+	var n int64
+	for key, k := range m {
+		if !k.ExpiresAt.After(now) {
+			delete(m, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestIdempotencyBegin(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ttl := 24 * time.Hour
+	tests := []struct {
+		name     string
+		existing *models.IdempotencyKey
+		// key ключ запроса, по умолчанию "key". Хеш запроса - "hash"
+		key     string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "new key",
+		},
+		{
+			name:     "completed with the same request",
+			existing: &models.IdempotencyKey{Key: "key", RequestHash: "hash", Response: []byte(`{"success":true}`), CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)},
+			want:     `{"success":true}`,
+		},
+		{
+			name:     "completed with another request",
+			existing: &models.IdempotencyKey{Key: "key", RequestHash: "other", Response: []byte(`{"success":true}`), CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)},
+			wantErr:  ErrIdempotencyKeyReused,
+		},
+		{
+			name:     "in progress",
+			existing: &models.IdempotencyKey{Key: "key", RequestHash: "hash", CreatedAt: now.Add(-time.Second), ExpiresAt: now.Add(ttl)},
+			wantErr:  storage.ErrIdempotencyKeyBusy,
+		},
+		{
+			name:     "in progress with another request",
+			existing: &models.IdempotencyKey{Key: "key", RequestHash: "other", CreatedAt: now.Add(-time.Second), ExpiresAt: now.Add(ttl)},
+			wantErr:  ErrIdempotencyKeyReused,
+		},
+		{
+			name:     "stale in progress",
+			existing: &models.IdempotencyKey{Key: "key", RequestHash: "hash", CreatedAt: now.Add(-idempotencyStaleAfter), ExpiresAt: now.Add(ttl)},
+		},
+		{
+			name:     "expired",
+			existing: &models.IdempotencyKey{Key: "key", RequestHash: "other", Response: []byte(`{"success":true}`), CreatedAt: now.Add(-ttl), ExpiresAt: now},
+		},
+		{
+			name:    "key too long",
+			key:     strings.Repeat("k", maxIdempotencyKeyLength+1),
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := memoryKeys{}
+			if tt.existing != nil {
+				keys[tt.existing.Key] = *tt.existing
+			}
+			i := NewIdempotency(slog.New(slog.DiscardHandler), keys, ttl, func() time.Time { return now })
+
+			key := cmp.Or(tt.key, "key")
+			got, err := i.Begin(context.Background(), key, "hash")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Begin error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Begin = %q, want %q", got, tt.want)
+			}
+			if tt.wantErr == nil && tt.want == "" && keys[key].RequestHash != "hash" {
+				t.Fatal("key is not claimed by the request")
+			}
+		})
+	}
+}
+
+func TestIdempotencyFinish(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		finish func(i *Idempotency) error
+		// want ответ на повтор, пустой - повтор выполняется заново
+		want string
+	}{
+		{
+			name: "complete",
+			finish: func(i *Idempotency) error {
+				return i.Complete(context.Background(), "key", "hash", []byte(`{"success":true}`))
+			},
+			want: `{"success":true}`,
+		},
+		{
+			name:   "release",
+			finish: func(i *Idempotency) error { return i.Release(context.Background(), "key", "hash") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewIdempotency(slog.New(slog.DiscardHandler), memoryKeys{}, time.Hour, func() time.Time { return now })
+			if _, err := i.Begin(context.Background(), "key", "hash"); err != nil {
+				t.Fatalf("Begin: %v", err)
+			}
+			if err := tt.finish(i); err != nil {
+				t.Fatalf("finish: %v", err)
+			}
+			got, err := i.Begin(context.Background(), "key", "hash")
+			if err != nil {
+				t.Fatalf("repeated Begin: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("repeated Begin = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/importer"
+)
+
+// Константы для описания операций
+const (
+	opImport = "service.Import"
+)
+
+// ErrInvalidImport возвращается, если хотя бы одна строка файла не прошла валидацию.
+// В этом случае ни одно событие не сохраняется
+var ErrInvalidImport = errors.New("import contains invalid rows")
+
+// EventUpserter описывает метод для сохранения импортируемых событий
+type EventUpserter interface {
+	UpsertEvents(ctx context.Context, events []models.Event) (inserted, updated int, err error)
+}
+
+// Importer описывает сервис импорта событий из файлов
+type Importer struct {
+	log         *slog.Logger
+	upserter    EventUpserter
+	defaultZone *time.Location
+}
+
+// NewImporter конструктор для Importer. defaultZone применяется к строкам без часового пояса
+func NewImporter(log *slog.Logger, upserter EventUpserter, defaultZone *time.Location) *Importer {
+	return &Importer{
+		log:         log,
+		upserter:    upserter,
+		defaultZone: defaultZone,
+	}
+}
+
+// Import разбирает и валидирует файл, после чего сохраняет все события одной транзакцией.
+// Если в файле есть ошибки, возвращается отчёт с ними и ErrInvalidImport
+func (i *Importer) Import(ctx context.Context, format importer.Format, r io.Reader) (*models.ImportReport, error) {
+	rows, err := importer.Parse(format, r, i.defaultZone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opImport, err)
+	}
+
+	report := &models.ImportReport{Total: len(rows)}
+	events := make([]models.Event, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		if err = validateImportRow(row, seen); err != nil {
+			report.Errors = append(report.Errors, models.ImportRowError{Row: row.Number, Message: err.Error()})
+			continue
+		}
+		events = append(events, row.Event)
+	}
+
+	if len(report.Errors) > 0 {
+		i.log.Info("import rejected", slog.Int("errors", len(report.Errors)), slog.String("operation", opImport))
+		return report, fmt.Errorf("%s: %w", opImport, ErrInvalidImport)
+	}
+
+	report.Inserted, report.Updated, err = i.upserter.UpsertEvents(ctx, events)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opImport, err)
+	}
+
+	i.log.Info("import completed",
+		slog.Int("inserted", report.Inserted),
+		slog.Int("updated", report.Updated),
+		slog.String("operation", opImport),
+	)
+	return report, nil
+}
+
+// validateImportRow проверяет обязательные поля строки и уникальность внешнего идентификатора в файле
+func validateImportRow(row importer.Row, seen map[string]int) error {
+	if row.Err != nil {
+		return row.Err
+	}
+	if row.Event.ExternalID == nil {
+		return errors.New("external_id is required")
+	}
+	if row.Event.Title == "" {
+		return errors.New("title is required")
+	}
+	if row.Event.StartsAt.IsZero() {
+		return errors.New("starts_at is required")
+	}
+	if prev, ok := seen[*row.Event.ExternalID]; ok {
+		return fmt.Errorf("duplicate external_id %q, first seen in row %d", *row.Event.ExternalID, prev)
+	}
+	seen[*row.Event.ExternalID] = row.Number
+	return nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/importer"
+)
+
+// upserterStub запоминает сохранённые события. Событие с уже сохранённым внешним идентификатором
+// считается обновлённым, как при upsert в repo-слое
+type upserterStub struct {
+	existing map[string]bool
+	saved    []string
+}
+
+func (u *upserterStub) UpsertEvents(_ context.Context, events []models.Event) (inserted, updated int, err error) {
+	for _, e := range events {
+		u.saved = append(u.saved, *e.ExternalID)
+		if u.existing[*e.ExternalID] {
+			updated++
+			continue
+		}
+		inserted++
+	}
+	return inserted, updated, nil
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		existing map[string]bool
+		want     models.ImportReport
+		// wantRows строки с ошибками в отчёте
+		wantRows  []int
+		wantSaved []string
+		wantErr   error
+	}{
+		{
+			name: "inserted and updated",
+			input: "external_id,title,starts_at\n" +
+				"a,Meetup,2026-11-01T18:00\n" +
+				"b,Workshop,2026-11-02T10:00\n",
+			existing:  map[string]bool{"b": true},
+			want:      models.ImportReport{Total: 2, Inserted: 1, Updated: 1},
+			wantSaved: []string{"a", "b"},
+		},
+		{
+			name: "invalid rows reject the whole file",
+			input: "external_id,title,starts_at\n" +
+				"a,Meetup,2026-11-01T18:00\n" +
+				",No id,2026-11-01T18:00\n" +
+				"c,,2026-11-01T18:00\n" +
+				"d,No time,\n" +
+				"a,Duplicate,2026-11-01T18:00\n" +
+				"f,Bad time,tomorrow\n",
+			want:     models.ImportReport{Total: 6},
+			wantRows: []int{3, 4, 5, 6, 7},
+			wantErr:  ErrInvalidImport,
+		},
+		{
+			name:    "malformed file",
+			input:   "external_id,title\n",
+			wantErr: importer.ErrMalformedFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upserter := &upserterStub{existing: tt.existing}
+			i := NewImporter(slog.New(slog.DiscardHandler), upserter, time.UTC)
+
+			report, err := i.Import(context.Background(), importer.FormatCSV, strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Import error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(upserter.saved, tt.wantSaved) {
+				t.Errorf("saved = %v, want %v", upserter.saved, tt.wantSaved)
+			}
+			if report == nil {
+				if tt.want.Total != 0 {
+					t.Fatal("report is missing")
+				}
+				return
+			}
+			var rows []int
+			for _, e := range report.Errors {
+				rows = append(rows, e.Row)
+			}
+			if !slices.Equal(rows, tt.wantRows) {
+				t.Errorf("error rows = %v, want %v", rows, tt.wantRows)
+			}
+			if report.Total != tt.want.Total || report.Inserted != tt.want.Inserted || report.Updated != tt.want.Updated {
+				t.Errorf("report = %d/%d/%d, want %d/%d/%d", report.Total, report.Inserted, report.Updated,
+					tt.want.Total, tt.want.Inserted, tt.want.Updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opSetVisibility = "service.SetVisibility"
+	opCreateInvite  = "service.CreateInvite"
+	opGetInvites    = "service.GetInvites"
+	opRevokeInvite  = "service.RevokeInvite"
+)
+
+// inviteCodeSize длина случайного кода приглашения в байтах. Код кодируется в base64url,
+// что допустимо в параметре start ссылки на Telegram-бота
+const inviteCodeSize = 9
+
+// inviteStartPrefix префикс кода приглашения в параметре start ссылки на бота. Отличает коды приглашения
+// от токенов ссылок на события
+const inviteStartPrefix = "inv_"
+
+// InviteStorage описывает методы repo-слоя для работы с видимостью событий и кодами приглашения
+type InviteStorage interface {
+	SetEventVisibility(ctx context.Context, eventID string, visibility models.Visibility) error
+	CreateInviteCode(ctx context.Context, c models.InviteCode) (models.InviteCode, error)
+	GetEventInviteCodes(ctx context.Context, eventID string) ([]models.InviteCode, error)
+	RevokeInviteCode(ctx context.Context, inviteID string, now time.Time) (models.InviteCode, error)
+}
+
+// Invites описывает сервис приглашений на закрытые события
+type Invites struct {
+	log     *slog.Logger
+	storage InviteStorage
+	// botUsername имя Telegram-бота для ссылок с кодом приглашения, пустое - ссылки не формируются
+	botUsername string
+}
+
+// NewInvites конструктор для Invites
+func NewInvites(log *slog.Logger, storage InviteStorage, botUsername string) *Invites {
+	return &Invites{
+		log:         log,
+		storage:     storage,
+		botUsername: strings.TrimPrefix(botUsername, "@"),
+	}
+}
+
+// SetVisibility задаёт видимость события: public, unlisted или private
+func (i *Invites) SetVisibility(ctx context.Context, eventID string, visibility models.Visibility) error {
+	switch visibility {
+	case models.VisibilityPublic, models.VisibilityUnlisted, models.VisibilityPrivate:
+	default:
+		return fmt.Errorf("%s: %w: unknown visibility %q", opSetVisibility, ErrInvalidArgument, visibility)
+	}
+	if err := i.storage.SetEventVisibility(ctx, eventID, visibility); err != nil {
+		return fmt.Errorf("%s: %w", opSetVisibility, err)
+	}
+	return nil
+}
+
+// CreateInvite создаёт код приглашения на событие. maxUses - сколько регистраций можно выполнить по коду
+// (1 - одноразовый код), nil - без ограничения; expiresAt - срок действия, nil - бессрочно
+func (i *Invites) CreateInvite(ctx context.Context, eventID string, maxUses *int, expiresAt *time.Time) (models.InviteCode, error) {
+	if maxUses != nil && *maxUses <= 0 {
+		return models.InviteCode{}, fmt.Errorf("%s: %w: max_uses must be positive", opCreateInvite, ErrInvalidArgument)
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return models.InviteCode{}, fmt.Errorf("%s: %w: expires_at must be in the future", opCreateInvite, ErrInvalidArgument)
+	}
+
+	raw := make([]byte, inviteCodeSize)
+	if _, err := rand.Read(raw); err != nil {
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opCreateInvite, err)
+	}
+	c, err := i.storage.CreateInviteCode(ctx, models.InviteCode{
+		EventID:   eventID,
+		Code:      base64.RawURLEncoding.EncodeToString(raw),
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opCreateInvite, err)
+	}
+	c.Link = i.link(c.Code)
+	return c, nil
+}
+
+// GetInvites возвращает коды приглашения на событие со ссылками на бота
+func (i *Invites) GetInvites(ctx context.Context, eventID string) ([]models.InviteCode, error) {
+	codes, err := i.storage.GetEventInviteCodes(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetInvites, err)
+	}
+	for j := range codes {
+		codes[j].Link = i.link(codes[j].Code)
+	}
+	return codes, nil
+}
+
+// RevokeInvite отзывает код приглашения, после чего по нему нельзя зарегистрироваться
+func (i *Invites) RevokeInvite(ctx context.Context, inviteID string) (models.InviteCode, error) {
+	c, err := i.storage.RevokeInviteCode(ctx, inviteID, time.Now())
+	if err != nil {
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opRevokeInvite, err)
+	}
+	c.Link = i.link(c.Code)
+	return c, nil
+}
+
+// link возвращает ссылку на Telegram-бота, открывающую его с кодом приглашения
+func (i *Invites) link(code string) string {
+	if i.botUsername == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://t.me/%s?start=%s%s", i.botUsername, inviteStartPrefix, code)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opNewPayments    = "service.NewPayments"
+	opCreatePayment  = "service.CreatePayment"
+	opHandleWebhook  = "service.HandleWebhook"
+	opExpirePayments = "service.ExpirePayments"
+	opPublishPayment = "service.PublishPaymentConfirmed"
+)
+
+// PaymentProvider описывает платёжного провайдера
+type PaymentProvider interface {
+	// Name название провайдера, по которому принимаются его уведомления
+	Name() string
+	// CreatePayment создаёт у провайдера платёж на сумму p.Amount и возвращает его идентификатор и ссылку на оплату
+	CreatePayment(ctx context.Context, p models.Payment) (models.ProviderPayment, error)
+	// ParseWebhook проверяет подлинность уведомления провайдера и извлекает из него результат платежа
+	ParseWebhook(header http.Header, body []byte) (models.PaymentNotification, error)
+}
+
+// PaymentStorage описывает методы repo-слоя для работы с платежами
+type PaymentStorage interface {
+	CreatePayment(ctx context.Context, p models.Payment) (models.Payment, error)
+	CancelPendingRegistration(ctx context.Context, registrationID string) error
+	ConfirmPayment(ctx context.Context, provider, providerPaymentID string, now time.Time) (models.Registration, models.Payment, error)
+	FailPayment(ctx context.Context, provider, providerPaymentID string, now time.Time) error
+	ExpirePendingRegistrations(ctx context.Context, now time.Time) (int, error)
+}
+
+// Publisher описывает метод для публикации сообщения в NATS. Данные публикуются в конверте с типом и версией
+type Publisher interface {
+	Publish(topic string, payload any) error
+}
+
+// Payments описывает сервис оплаты регистраций
+type Payments struct {
+	log       *slog.Logger
+	storage   PaymentStorage
+	publisher Publisher
+	signer    TicketSigner
+	// topic топик для сообщений о подтверждённой оплате
+	topic string
+	// provider провайдер, через который создаются новые платежи
+	provider  PaymentProvider
+	providers map[string]PaymentProvider
+}
+
+// NewPayments конструктор для Payments. Новые платежи создаются через провайдера defaultProvider,
+// уведомления принимаются от всех переданных провайдеров
+func NewPayments(log *slog.Logger, storage PaymentStorage, publisher Publisher, signer TicketSigner, topic, defaultProvider string, providers ...PaymentProvider) (*Payments, error) {
+	p := &Payments{
+		log:       log,
+		storage:   storage,
+		publisher: publisher,
+		signer:    signer,
+		topic:     topic,
+		providers: make(map[string]PaymentProvider, len(providers)),
+	}
+	for _, provider := range providers {
+		p.providers[provider.Name()] = provider
+	}
+	provider, ok := p.providers[defaultProvider]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown payment provider %q", opNewPayments, defaultProvider)
+	}
+	p.provider = provider
+	return p, nil
+}
+
+// CreatePaymentOrCancel создаёт платёж за регистрацию пользователя, ожидающую оплаты. Если платёж создать
+// не удалось, регистрация отменяется, чтобы не удерживать место
+func (p *Payments) CreatePaymentOrCancel(ctx context.Context, reg models.Registration) (models.Payment, error) {
+	payment, err := p.CreatePayment(ctx, reg)
+	if err != nil {
+		if cancelErr := p.storage.CancelPendingRegistration(ctx, reg.ID); cancelErr != nil {
+			p.log.Error("error", cancelErr.Error(), slog.String("operation", opCreatePayment))
+		}
+		return models.Payment{}, err
+	}
+	return payment, nil
+}
+
+// CreatePayment создаёт платёж за регистрацию, ожидающую оплаты. Если платёж создать не удалось,
+// регистрация не меняется
+func (p *Payments) CreatePayment(ctx context.Context, reg models.Registration) (models.Payment, error) {
+	payment := models.Payment{
+		RegistrationID: &reg.ID,
+		EventID:        reg.EventID,
+		ChatID:         reg.ChatID,
+		Provider:       p.provider.Name(),
+		Amount:         reg.PriceAmount,
+		Currency:       reg.Currency,
+	}
+	if reg.ExpiresAt != nil {
+		payment.ExpiresAt = *reg.ExpiresAt
+	}
+
+	created, err := p.createPayment(ctx, payment)
+	if err != nil {
+		return models.Payment{}, fmt.Errorf("%s: %w", opCreatePayment, err)
+	}
+	p.log.Info("payment created", slog.String("payment_id", created.ID), slog.String("registration_id", reg.ID),
+		slog.String("provider", created.Provider))
+	return created, nil
+}
+
+// createPayment создаёт платёж у провайдера и сохраняет его
+func (p *Payments) createPayment(ctx context.Context, payment models.Payment) (models.Payment, error) {
+	providerPayment, err := p.provider.CreatePayment(ctx, payment)
+	if err != nil {
+		p.log.Error("error", err.Error(), slog.String("operation", opCreatePayment), slog.String("provider", payment.Provider))
+		return models.Payment{}, err
+	}
+	payment.ProviderPaymentID = providerPayment.ID
+	payment.ConfirmationURL = providerPayment.ConfirmationURL
+	return p.storage.CreatePayment(ctx, payment)
+}
+
+// HandleWebhook обрабатывает уведомление провайдера о результате платежа. Успешная оплата подтверждает
+// регистрацию и публикует сообщение в NATS вместе с токеном билета, неуспешная - освобождает место. Повторные уведомления
+// об уже обработанном платеже возвращают storage.ErrPaymentProcessed
+func (p *Payments) HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) error {
+	provider, ok := p.providers[providerName]
+	if !ok {
+		return fmt.Errorf("%s: %w: unknown payment provider %q", opHandleWebhook, ErrInvalidArgument, providerName)
+	}
+	notification, err := provider.ParseWebhook(header, body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opHandleWebhook, err)
+	}
+
+	now := time.Now()
+	if notification.Status == models.PaymentFailed {
+		if err = p.storage.FailPayment(ctx, providerName, notification.ProviderPaymentID, now); err != nil {
+			return fmt.Errorf("%s: %w", opHandleWebhook, err)
+		}
+		return nil
+	}
+
+	reg, payment, err := p.storage.ConfirmPayment(ctx, providerName, notification.ProviderPaymentID, now)
+	if errors.Is(err, storage.ErrPaymentExpired) {
+		// Деньги списаны, но место уже освобождено - платёж нужно вернуть вручную
+		p.log.Warn("payment succeeded after registration expired", slog.String("operation", opHandleWebhook),
+			slog.String("provider", providerName), slog.String("provider_payment_id", notification.ProviderPaymentID))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", opHandleWebhook, err)
+	}
+
+	if err = p.publishConfirmed(reg, payment); err != nil {
+		// Регистрация уже подтверждена, ошибка публикации не должна приводить к повторной доставке уведомления
+		p.log.Error("error", err.Error(), slog.String("operation", opHandleWebhook), slog.String("payment_id", payment.ID))
+	}
+	return nil
+}
+
+// publishConfirmed публикует сообщение о подтверждённой оплате регистрации
+func (p *Payments) publishConfirmed(reg models.Registration, payment models.Payment) error {
+	err := p.publisher.Publish(p.topic, models.PaymentConfirmed{
+		RegistrationID: reg.ID,
+		PaymentID:      payment.ID,
+		EventID:        reg.EventID,
+		ChatID:         reg.ChatID,
+		Username:       reg.User.Username,
+		SessionID:      reg.SessionID,
+		TicketTypeID:   reg.TicketTypeID,
+		Amount:         payment.Amount,
+		Currency:       payment.Currency,
+		TicketToken:    p.signer.Sign(reg.ID, reg.EventID),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", opPublishPayment, err)
+	}
+	return nil
+}
+
+// ExpirePending освобождает места, удерживаемые за регистрациями с истёкшим сроком оплаты
+func (p *Payments) ExpirePending(ctx context.Context) error {
+	expired, err := p.storage.ExpirePendingRegistrations(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", opExpirePayments, err)
+	}
+	if expired > 0 {
+		p.log.Info("expired unpaid registrations", slog.Int("count", expired))
+	}
+	return nil
+}
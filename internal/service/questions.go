@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opCreateQuestion = "service.CreateQuestion"
+	opUpdateQuestion = "service.UpdateQuestion"
+	opDeleteQuestion = "service.DeleteQuestion"
+)
+
+// maxAnswerLength максимальная длина текстового ответа в символах
+const maxAnswerLength = 1000
+
+// QuestionStorage описывает методы repo-слоя для работы с вопросами анкеты
+type QuestionStorage interface {
+	CreateQuestion(ctx context.Context, q models.Question) (models.Question, error)
+	UpdateQuestion(ctx context.Context, q models.Question) (models.Question, error)
+	DeleteQuestion(ctx context.Context, questionID string) error
+}
+
+// Questions описывает сервис анкет регистрации
+type Questions struct {
+	log     *slog.Logger
+	storage QuestionStorage
+}
+
+// NewQuestions конструктор для Questions
+func NewQuestions(log *slog.Logger, storage QuestionStorage) *Questions {
+	return &Questions{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// CreateQuestion создаёт вопрос анкеты события
+func (q *Questions) CreateQuestion(ctx context.Context, question models.Question) (models.Question, error) {
+	if err := validateQuestion(&question); err != nil {
+		return models.Question{}, fmt.Errorf("%s: %w", opCreateQuestion, err)
+	}
+	created, err := q.storage.CreateQuestion(ctx, question)
+	if err != nil {
+		return models.Question{}, fmt.Errorf("%s: %w", opCreateQuestion, err)
+	}
+	return created, nil
+}
+
+// UpdateQuestion изменяет вопрос анкеты. Уже полученные ответы не перепроверяются
+func (q *Questions) UpdateQuestion(ctx context.Context, question models.Question) (models.Question, error) {
+	if err := validateQuestion(&question); err != nil {
+		return models.Question{}, fmt.Errorf("%s: %w", opUpdateQuestion, err)
+	}
+	updated, err := q.storage.UpdateQuestion(ctx, question)
+	if err != nil {
+		return models.Question{}, fmt.Errorf("%s: %w", opUpdateQuestion, err)
+	}
+	return updated, nil
+}
+
+// DeleteQuestion удаляет вопрос анкеты без ответов
+func (q *Questions) DeleteQuestion(ctx context.Context, questionID string) error {
+	if err := q.storage.DeleteQuestion(ctx, questionID); err != nil {
+		return fmt.Errorf("%s: %w", opDeleteQuestion, err)
+	}
+	return nil
+}
+
+// validateQuestion проверяет и нормализует вопрос анкеты. Варианты ответа обязательны
+// для вопросов с выбором и недопустимы для остальных
+func validateQuestion(q *models.Question) error {
+	q.Label = strings.TrimSpace(q.Label)
+	if q.Label == "" {
+		return fmt.Errorf("%w: question label is required", ErrInvalidArgument)
+	}
+	options := make([]string, 0, len(q.Options))
+	for _, o := range q.Options {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			return fmt.Errorf("%w: question options must not be empty", ErrInvalidArgument)
+		}
+		if slices.Contains(options, o) {
+			return fmt.Errorf("%w: duplicate question option %q", ErrInvalidArgument, o)
+		}
+		options = append(options, o)
+	}
+	q.Options = options
+
+	switch q.Type {
+	case models.QuestionText, models.QuestionNumber, models.QuestionBoolean:
+		if len(q.Options) > 0 {
+			return fmt.Errorf("%w: options are allowed only for choice questions", ErrInvalidArgument)
+		}
+	case models.QuestionSingleChoice, models.QuestionMultipleChoice:
+		if len(q.Options) < 2 {
+			return fmt.Errorf("%w: choice question needs at least two options", ErrInvalidArgument)
+		}
+	default:
+		return fmt.Errorf("%w: unknown question type %q", ErrInvalidArgument, q.Type)
+	}
+	return nil
+}
+
+// validateAnswers проверяет ответы на анкету события и приводит их к каноническому виду: числа и логические
+// значения записываются единообразно, выбранные варианты - в порядке вариантов вопроса. Пустые ответы
+// считаются отсутствующими. Возвращает ответы в порядке вопросов
+func validateAnswers(questions []models.Question, answers []models.Answer) ([]models.Answer, error) {
+	byID := make(map[string]models.Answer, len(answers))
+	for _, a := range answers {
+		if !slices.ContainsFunc(questions, func(q models.Question) bool { return q.ID == a.QuestionID }) {
+			return nil, fmt.Errorf("%w: unknown question %q", ErrInvalidArgument, a.QuestionID)
+		}
+		if _, ok := byID[a.QuestionID]; ok {
+			return nil, fmt.Errorf("%w: duplicate answer to question %q", ErrInvalidArgument, a.QuestionID)
+		}
+		byID[a.QuestionID] = a
+	}
+
+	result := make([]models.Answer, 0, len(byID))
+	for _, q := range questions {
+		var values []string
+		for _, v := range byID[q.ID].Values {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			if q.Required {
+				return nil, fmt.Errorf("%w: answer to %q is required", ErrInvalidArgument, q.Label)
+			}
+			continue
+		}
+		values, err := normalizeAnswer(q, values)
+		if err != nil {
+			return nil, fmt.Errorf("%w: answer to %q: %s", ErrInvalidArgument, q.Label, err.Error())
+		}
+		result = append(result, models.Answer{QuestionID: q.ID, Values: values})
+	}
+	return result, nil
+}
+
+// normalizeAnswer проверяет непустой ответ на вопрос q и приводит его к каноническому виду
+func normalizeAnswer(q models.Question, values []string) ([]string, error) {
+	if q.Type != models.QuestionMultipleChoice && len(values) > 1 {
+		return nil, fmt.Errorf("expected a single value")
+	}
+	switch q.Type {
+	case models.QuestionText:
+		if utf8.RuneCountInString(values[0]) > maxAnswerLength {
+			return nil, fmt.Errorf("must be at most %d characters", maxAnswerLength)
+		}
+	case models.QuestionNumber:
+		n, err := strconv.ParseFloat(values[0], 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("must be a number")
+		}
+		values[0] = strconv.FormatFloat(n, 'f', -1, 64)
+	case models.QuestionBoolean:
+		b, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("must be true or false")
+		}
+		values[0] = strconv.FormatBool(b)
+	case models.QuestionSingleChoice, models.QuestionMultipleChoice:
+		for _, v := range values {
+			if !slices.Contains(q.Options, v) {
+				return nil, fmt.Errorf("unknown option %q", v)
+			}
+		}
+		// Сохраняем варианты в порядке вопроса без повторов
+		chosen := make([]string, 0, len(values))
+		for _, o := range q.Options {
+			if slices.Contains(values, o) {
+				chosen = append(chosen, o)
+			}
+		}
+		values = chosen
+	}
+	return values, nil
+}
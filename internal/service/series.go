@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/teambition/rrule-go"
+)
+
+// Константы для описания операций
+const (
+	opCreateSeries      = "service.CreateSeries"
+	opUpdateSeries      = "service.UpdateSeries"
+	opGetSeries         = "service.GetSeries"
+	opMaterializeSeries = "service.MaterializeSeries"
+)
+
+// SeriesStorage описывает методы repo-слоя для работы с сериями событий
+type SeriesStorage interface {
+	CreateSeries(ctx context.Context, sr models.Series) (models.Series, error)
+	UpdateSeries(ctx context.Context, sr models.Series) (models.Series, error)
+	GetSeries(ctx context.Context) ([]models.Series, error)
+	SyncSeriesOccurrences(ctx context.Context, seriesID string, occurrences []models.Event, limits models.RegistrationLimits, from, to time.Time) (int, []models.Registration, error)
+}
+
+// Series описывает сервис серий повторяющихся событий. Повторения создаются как обычные события
+// на скользящем горизонте horizon от текущего момента. Подписчики серии регистрируются на повторения
+// с теми же ограничениями options, что и при регистрации на событие, о регистрации публикуется сообщение в topic
+type Series struct {
+	log         *slog.Logger
+	storage     SeriesStorage
+	signer      TicketSigner
+	publisher   Publisher
+	topic       string
+	options     RegistrationOptions
+	defaultZone *time.Location
+	horizon     time.Duration
+}
+
+// NewSeries конструктор для Series
+func NewSeries(log *slog.Logger, storage SeriesStorage, signer TicketSigner, publisher Publisher, topic string, options RegistrationOptions, defaultZone *time.Location, horizon time.Duration) *Series {
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	return &Series{
+		log:         log,
+		storage:     storage,
+		signer:      signer,
+		publisher:   publisher,
+		topic:       topic,
+		options:     options,
+		defaultZone: defaultZone,
+		horizon:     horizon,
+	}
+}
+
+// CreateSeries создаёт серию и сразу создаёт её повторения в пределах горизонта
+func (s *Series) CreateSeries(ctx context.Context, sr models.Series) (models.Series, error) {
+	if err := s.validateSeries(&sr); err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+	created, err := s.storage.CreateSeries(ctx, sr)
+	if err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+	if err = s.materialize(ctx, created, s.options.Now()); err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+	return created, nil
+}
+
+// UpdateSeries изменяет серию. Изменения применяются только к будущим повторениям,
+// прошедшие повторения остаются как были
+func (s *Series) UpdateSeries(ctx context.Context, sr models.Series) (models.Series, error) {
+	if err := s.validateSeries(&sr); err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	updated, err := s.storage.UpdateSeries(ctx, sr)
+	if err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	if err = s.materialize(ctx, updated, s.options.Now()); err != nil {
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	return updated, nil
+}
+
+// GetSeries возвращает все серии событий
+func (s *Series) GetSeries(ctx context.Context) ([]models.Series, error) {
+	series, err := s.storage.GetSeries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetSeries, err)
+	}
+	return series, nil
+}
+
+// Materialize создаёт недостающие повторения всех серий, сдвигая горизонт вперёд.
+// Предназначен для периодического запуска в фоне
+func (s *Series) Materialize(ctx context.Context) error {
+	series, err := s.storage.GetSeries(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opMaterializeSeries, err)
+	}
+
+	// Ошибка в одной серии не должна останавливать материализацию остальных
+	now := s.options.Now()
+	var errs []error
+	for _, sr := range series {
+		if err = s.materialize(ctx, sr, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", opMaterializeSeries, err)
+	}
+	return nil
+}
+
+// materialize синхронизирует повторения серии в промежутке от now до горизонта и публикует сообщения
+// о регистрации подписчиков серии на повторения
+func (s *Series) materialize(ctx context.Context, sr models.Series, now time.Time) error {
+	to := now.Add(s.horizon)
+	occurrences, err := expandSeries(sr, now, to)
+	if err != nil {
+		return fmt.Errorf("series %s: %w", sr.ID, err)
+	}
+	created, regs, err := s.storage.SyncSeriesOccurrences(ctx, sr.ID, occurrences, s.options.limits(now), now, to)
+	if err != nil {
+		return fmt.Errorf("series %s: %w", sr.ID, err)
+	}
+	if created > 0 {
+		s.log.Info("series occurrences created", slog.String("series_id", sr.ID), slog.Int("created", created))
+	}
+	for _, reg := range regs {
+		reg.TicketToken = s.signer.Sign(reg.ID, reg.EventID)
+		// Регистрация уже сохранена, ошибка публикации не должна останавливать материализацию
+		if err = s.publisher.Publish(s.topic, models.RegisteredUser(reg)); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opMaterializeSeries), slog.String("registration_id", reg.ID))
+		}
+	}
+	return nil
+}
+
+// validateSeries проверяет и нормализует данные серии
+func (s *Series) validateSeries(sr *models.Series) error {
+	sr.Title = strings.TrimSpace(sr.Title)
+	sr.Description = strings.TrimSpace(sr.Description)
+	if sr.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidArgument)
+	}
+	if sr.StartsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at is required", ErrInvalidArgument)
+	}
+
+	sr.TimeZone = strings.TrimSpace(sr.TimeZone)
+	if sr.TimeZone == "" {
+		sr.TimeZone = s.defaultZone.String()
+	}
+	loc, err := time.LoadLocation(sr.TimeZone)
+	if err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidArgument, sr.TimeZone)
+	}
+
+	sr.RRule = strings.ToUpper(strings.TrimSpace(sr.RRule))
+	sr.RRule = strings.TrimPrefix(sr.RRule, "RRULE:")
+	opt, err := rrule.StrToROptionInLocation(sr.RRule, loc)
+	if err != nil {
+		return fmt.Errorf("%w: invalid rrule: %s", ErrInvalidArgument, err.Error())
+	}
+	// Повторения сопоставляются с правилом по местной дате, поэтому чаще раза в день серия повторяться не может
+	if opt.Freq > rrule.DAILY {
+		return fmt.Errorf("%w: rrule frequency must be DAILY or less frequent", ErrInvalidArgument)
+	}
+	if len(opt.Byhour) > 0 || len(opt.Byminute) > 0 || len(opt.Bysecond) > 0 {
+		return fmt.Errorf("%w: rrule must not contain BYHOUR, BYMINUTE or BYSECOND, time is taken from starts_at", ErrInvalidArgument)
+	}
+	if !opt.Dtstart.IsZero() {
+		return fmt.Errorf("%w: rrule must not contain DTSTART, use starts_at", ErrInvalidArgument)
+	}
+
+	exceptions := make([]string, 0, len(sr.Exceptions))
+	for _, e := range sr.Exceptions {
+		d, err := time.Parse(time.DateOnly, strings.TrimSpace(e))
+		if err != nil {
+			return fmt.Errorf("%w: exception %q must be a date like 2006-01-02", ErrInvalidArgument, e)
+		}
+		exceptions = append(exceptions, d.Format(time.DateOnly))
+	}
+	slices.Sort(exceptions)
+	sr.Exceptions = slices.Compact(exceptions)
+	return nil
+}
+
+// expandSeries вычисляет повторения серии, которые начинаются в промежутке [from, to).
+// Местное время повторений совпадает с местным временем первого повторения, в том числе
+// после перехода на летнее время
+func expandSeries(sr models.Series, from, to time.Time) ([]models.Event, error) {
+	loc, err := time.LoadLocation(sr.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	opt, err := rrule.StrToROptionInLocation(sr.RRule, loc)
+	if err != nil {
+		return nil, err
+	}
+	opt.Dtstart = sr.StartsAt.In(loc)
+	rule, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []models.Event
+	for _, t := range rule.Between(from, to, true) {
+		if !t.Before(to) {
+			continue
+		}
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		if slices.Contains(sr.Exceptions, date.Format(time.DateOnly)) {
+			continue
+		}
+		seriesID := sr.ID
+		occurrences = append(occurrences, models.Event{
+			Title:          sr.Title,
+			Description:    sr.Description,
+			StartsAt:       t,
+			TimeZone:       sr.TimeZone,
+			VenueID:        sr.VenueID,
+			SeriesID:       &seriesID,
+			OccurrenceDate: &date,
+		})
+	}
+	return occurrences, nil
+}
@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
-	pb "github.com/Telegram-bot-for-register-on-events/shared-proto/pb/event"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
 )
 
 // Константы для описания операций
 const (
-	opGetEvents = "service.GetEvents"
-	opGetEvent  = "service.GetEvent"
-	opRegister  = "service.Register"
+	opGetEvents      = "service.GetEvents"
+	opGetEvent       = "service.GetEvent"
+	opGetByInvite    = "service.GetEventByInvite"
+	opRegister       = "service.Register"
+	opRegisterSeries = "service.RegisterSeries"
 )
 
 // Service описывает сервисный слой микросервиса
@@ -20,48 +25,202 @@ type Service struct {
 	log           *slog.Logger
 	eventReceiver EventReceiver
 	registerer    Registerer
+	payments      PaymentCreator
+	signer        TicketSigner
+	options       RegistrationOptions
+	localization  Localization
+}
+
+// RegistrationOptions описывает настройки регистрации
+type RegistrationOptions struct {
+	// PaymentTTL срок оплаты платного билета
+	PaymentTTL time.Duration
+	// MaxNoShows количество неявок за NoShowPeriod, после которого пользователь не может регистрироваться
+	// на события с ограниченной вместимостью, 0 - без ограничения
+	MaxNoShows   int
+	NoShowPeriod time.Duration
+	// EventRateLimit сколько регистраций на одно событие принимается за EventRateWindow, 0 - без ограничения
+	EventRateLimit  int
+	EventRateWindow time.Duration
+	// Now источник текущего времени для проверки окна регистрации, nil - time.Now
+	Now func() time.Time
+}
+
+// limits возвращает ограничения регистрации, действующие в момент now
+func (o RegistrationOptions) limits(now time.Time) models.RegistrationLimits {
+	var l models.RegistrationLimits
+	if o.MaxNoShows > 0 {
+		l.MaxNoShows, l.NoShowsSince = o.MaxNoShows, now.Add(-o.NoShowPeriod)
+	}
+	if o.EventRateLimit > 0 {
+		l.EventRateLimit, l.EventRateSince = o.EventRateLimit, now.Add(-o.EventRateWindow)
+	}
+	return l
 }
 
 // EventReceiver описывает методы для получения информации о событиях
 type EventReceiver interface {
-	GetEvents(ctx context.Context) ([]*pb.Event, error)
-	GetEvent(ctx context.Context, eventID string) (*pb.Event, error)
+	GetEvents(ctx context.Context, filter models.EventFilter) ([]models.Event, error)
+	GetEvent(ctx context.Context, eventID string) (models.Event, error)
+	GetEventQuestions(ctx context.Context, eventID string) ([]models.Question, error)
+	GetRegistrationWindow(ctx context.Context, eventID string) (models.RegistrationWindow, error)
+	GetInviteCode(ctx context.Context, code string) (models.InviteCode, error)
+	GetUser(ctx context.Context, chatID int64) (models.UserProfile, error)
 }
 
-// Registerer описывает метод для взаимодействия с repo-слоем
+// Registerer описывает методы регистрации для взаимодействия с repo-слоем
 type Registerer interface {
-	RegisterUser(ctx context.Context, eventID string, chatID int64, username string) error
+	RegisterUser(ctx context.Context, req models.RegistrationRequest) (models.Registration, error)
+	RegisterSeries(ctx context.Context, eventID string, user models.UserProfile, limits models.RegistrationLimits, from time.Time) ([]models.Registration, error)
+}
+
+// PaymentCreator описывает метод создания платежа за регистрацию, ожидающую оплаты. Если платёж создать
+// не удалось, регистрация отменяется
+type PaymentCreator interface {
+	CreatePaymentOrCancel(ctx context.Context, reg models.Registration) (models.Payment, error)
 }
 
 // NewService конструктор для создания Service
-func NewService(log *slog.Logger, eventReceiver EventReceiver, registerer Registerer) *Service {
+func NewService(log *slog.Logger, eventReceiver EventReceiver, registerer Registerer, payments PaymentCreator, signer TicketSigner, options RegistrationOptions, localization Localization) *Service {
+	if options.Now == nil {
+		options.Now = time.Now
+	}
 	return &Service{
 		log:           log,
 		eventReceiver: eventReceiver,
 		registerer:    registerer,
+		payments:      payments,
+		signer:        signer,
+		options:       options,
+		localization:  localization,
 	}
 }
 
-func (s *Service) GetEvents(ctx context.Context) ([]*pb.Event, error) {
-	events, err := s.eventReceiver.GetEvents(ctx)
+func (s *Service) GetEvents(ctx context.Context, filter models.EventFilter) ([]models.Event, error) {
+	filter.Category = strings.ToLower(strings.TrimSpace(filter.Category))
+	filter.Tags = normalizeTags(filter.Tags)
+	events, err := s.eventReceiver.GetEvents(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", opGetEvents, err)
 	}
+	now := s.options.Now()
+	for i := range events {
+		setState(&events[i], now)
+	}
 	return events, nil
 }
 
-func (s *Service) GetEvent(ctx context.Context, eventID string) (*pb.Event, error) {
+// GetEvent возвращает событие по ID. Закрытые события доступны только по коду приглашения
+func (s *Service) GetEvent(ctx context.Context, eventID string) (models.Event, error) {
 	event, err := s.eventReceiver.GetEvent(ctx, eventID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", opGetEvent, err)
+		return models.Event{}, fmt.Errorf("%s: %w", opGetEvent, err)
 	}
+	if event.Visibility == models.VisibilityPrivate {
+		return models.Event{}, fmt.Errorf("%s: %w", opGetEvent, storage.ErrEventNotFound)
+	}
+	setState(&event, s.options.Now())
 	return event, nil
 }
 
-func (s *Service) RegisterUser(ctx context.Context, eventID string, chatID int64, username string) error {
-	err := s.registerer.RegisterUser(ctx, eventID, chatID, username)
+// GetEventByInvite возвращает событие, на которое выдан код приглашения. Недействующий код не раскрывает событие
+func (s *Service) GetEventByInvite(ctx context.Context, code string) (models.Event, error) {
+	invite, err := s.eventReceiver.GetInviteCode(ctx, code)
 	if err != nil {
-		return fmt.Errorf("%s: %w", opRegister, err)
+		return models.Event{}, fmt.Errorf("%s: %w", opGetByInvite, err)
+	}
+	now := s.options.Now()
+	if !invite.Valid(now) {
+		return models.Event{}, fmt.Errorf("%s: %w", opGetByInvite, storage.ErrInviteCodeInvalid)
 	}
-	return nil
+	event, err := s.eventReceiver.GetEvent(ctx, invite.EventID)
+	if err != nil {
+		return models.Event{}, fmt.Errorf("%s: %w", opGetByInvite, err)
+	}
+	setState(&event, now)
+	return event, nil
+}
+
+// setState заполняет состояние регистрации и продаж билетов события на момент now
+func setState(event *models.Event, now time.Time) {
+	event.RegistrationState = event.RegistrationWindow().State(now)
+	for i := range event.TicketTypes {
+		event.TicketTypes[i].OnSaleNow = event.TicketTypes[i].OnSale(now)
+	}
+}
+
+// RegisterUser регистрирует пользователя на событие или на сессию события и выпускает токен билета.
+// Регистрация принимается только в окне регистрации события.
+// При выборе платного билета регистрация ожидает оплаты, а в поле Payment возвращается созданный платёж
+// со ссылкой на оплату. Токен билета для такой регистрации выпускается после подтверждения оплаты.
+// Пользователи с повторными неявками не могут регистрироваться на события с ограниченной вместимостью.
+// На событие с одобрением организатора создаётся заявка: билет выпускается или оплата запрашивается после одобрения.
+// Гости пользователя занимают места наравне с ним. Ответы на анкету события проверяются до регистрации.
+// На закрытое событие регистрируются только по коду приглашения req.InviteCode.
+// Заблокированные пользователи не регистрируются, а число регистраций на событие за окно ограничено настройками
+func (s *Service) RegisterUser(ctx context.Context, req models.RegistrationRequest) (models.Registration, error) {
+	if req.Guests < 0 {
+		return models.Registration{}, fmt.Errorf("%s: %w: guests must not be negative", opRegister, ErrInvalidArgument)
+	}
+	req.User = normalizeProfile(req.User)
+	window, err := s.eventReceiver.GetRegistrationWindow(ctx, req.EventID)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	switch window.State(s.options.Now()) {
+	case models.RegistrationNotYetOpen:
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, ErrRegistrationNotOpen)
+	case models.RegistrationClosed:
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, ErrRegistrationClosed)
+	}
+	questions, err := s.eventReceiver.GetEventQuestions(ctx, req.EventID)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	if req.Answers, err = validateAnswers(questions, req.Answers); err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	req.PaymentTTL = s.options.PaymentTTL
+	req.RegistrationLimits = s.options.limits(s.options.Now())
+	reg, err := s.registerer.RegisterUser(ctx, req)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	switch reg.Status {
+	case models.RegistrationPendingPayment:
+		payment, err := s.payments.CreatePaymentOrCancel(ctx, reg)
+		if err != nil {
+			return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+		}
+		reg.Payment = &payment
+		return reg, nil
+	case models.RegistrationPendingApproval:
+		return reg, nil
+	}
+	reg.TicketToken = s.signer.Sign(reg.ID, reg.EventID)
+	return reg, nil
+}
+
+// RegisterSeries регистрирует пользователя на все будущие повторения серии, к которой относится событие,
+// в том числе на повторения, которые будут созданы позже. Возвращает созданные регистрации с токенами билетов
+func (s *Service) RegisterSeries(ctx context.Context, eventID string, user models.UserProfile) ([]models.Registration, error) {
+	now := s.options.Now()
+	regs, err := s.registerer.RegisterSeries(ctx, eventID, normalizeProfile(user), s.options.limits(now), now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	for i := range regs {
+		regs[i].TicketToken = s.signer.Sign(regs[i].ID, regs[i].EventID)
+	}
+	return regs, nil
+}
+
+// normalizeProfile приводит данные пользователя из Telegram к единому виду: username без @,
+// код языка в нижнем регистре
+func normalizeProfile(user models.UserProfile) models.UserProfile {
+	user.Username = strings.TrimPrefix(strings.TrimSpace(user.Username), "@")
+	user.FirstName = strings.TrimSpace(user.FirstName)
+	user.LastName = strings.TrimSpace(user.LastName)
+	user.LanguageCode = strings.ToLower(strings.TrimSpace(user.LanguageCode))
+	return user
 }
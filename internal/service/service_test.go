@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// eventsStub возвращает одно событие. Остальные методы EventReceiver в тестах не вызываются
+type eventsStub struct {
+	EventReceiver
+	event models.Event
+}
+
+func (e eventsStub) GetEvent(context.Context, string) (models.Event, error) { return e.event, nil }
+
+func TestGetEventState(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+	tests := []struct {
+		name       string
+		opensAt    *time.Time
+		salesStart *time.Time
+		salesEnd   *time.Time
+		wantState  models.RegistrationState
+		wantOnSale bool
+	}{
+		{name: "open", wantState: models.RegistrationOpen, wantOnSale: true},
+		{name: "sales not started", salesStart: &after, wantState: models.RegistrationOpen},
+		{name: "sales ended", salesEnd: &before, wantState: models.RegistrationOpen},
+		{name: "sales in window", salesStart: &before, salesEnd: &after, wantState: models.RegistrationOpen, wantOnSale: true},
+		{name: "registration not open", opensAt: &after, wantState: models.RegistrationNotYetOpen, wantOnSale: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := models.Event{
+				ID:                  "event",
+				StartsAt:            now.Add(24 * time.Hour),
+				RegistrationOpensAt: tt.opensAt,
+				TicketTypes:         []models.TicketType{{ID: "ticket", SalesStartAt: tt.salesStart, SalesEndAt: tt.salesEnd}},
+			}
+			s := NewService(slog.New(slog.DiscardHandler), eventsStub{event: event}, nil, nil, nil,
+				RegistrationOptions{Now: func() time.Time { return now }}, Localization{})
+
+			got, err := s.GetEvent(context.Background(), "event")
+			if err != nil {
+				t.Fatalf("GetEvent: %v", err)
+			}
+			if got.RegistrationState != tt.wantState {
+				t.Errorf("RegistrationState = %q, want %q", got.RegistrationState, tt.wantState)
+			}
+			if got.TicketTypes[0].OnSaleNow != tt.wantOnSale {
+				t.Errorf("OnSaleNow = %v, want %v", got.TicketTypes[0].OnSaleNow, tt.wantOnSale)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opCreateSession = "service.CreateSession"
+	opUpdateSession = "service.UpdateSession"
+	opDeleteSession = "service.DeleteSession"
+)
+
+// SessionStorage описывает методы repo-слоя для работы с сессиями событий
+type SessionStorage interface {
+	CreateSession(ctx context.Context, session models.Session) (models.Session, error)
+	UpdateSession(ctx context.Context, session models.Session) (models.Session, error)
+	DeleteSession(ctx context.Context, sessionID string) error
+}
+
+// Sessions описывает сервис сессий многодневных и многосеансовых событий
+type Sessions struct {
+	log     *slog.Logger
+	storage SessionStorage
+}
+
+// NewSessions конструктор для Sessions
+func NewSessions(log *slog.Logger, storage SessionStorage) *Sessions {
+	return &Sessions{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// CreateSession создаёт сессию события
+func (s *Sessions) CreateSession(ctx context.Context, session models.Session) (models.Session, error) {
+	if err := validateSession(&session); err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", opCreateSession, err)
+	}
+	created, err := s.storage.CreateSession(ctx, session)
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", opCreateSession, err)
+	}
+	return created, nil
+}
+
+// UpdateSession изменяет сессию. Уменьшение вместимости ниже числа регистраций не отменяет
+// существующие регистрации, но закрывает регистрацию новых пользователей
+func (s *Sessions) UpdateSession(ctx context.Context, session models.Session) (models.Session, error) {
+	if err := validateSession(&session); err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", opUpdateSession, err)
+	}
+	updated, err := s.storage.UpdateSession(ctx, session)
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", opUpdateSession, err)
+	}
+	return updated, nil
+}
+
+// DeleteSession удаляет сессию без регистраций
+func (s *Sessions) DeleteSession(ctx context.Context, sessionID string) error {
+	if err := s.storage.DeleteSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("%s: %w", opDeleteSession, err)
+	}
+	return nil
+}
+
+// validateSession проверяет и нормализует данные сессии
+func validateSession(session *models.Session) error {
+	session.Title = strings.TrimSpace(session.Title)
+	if session.StartsAt.IsZero() || session.EndsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at and ends_at are required", ErrInvalidArgument)
+	}
+	if !session.EndsAt.After(session.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidArgument)
+	}
+	if session.Capacity != nil && *session.Capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", ErrInvalidArgument)
+	}
+	return nil
+}
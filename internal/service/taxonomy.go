@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opGetCategories      = "service.GetCategories"
+	opCreateCategory     = "service.CreateCategory"
+	opUpdateCategory     = "service.UpdateCategory"
+	opDeleteCategory     = "service.DeleteCategory"
+	opSetEventCategories = "service.SetEventCategories"
+	opSetEventTags       = "service.SetEventTags"
+)
+
+// maxTagLength максимальная длина тега в символах
+const maxTagLength = 50
+
+// slugPattern допустимый формат slug категории
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// TaxonomyStorage описывает методы repo-слоя для работы с категориями и тегами
+type TaxonomyStorage interface {
+	GetCategories(ctx context.Context) ([]models.Category, error)
+	CreateCategory(ctx context.Context, c models.Category) (models.Category, error)
+	UpdateCategory(ctx context.Context, c models.Category) (models.Category, error)
+	DeleteCategory(ctx context.Context, categoryID string) error
+	SetEventCategories(ctx context.Context, eventID string, categoryIDs []string) error
+	SetEventTags(ctx context.Context, eventID string, tags []string) error
+}
+
+// Taxonomy описывает сервис категорий и тегов событий
+type Taxonomy struct {
+	log     *slog.Logger
+	storage TaxonomyStorage
+}
+
+// NewTaxonomy конструктор для Taxonomy
+func NewTaxonomy(log *slog.Logger, storage TaxonomyStorage) *Taxonomy {
+	return &Taxonomy{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// GetCategories возвращает категории в порядке отображения
+func (t *Taxonomy) GetCategories(ctx context.Context) ([]models.Category, error) {
+	categories, err := t.storage.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetCategories, err)
+	}
+	return categories, nil
+}
+
+// CreateCategory создаёт категорию
+func (t *Taxonomy) CreateCategory(ctx context.Context, c models.Category) (models.Category, error) {
+	if err := validateCategory(&c); err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", opCreateCategory, err)
+	}
+	created, err := t.storage.CreateCategory(ctx, c)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", opCreateCategory, err)
+	}
+	return created, nil
+}
+
+// UpdateCategory обновляет категорию
+func (t *Taxonomy) UpdateCategory(ctx context.Context, c models.Category) (models.Category, error) {
+	if err := validateCategory(&c); err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", opUpdateCategory, err)
+	}
+	updated, err := t.storage.UpdateCategory(ctx, c)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("%s: %w", opUpdateCategory, err)
+	}
+	return updated, nil
+}
+
+// DeleteCategory удаляет категорию
+func (t *Taxonomy) DeleteCategory(ctx context.Context, categoryID string) error {
+	if err := t.storage.DeleteCategory(ctx, categoryID); err != nil {
+		return fmt.Errorf("%s: %w", opDeleteCategory, err)
+	}
+	return nil
+}
+
+// SetEventCategories заменяет категории события
+func (t *Taxonomy) SetEventCategories(ctx context.Context, eventID string, categoryIDs []string) error {
+	slices.Sort(categoryIDs)
+	categoryIDs = slices.Compact(categoryIDs)
+	if err := t.storage.SetEventCategories(ctx, eventID, categoryIDs); err != nil {
+		return fmt.Errorf("%s: %w", opSetEventCategories, err)
+	}
+	return nil
+}
+
+// SetEventTags заменяет теги события. Теги приводятся к нижнему регистру, дубликаты удаляются
+func (t *Taxonomy) SetEventTags(ctx context.Context, eventID string, tags []string) error {
+	tags = normalizeTags(tags)
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return fmt.Errorf("%s: %w: tag %q is longer than %d characters", opSetEventTags, ErrInvalidArgument, tag, maxTagLength)
+		}
+	}
+	if err := t.storage.SetEventTags(ctx, eventID, tags); err != nil {
+		return fmt.Errorf("%s: %w", opSetEventTags, err)
+	}
+	return nil
+}
+
+// validateCategory проверяет и нормализует категорию
+func validateCategory(c *models.Category) error {
+	c.Slug = strings.ToLower(strings.TrimSpace(c.Slug))
+	c.Name = strings.TrimSpace(c.Name)
+	if !slugPattern.MatchString(c.Slug) {
+		return fmt.Errorf("%w: slug must contain only latin letters, digits and dashes", ErrInvalidArgument)
+	}
+	if c.Name == "" {
+		return fmt.Errorf("%w: category name is required", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// normalizeTags приводит теги к нижнему регистру, убирает пустые и повторяющиеся
+func normalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
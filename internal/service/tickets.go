@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opCreateTicketType = "service.CreateTicketType"
+	opUpdateTicketType = "service.UpdateTicketType"
+	opDeleteTicketType = "service.DeleteTicketType"
+)
+
+// DefaultCurrency валюта цены билета по умолчанию
+const DefaultCurrency = "RUB"
+
+// currencyPattern код валюты ISO 4217
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// TicketTypeStorage описывает методы repo-слоя для работы с типами билетов
+type TicketTypeStorage interface {
+	CreateTicketType(ctx context.Context, t models.TicketType) (models.TicketType, error)
+	UpdateTicketType(ctx context.Context, t models.TicketType) (models.TicketType, error)
+	DeleteTicketType(ctx context.Context, ticketTypeID string) error
+}
+
+// TicketTypes описывает сервис типов билетов
+type TicketTypes struct {
+	log     *slog.Logger
+	storage TicketTypeStorage
+}
+
+// NewTicketTypes конструктор для TicketTypes
+func NewTicketTypes(log *slog.Logger, storage TicketTypeStorage) *TicketTypes {
+	return &TicketTypes{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// CreateTicketType создаёт тип билета события
+func (t *TicketTypes) CreateTicketType(ctx context.Context, ticketType models.TicketType) (models.TicketType, error) {
+	if err := validateTicketType(&ticketType); err != nil {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opCreateTicketType, err)
+	}
+	created, err := t.storage.CreateTicketType(ctx, ticketType)
+	if err != nil {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opCreateTicketType, err)
+	}
+	return created, nil
+}
+
+// UpdateTicketType изменяет тип билета. Уменьшение количества билетов ниже числа регистраций
+// не отменяет существующие регистрации, но останавливает продажи
+func (t *TicketTypes) UpdateTicketType(ctx context.Context, ticketType models.TicketType) (models.TicketType, error) {
+	if err := validateTicketType(&ticketType); err != nil {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opUpdateTicketType, err)
+	}
+	updated, err := t.storage.UpdateTicketType(ctx, ticketType)
+	if err != nil {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opUpdateTicketType, err)
+	}
+	return updated, nil
+}
+
+// DeleteTicketType удаляет тип билета без регистраций
+func (t *TicketTypes) DeleteTicketType(ctx context.Context, ticketTypeID string) error {
+	if err := t.storage.DeleteTicketType(ctx, ticketTypeID); err != nil {
+		return fmt.Errorf("%s: %w", opDeleteTicketType, err)
+	}
+	return nil
+}
+
+// validateTicketType проверяет и нормализует данные типа билета
+func validateTicketType(t *models.TicketType) error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	if t.Name == "" {
+		return fmt.Errorf("%w: ticket type name is required", ErrInvalidArgument)
+	}
+	if t.PriceAmount < 0 {
+		return fmt.Errorf("%w: price_amount must not be negative", ErrInvalidArgument)
+	}
+	t.Currency = strings.ToUpper(strings.TrimSpace(t.Currency))
+	if t.Currency == "" {
+		t.Currency = DefaultCurrency
+	}
+	if !currencyPattern.MatchString(t.Currency) {
+		return fmt.Errorf("%w: currency must be an ISO 4217 code like RUB", ErrInvalidArgument)
+	}
+	if t.Capacity != nil && *t.Capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", ErrInvalidArgument)
+	}
+	if t.SalesStartAt != nil && t.SalesEndAt != nil && !t.SalesEndAt.After(*t.SalesStartAt) {
+		return fmt.Errorf("%w: sales_end_at must be after sales_start_at", ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opLocalize               = "service.Localize"
+	opGetEventTranslations   = "service.GetEventTranslations"
+	opSetEventTranslation    = "service.SetEventTranslation"
+	opDeleteEventTranslation = "service.DeleteEventTranslation"
+)
+
+// languagePattern допустимый код языка: основной язык и необязательные подтеги (en, pt-br, zh-hant)
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Localization описывает настройки перевода текстов событий
+type Localization struct {
+	// DefaultLanguage язык, на котором написаны исходные тексты событий
+	DefaultLanguage string
+	// FallbackLanguage язык перевода, который показывается, если нет перевода на язык пользователя.
+	// Пусто - показывается исходный текст
+	FallbackLanguage string
+}
+
+// candidates возвращает языки, перевод на которые подходит пользователю с языком language, в порядке предпочтения:
+// сам язык, основной язык без региона и запасной язык. Для неизвестного языка подходит только исходный текст
+func (l Localization) candidates(language string) []string {
+	language = models.NormalizeLanguage(language)
+	if language == "" {
+		return nil
+	}
+	var list []string
+	for _, lang := range []string{language, models.BaseLanguage(language), l.FallbackLanguage} {
+		if lang != "" && !slices.Contains(list, lang) {
+			list = append(list, lang)
+		}
+	}
+	return list
+}
+
+// localize заменяет тексты события переводом на первый подходящий язык. Язык исходных текстов
+// в списке означает, что перевод не нужен
+func (l Localization) localize(e *models.Event, candidates []string) {
+	e.Language = l.DefaultLanguage
+	for _, lang := range candidates {
+		if lang == l.DefaultLanguage {
+			return
+		}
+		if t, ok := e.Translation(lang); ok {
+			e.Title, e.Language = t.Title, t.Language
+			if t.Description != "" {
+				e.Description = t.Description
+			}
+			return
+		}
+	}
+}
+
+// Localize переводит тексты событий на язык пользователя. language - язык из запроса, если он не передан,
+// используется язык из профиля пользователя chatID. Ошибка чтения профиля не мешает вернуть событие:
+// тексты остаются на исходном языке
+func (s *Service) Localize(ctx context.Context, language string, chatID *int64, events ...*models.Event) {
+	if strings.TrimSpace(language) == "" && chatID != nil {
+		user, err := s.eventReceiver.GetUser(ctx, *chatID)
+		switch {
+		case err == nil:
+			language = user.LanguageCode
+		case !errors.Is(err, storage.ErrUserNotFound):
+			s.log.Error("error", err.Error(), slog.String("operation", opLocalize))
+		}
+	}
+	candidates := s.localization.candidates(language)
+	for _, e := range events {
+		s.localization.localize(e, candidates)
+	}
+}
+
+// TranslationStorage описывает методы repo-слоя для работы с переводами событий
+type TranslationStorage interface {
+	GetEventTranslations(ctx context.Context, eventID string) ([]models.EventTranslation, error)
+	SetEventTranslation(ctx context.Context, t models.EventTranslation) (models.EventTranslation, error)
+	DeleteEventTranslation(ctx context.Context, eventID, language string) error
+}
+
+// Translations описывает сервис управления переводами событий
+type Translations struct {
+	log     *slog.Logger
+	storage TranslationStorage
+	// defaultLanguage язык исходных текстов, перевод на него не создаётся
+	defaultLanguage string
+}
+
+// NewTranslations конструктор для Translations
+func NewTranslations(log *slog.Logger, storage TranslationStorage, defaultLanguage string) *Translations {
+	return &Translations{
+		log:             log,
+		storage:         storage,
+		defaultLanguage: defaultLanguage,
+	}
+}
+
+// GetEventTranslations возвращает переводы события
+func (t *Translations) GetEventTranslations(ctx context.Context, eventID string) ([]models.EventTranslation, error) {
+	translations, err := t.storage.GetEventTranslations(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetEventTranslations, err)
+	}
+	return translations, nil
+}
+
+// SetEventTranslation создаёт или заменяет перевод события. Пустое описание - показывается исходное описание
+func (t *Translations) SetEventTranslation(ctx context.Context, tr models.EventTranslation) (models.EventTranslation, error) {
+	tr.Language = models.NormalizeLanguage(tr.Language)
+	if !languagePattern.MatchString(tr.Language) {
+		return models.EventTranslation{}, fmt.Errorf("%s: %w: invalid language code", opSetEventTranslation, ErrInvalidArgument)
+	}
+	if tr.Language == t.defaultLanguage {
+		return models.EventTranslation{}, fmt.Errorf("%s: %w: %s is the language of the original text",
+			opSetEventTranslation, ErrInvalidArgument, tr.Language)
+	}
+	tr.Title = strings.TrimSpace(tr.Title)
+	tr.Description = strings.TrimSpace(tr.Description)
+	if tr.Title == "" {
+		return models.EventTranslation{}, fmt.Errorf("%s: %w: title is required", opSetEventTranslation, ErrInvalidArgument)
+	}
+	saved, err := t.storage.SetEventTranslation(ctx, tr)
+	if err != nil {
+		return models.EventTranslation{}, fmt.Errorf("%s: %w", opSetEventTranslation, err)
+	}
+	return saved, nil
+}
+
+// DeleteEventTranslation удаляет перевод события на язык language
+func (t *Translations) DeleteEventTranslation(ctx context.Context, eventID, language string) error {
+	if err := t.storage.DeleteEventTranslation(ctx, eventID, models.NormalizeLanguage(language)); err != nil {
+		return fmt.Errorf("%s: %w", opDeleteEventTranslation, err)
+	}
+	return nil
+}
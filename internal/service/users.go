@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opGetUser = "service.GetUser"
+)
+
+// UserStorage описывает методы repo-слоя для работы с профилями пользователей
+type UserStorage interface {
+	GetUser(ctx context.Context, chatID int64) (models.UserProfile, error)
+}
+
+// Users описывает сервис профилей пользователей
+type Users struct {
+	log     *slog.Logger
+	storage UserStorage
+}
+
+// NewUsers конструктор для Users
+func NewUsers(log *slog.Logger, storage UserStorage) *Users {
+	return &Users{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// GetUser возвращает профиль пользователя по chat_id
+func (u *Users) GetUser(ctx context.Context, chatID int64) (models.UserProfile, error) {
+	user, err := u.storage.GetUser(ctx, chatID)
+	if err != nil {
+		return models.UserProfile{}, fmt.Errorf("%s: %w", opGetUser, err)
+	}
+	return user, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opCreateVenue   = "service.CreateVenue"
+	opUpdateVenue   = "service.UpdateVenue"
+	opGetVenues     = "service.GetVenues"
+	opSetEventVenue = "service.SetEventVenue"
+	opEventsNearby  = "service.EventsNearby"
+)
+
+// Ограничения поиска событий рядом
+const (
+	DefaultNearbyRadiusKm = 10.0
+	MaxNearbyRadiusKm     = 200.0
+)
+
+// VenueStorage описывает методы repo-слоя для работы с площадками
+type VenueStorage interface {
+	CreateVenue(ctx context.Context, v models.Venue) (models.Venue, error)
+	UpdateVenue(ctx context.Context, v models.Venue) (models.Venue, error)
+	GetVenues(ctx context.Context) ([]models.Venue, error)
+	SetEventVenue(ctx context.Context, eventID string, venueID *string) error
+	GetEventsNear(ctx context.Context, lat, lon, radiusKm float64, from time.Time) ([]models.NearbyEvent, error)
+}
+
+// Venues описывает сервис площадок и геопоиска
+type Venues struct {
+	log     *slog.Logger
+	storage VenueStorage
+}
+
+// NewVenues конструктор для Venues
+func NewVenues(log *slog.Logger, storage VenueStorage) *Venues {
+	return &Venues{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// CreateVenue создаёт площадку
+func (v *Venues) CreateVenue(ctx context.Context, venue models.Venue) (models.Venue, error) {
+	if err := validateVenue(&venue); err != nil {
+		return models.Venue{}, fmt.Errorf("%s: %w", opCreateVenue, err)
+	}
+	created, err := v.storage.CreateVenue(ctx, venue)
+	if err != nil {
+		return models.Venue{}, fmt.Errorf("%s: %w", opCreateVenue, err)
+	}
+	return created, nil
+}
+
+// UpdateVenue обновляет площадку
+func (v *Venues) UpdateVenue(ctx context.Context, venue models.Venue) (models.Venue, error) {
+	if err := validateVenue(&venue); err != nil {
+		return models.Venue{}, fmt.Errorf("%s: %w", opUpdateVenue, err)
+	}
+	updated, err := v.storage.UpdateVenue(ctx, venue)
+	if err != nil {
+		return models.Venue{}, fmt.Errorf("%s: %w", opUpdateVenue, err)
+	}
+	return updated, nil
+}
+
+// GetVenues возвращает все площадки
+func (v *Venues) GetVenues(ctx context.Context) ([]models.Venue, error) {
+	venues, err := v.storage.GetVenues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetVenues, err)
+	}
+	return venues, nil
+}
+
+// SetEventVenue привязывает событие к площадке или отвязывает его, если venueID равен nil
+func (v *Venues) SetEventVenue(ctx context.Context, eventID string, venueID *string) error {
+	if err := v.storage.SetEventVenue(ctx, eventID, venueID); err != nil {
+		return fmt.Errorf("%s: %w", opSetEventVenue, err)
+	}
+	return nil
+}
+
+// EventsNearby возвращает предстоящие события в радиусе radiusKm от точки, отсортированные по расстоянию.
+// Нулевой радиус заменяется на DefaultNearbyRadiusKm
+func (v *Venues) EventsNearby(ctx context.Context, lat, lon, radiusKm float64) ([]models.NearbyEvent, error) {
+	if radiusKm == 0 {
+		radiusKm = DefaultNearbyRadiusKm
+	}
+	if err := validateCoordinates(lat, lon); err != nil {
+		return nil, fmt.Errorf("%s: %w", opEventsNearby, err)
+	}
+	if radiusKm < 0 || radiusKm > MaxNearbyRadiusKm {
+		return nil, fmt.Errorf("%s: %w: radius must be between 0 and %g km", opEventsNearby, ErrInvalidArgument, MaxNearbyRadiusKm)
+	}
+
+	events, err := v.storage.GetEventsNear(ctx, lat, lon, radiusKm, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opEventsNearby, err)
+	}
+	return events, nil
+}
+
+// validateVenue проверяет и нормализует данные площадки
+func validateVenue(venue *models.Venue) error {
+	venue.Name = strings.TrimSpace(venue.Name)
+	if venue.Name == "" {
+		return fmt.Errorf("%w: venue name is required", ErrInvalidArgument)
+	}
+	if err := validateCoordinates(venue.Latitude, venue.Longitude); err != nil {
+		return err
+	}
+	if venue.Capacity != nil && *venue.Capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", ErrInvalidArgument)
+	}
+	if venue.TimeZone != nil {
+		if _, err := time.LoadLocation(*venue.TimeZone); err != nil {
+			return fmt.Errorf("%w: unknown time zone %q", ErrInvalidArgument, *venue.TimeZone)
+		}
+	}
+	return nil
+}
+
+// validateCoordinates проверяет диапазоны широты и долготы
+func validateCoordinates(lat, lon float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("%w: latitude must be between -90 and 90", ErrInvalidArgument)
+	}
+	if lon < -180 || lon > 180 {
+		return fmt.Errorf("%w: longitude must be between -180 and 180", ErrInvalidArgument)
+	}
+	return nil
+}
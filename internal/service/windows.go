@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opSetRegistrationWindow = "service.SetRegistrationWindow"
+	opAnnounceOpened        = "service.AnnounceOpenedRegistrations"
+)
+
+// RegistrationWindowStorage описывает методы repo-слоя для работы с окнами регистрации
+type RegistrationWindowStorage interface {
+	SetRegistrationWindow(ctx context.Context, eventID string, w models.RegistrationWindow) error
+	ClaimOpenedRegistrations(ctx context.Context, now time.Time) ([]models.Event, error)
+}
+
+// RegistrationWindows описывает сервис окон регистрации на события
+type RegistrationWindows struct {
+	log       *slog.Logger
+	storage   RegistrationWindowStorage
+	publisher Publisher
+	// topic топик для сообщений об открытии регистрации
+	topic string
+	now   func() time.Time
+}
+
+// NewRegistrationWindows конструктор для RegistrationWindows. now - источник текущего времени, nil - time.Now
+func NewRegistrationWindows(log *slog.Logger, storage RegistrationWindowStorage, publisher Publisher, topic string, now func() time.Time) *RegistrationWindows {
+	if now == nil {
+		now = time.Now
+	}
+	return &RegistrationWindows{
+		log:       log,
+		storage:   storage,
+		publisher: publisher,
+		topic:     topic,
+		now:       now,
+	}
+}
+
+// SetRegistrationWindow задаёт окно регистрации на событие. closesBefore - за сколько до начала события
+// закрывается регистрация, задаётся с точностью до минуты
+func (r *RegistrationWindows) SetRegistrationWindow(ctx context.Context, eventID string, opensAt, closesAt *time.Time, closesBefore *time.Duration) error {
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return fmt.Errorf("%s: %w: closes_at must be after opens_at", opSetRegistrationWindow, ErrInvalidArgument)
+	}
+	w := models.RegistrationWindow{OpensAt: opensAt, ClosesAt: closesAt}
+	if closesBefore != nil {
+		if *closesBefore < 0 || *closesBefore%time.Minute != 0 {
+			return fmt.Errorf("%s: %w: closes_before_start must be a non-negative whole number of minutes",
+				opSetRegistrationWindow, ErrInvalidArgument)
+		}
+		minutes := int(*closesBefore / time.Minute)
+		w.ClosesBefore = &minutes
+	}
+	if err := r.storage.SetRegistrationWindow(ctx, eventID, w); err != nil {
+		return fmt.Errorf("%s: %w", opSetRegistrationWindow, err)
+	}
+	return nil
+}
+
+// AnnounceOpened публикует сообщения об открытии регистрации на события, у которых наступил момент открытия.
+// Если регистрация к этому моменту уже закрылась, сообщение не публикуется. Предназначен для периодического запуска в фоне
+func (r *RegistrationWindows) AnnounceOpened(ctx context.Context) error {
+	now := r.now()
+	events, err := r.storage.ClaimOpenedRegistrations(ctx, now)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opAnnounceOpened, err)
+	}
+	for _, e := range events {
+		window := e.RegistrationWindow()
+		if window.State(now) != models.RegistrationOpen {
+			continue
+		}
+		msg := models.RegistrationOpened{
+			EventID:  e.ID,
+			Title:    e.Title,
+			StartsAt: e.StartsAt.UTC(),
+			TimeZone: e.TimeZone,
+			OpensAt:  window.OpensAt.UTC(),
+		}
+		if deadline := window.Deadline(); deadline != nil {
+			closesAt := deadline.UTC()
+			msg.ClosesAt = &closesAt
+		}
+		if err := r.publisher.Publish(r.topic, msg); err != nil {
+			// Событие уже отмечено объявленным, повторная публикация не выполняется
+			r.log.Error("error", err.Error(), slog.String("operation", opAnnounceOpened), slog.String("event_id", e.ID))
+			continue
+		}
+		r.log.Info("registration opened", slog.String("event_id", e.ID))
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Константы для описания операций
+const (
+	opSetEventRequiresApproval = "postgres.setEventRequiresApproval"
+	opApproveRegistration      = "postgres.approveRegistration"
+	opRejectRegistration       = "postgres.rejectRegistration"
+	opRevertApproval           = "postgres.revertApproval"
+)
+
+// SetEventRequiresApproval включает или выключает одобрение регистраций на событие организатором.
+// Заявки, поданные до выключения, остаются ожидать решения
+func (s *Storage) SetEventRequiresApproval(ctx context.Context, eventID string, requiresApproval bool) error {
+	res, err := s.DB.ExecContext(ctx, `update events set requires_approval = $2 where id = $1`, eventID, requiresApproval)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opSetEventRequiresApproval, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventRequiresApproval))
+		return fmt.Errorf("%s: %w", opSetEventRequiresApproval, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opSetEventRequiresApproval, storage.ErrEventNotFound)
+	}
+	return nil
+}
+
+// ApproveRegistration одобряет заявку на регистрацию, если в сессии и в типе билета хватает мест на пользователя
+// и гостей. Регистрация с платным билетом переходит в ожидание оплаты со сроком paymentTTL, остальные - подтверждаются
+func (s *Storage) ApproveRegistration(ctx context.Context, registrationID string, reason *string, paymentTTL time.Duration, now time.Time) (reg models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opApproveRegistration))
+		return models.Registration{}, fmt.Errorf("%s: %w", opApproveRegistration, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	reg, err = s.lockApplication(ctx, tx, opApproveRegistration, registrationID)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opApproveRegistration, err)
+	}
+	ticketType, err := s.reserveSeats(ctx, tx, opApproveRegistration, reg, 1+reg.Guests)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opApproveRegistration, err)
+	}
+
+	status, expiresAt := models.RegistrationConfirmed, (*time.Time)(nil)
+	if ticketType.PriceAmount > 0 {
+		deadline := now.Add(paymentTTL)
+		status, expiresAt = models.RegistrationPendingPayment, &deadline
+	}
+	query := `update registration set status = $2, expires_at = $3, decision_reason = $4, decided_at = $5
+		where id = $1 returning *`
+	if err = tx.GetContext(ctx, &reg, query, reg.ID, status, expiresAt, reason, now); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opApproveRegistration))
+		return models.Registration{}, fmt.Errorf("%s: %w", opApproveRegistration, err)
+	}
+	if err = s.attachUsers(ctx, tx, &reg); err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opApproveRegistration, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opApproveRegistration))
+		return models.Registration{}, fmt.Errorf("%s: %w", opApproveRegistration, err)
+	}
+	reg.PriceAmount, reg.Currency = ticketType.PriceAmount*int64(1+reg.Guests), ticketType.Currency
+	return reg, nil
+}
+
+// RejectRegistration отклоняет заявку на регистрацию с указанием причины
+func (s *Storage) RejectRegistration(ctx context.Context, registrationID, reason string, now time.Time) (reg models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRejectRegistration))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRejectRegistration, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	reg, err = s.lockApplication(ctx, tx, opRejectRegistration, registrationID)
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRejectRegistration, err)
+	}
+	query := `update registration set status = $2, decision_reason = $3, decided_at = $4 where id = $1 returning *`
+	if err = tx.GetContext(ctx, &reg, query, reg.ID, models.RegistrationRejected, reason, now); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRejectRegistration))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRejectRegistration, err)
+	}
+	if err = s.attachUsers(ctx, tx, &reg); err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRejectRegistration, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRejectRegistration))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRejectRegistration, err)
+	}
+	return reg, nil
+}
+
+// RevertApproval возвращает одобренную заявку, ожидающую оплаты, к рассмотрению. Используется, если платёж
+// за одобренную регистрацию создать не удалось: место освобождается, а заявка снова ждёт решения
+func (s *Storage) RevertApproval(ctx context.Context, registrationID string) error {
+	query := `update registration set status = $2, expires_at = null, decision_reason = null, decided_at = null
+		where id = $1 and status = $3`
+	_, err := s.DB.ExecContext(ctx, query, registrationID, models.RegistrationPendingApproval, models.RegistrationPendingPayment)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRevertApproval))
+		return fmt.Errorf("%s: %w", opRevertApproval, err)
+	}
+	return nil
+}
+
+// lockApplication блокирует заявку на регистрацию до конца транзакции. Решение по заявке принимается один раз
+func (s *Storage) lockApplication(ctx context.Context, tx *sqlx.Tx, op, registrationID string) (models.Registration, error) {
+	var reg models.Registration
+	err := tx.GetContext(ctx, &reg, `select * from registration where id = $1 for update`, registrationID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Registration{}, storage.ErrRegistrationNotFound
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", op))
+		return models.Registration{}, err
+	}
+	if reg.Status != models.RegistrationPendingApproval {
+		return models.Registration{}, storage.ErrApplicationDecided
+	}
+	return reg, nil
+}
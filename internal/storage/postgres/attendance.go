@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opMarkNoShows        = "postgres.markNoShows"
+	opGetUserAttendance  = "postgres.getUserAttendance"
+	opGetEventAttendance = "postgres.getEventAttendance"
+)
+
+// registrationEnd выражение для момента окончания того, на что зарегистрирован пользователь: сессии,
+// а для событий без сессий - начала события плюс предполагаемая длительность $2 в секундах
+const registrationEnd = `coalesce(es.ends_at, e.starts_at + make_interval(secs => $2))`
+
+// MarkNoShows отмечает неявку по подтверждённым регистрациям без отметки прохода, которые закончились
+// в промежутке [from, now). Моментом неявки считается окончание события или сессии. Возвращает количество неявок
+func (s *Storage) MarkNoShows(ctx context.Context, from, now time.Time, eventDuration time.Duration) (int, error) {
+	query := `insert into attendance (registration_id, event_id, session_id, chat_id, status, recorded_at)
+		select r.id, r.event_id, r.session_id, r.chat_id, $5, ` + registrationEnd + `
+		from registration r
+			join events e on e.id = r.event_id
+			left join event_sessions es on es.id = r.session_id
+		where r.status = $4 and r.checked_in_at is null
+			and ` + registrationEnd + ` >= $1 and ` + registrationEnd + ` < $3
+		on conflict (registration_id) do nothing`
+	res, err := s.DB.ExecContext(ctx, query, from, eventDuration.Seconds(), now,
+		models.RegistrationConfirmed, models.AttendanceNoShow)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opMarkNoShows))
+		return 0, fmt.Errorf("%s: %w", opMarkNoShows, err)
+	}
+	marked, _ := res.RowsAffected()
+	return int(marked), nil
+}
+
+// GetUserAttendance возвращает историю посещений пользователя, начиная с последних событий
+func (s *Storage) GetUserAttendance(ctx context.Context, chatID int64) ([]models.AttendanceRecord, error) {
+	var records []models.AttendanceRecord
+	query := `select a.*, e.title as event_title, e.starts_at as event_starts_at
+		from attendance a join events e on e.id = a.event_id
+		where a.chat_id = $1
+		order by a.recorded_at desc`
+	if err := s.DB.SelectContext(ctx, &records, query, chatID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetUserAttendance))
+		return nil, fmt.Errorf("%s: %w", opGetUserAttendance, err)
+	}
+	return records, nil
+}
+
+// GetEventAttendance возвращает статистику посещения события
+func (s *Storage) GetEventAttendance(ctx context.Context, eventID string) (models.EventAttendance, error) {
+	var stats models.EventAttendance
+	query := `select e.id as event_id,
+			(select count(*) from registration r where r.event_id = e.id and r.status = $2) as registered,
+			(select count(*) from attendance a where a.event_id = e.id and a.status = $3) as attended,
+			(select count(*) from attendance a where a.event_id = e.id and a.status = $4) as no_shows,
+			(select count(*) from registration r where r.event_id = e.id and r.status = $2
+				and not exists (select 1 from attendance a where a.registration_id = r.id)) as unknown
+		from events e where e.id = $1`
+	err := s.DB.GetContext(ctx, &stats, query, eventID, models.RegistrationConfirmed,
+		models.AttendanceAttended, models.AttendanceNoShow)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.EventAttendance{}, fmt.Errorf("%s: %w", opGetEventAttendance, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventAttendance))
+		return models.EventAttendance{}, fmt.Errorf("%s: %w", opGetEventAttendance, err)
+	}
+	return stats, nil
+}
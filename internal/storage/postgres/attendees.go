@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opGetEventRegistrations = "postgres.getEventRegistrations"
+)
+
+// GetEventRegistrations возвращает регистрации на событие в порядке создания вместе с профилями пользователей
+// и ответами на анкету
+func (s *Storage) GetEventRegistrations(ctx context.Context, eventID string) ([]models.Registration, error) {
+	var exists bool
+	err := s.DB.GetContext(ctx, &exists, `select exists (select 1 from events where id = $1)`, eventID)
+	if isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opGetEventRegistrations, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventRegistrations))
+		return nil, fmt.Errorf("%s: %w", opGetEventRegistrations, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", opGetEventRegistrations, storage.ErrEventNotFound)
+	}
+
+	var regs []models.Registration
+	query := `select * from registration where event_id = $1 order by created_at, id`
+	if err = s.DB.SelectContext(ctx, &regs, query, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventRegistrations))
+		return nil, fmt.Errorf("%s: %w", opGetEventRegistrations, err)
+	}
+	if len(regs) == 0 {
+		return regs, nil
+	}
+	if err = s.attachUsers(ctx, s.DB, registrationPtrs(regs)...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetEventRegistrations, err)
+	}
+
+	ids := make([]string, 0, len(regs))
+	byID := make(map[string]*models.Registration, len(regs))
+	for i := range regs {
+		ids = append(ids, regs[i].ID)
+		byID[regs[i].ID] = &regs[i]
+	}
+	var answers []struct {
+		RegistrationID string         `db:"registration_id"`
+		QuestionID     string         `db:"question_id"`
+		Answer         pq.StringArray `db:"answer"`
+	}
+	query = `select registration_id, question_id, answer from registration_answers
+		where registration_id = any($1::uuid[])`
+	if err = s.DB.SelectContext(ctx, &answers, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventRegistrations))
+		return nil, fmt.Errorf("%s: %w", opGetEventRegistrations, err)
+	}
+	for _, a := range answers {
+		reg := byID[a.RegistrationID]
+		reg.Answers = append(reg.Answers, models.Answer{QuestionID: a.QuestionID, Values: []string(a.Answer)})
+	}
+	return regs, nil
+}
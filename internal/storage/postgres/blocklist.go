@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Константы для описания операций
+const (
+	opGetBlockedChats = "postgres.getBlockedChats"
+	opBlockChat       = "postgres.blockChat"
+	opUnblockChat     = "postgres.unblockChat"
+	opCheckBlocked    = "postgres.checkBlocked"
+)
+
+// GetBlockedChats возвращает действующие на момент now блокировки в порядке их создания
+func (s *Storage) GetBlockedChats(ctx context.Context, now time.Time) ([]models.BlockedChat, error) {
+	chats := []models.BlockedChat{}
+	query := `select * from blocked_chats where blocked_until is null or blocked_until > $1 order by created_at`
+	if err := s.DB.SelectContext(ctx, &chats, query, now); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetBlockedChats))
+		return nil, fmt.Errorf("%s: %w", opGetBlockedChats, err)
+	}
+	return chats, nil
+}
+
+// BlockChat блокирует пользователя или заменяет причину и срок существующей блокировки
+func (s *Storage) BlockChat(ctx context.Context, b models.BlockedChat) (models.BlockedChat, error) {
+	query := `insert into blocked_chats (chat_id, reason, blocked_until, created_at) values ($1, $2, $3, $4)
+		on conflict (chat_id) do update set reason = excluded.reason, blocked_until = excluded.blocked_until
+		returning *`
+	var saved models.BlockedChat
+	if err := s.DB.GetContext(ctx, &saved, query, b.ChatID, b.Reason, b.BlockedUntil, time.Now()); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opBlockChat))
+		return models.BlockedChat{}, fmt.Errorf("%s: %w", opBlockChat, err)
+	}
+	return saved, nil
+}
+
+// UnblockChat снимает блокировку пользователя
+func (s *Storage) UnblockChat(ctx context.Context, chatID int64) error {
+	res, err := s.DB.ExecContext(ctx, `delete from blocked_chats where chat_id = $1`, chatID)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUnblockChat))
+		return fmt.Errorf("%s: %w", opUnblockChat, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opUnblockChat, storage.ErrChatNotBlocked)
+	}
+	return nil
+}
+
+// checkBlocked проверяет в транзакции регистрации, что пользователь не заблокирован на момент now
+func (s *Storage) checkBlocked(ctx context.Context, tx *sqlx.Tx, chatID int64, now time.Time) error {
+	var blocked bool
+	query := `select exists (select 1 from blocked_chats
+		where chat_id = $1 and (blocked_until is null or blocked_until > $2))`
+	if err := tx.GetContext(ctx, &blocked, query, chatID, now); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCheckBlocked))
+		return err
+	}
+	if blocked {
+		return storage.ErrChatBlocked
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opGetUpcomingEvents = "postgres.getUpcomingEvents"
+	opGetChatEvents     = "postgres.getChatEvents"
+	opGetFeedChatID     = "postgres.getFeedChatID"
+	opSaveFeedToken     = "postgres.saveFeedToken"
+)
+
+// GetUpcomingEvents возвращает публичные события, которые начинаются не раньше from, отсортированные по времени начала
+func (s *Storage) GetUpcomingEvents(ctx context.Context, from time.Time) ([]models.Event, error) {
+	var events []models.Event
+	err := s.DB.SelectContext(ctx, &events, `select * from events where starts_at >= $1 and visibility = 'public' order by starts_at`, from)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetUpcomingEvents))
+		return nil, fmt.Errorf("%s: %w", opGetUpcomingEvents, err)
+	}
+	if err = s.attachDetails(ctx, eventPtrs(events)...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetUpcomingEvents, err)
+	}
+	return events, nil
+}
+
+// GetChatEvents возвращает события, на которые зарегистрирован чат. Неоплаченные регистрации не учитываются
+func (s *Storage) GetChatEvents(ctx context.Context, chatID int64) ([]models.Event, error) {
+	var events []models.Event
+	query := `select e.* from events e
+		where exists (select 1 from registration r where r.event_id = e.id and r.chat_id = $1 and r.status = $2)
+		order by e.starts_at`
+	if err := s.DB.SelectContext(ctx, &events, query, chatID, models.RegistrationConfirmed); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetChatEvents))
+		return nil, fmt.Errorf("%s: %w", opGetChatEvents, err)
+	}
+	if err := s.attachDetails(ctx, eventPtrs(events)...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetChatEvents, err)
+	}
+	return events, nil
+}
+
+// GetFeedChatID возвращает чат, которому принадлежит календарная подписка с указанным хешем токена
+func (s *Storage) GetFeedChatID(ctx context.Context, tokenHash string) (int64, error) {
+	var chatID int64
+	err := s.DB.GetContext(ctx, &chatID, `select chat_id from calendar_feeds where token_hash = $1`, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", opGetFeedChatID, storage.ErrFeedNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetFeedChatID))
+		return 0, fmt.Errorf("%s: %w", opGetFeedChatID, err)
+	}
+	return chatID, nil
+}
+
+// SaveFeedToken сохраняет хеш токена календарной подписки чата, заменяя предыдущий
+func (s *Storage) SaveFeedToken(ctx context.Context, chatID int64, tokenHash string) error {
+	query := `insert into calendar_feeds (chat_id, token_hash, created_at) values ($1, $2, now())
+		on conflict (chat_id) do update set token_hash = excluded.token_hash, created_at = excluded.created_at`
+	if _, err := s.DB.ExecContext(ctx, query, chatID, tokenHash); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSaveFeedToken))
+		return fmt.Errorf("%s: %w", opSaveFeedToken, err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opGetChatRegistrations = "postgres.getChatRegistrations"
+	opCheckIn              = "postgres.checkIn"
+)
+
+// GetChatRegistrations возвращает подтверждённые регистрации чата на событие
+func (s *Storage) GetChatRegistrations(ctx context.Context, eventID string, chatID int64) ([]models.Registration, error) {
+	var regs []models.Registration
+	query := `select * from registration where event_id = $1 and chat_id = $2 and status = $3 order by created_at`
+	err := s.DB.SelectContext(ctx, &regs, query, eventID, chatID, models.RegistrationConfirmed)
+	if isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opGetChatRegistrations, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetChatRegistrations))
+		return nil, fmt.Errorf("%s: %w", opGetChatRegistrations, err)
+	}
+	if err = s.attachUsers(ctx, s.DB, registrationPtrs(regs)...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetChatRegistrations, err)
+	}
+	return regs, nil
+}
+
+// CheckIn отмечает проход по билету регистрации на событие. Отметка ставится только один раз
+// и только для подтверждённой регистрации, проход сохраняется в истории посещений. При повторном проходе возвращает регистрацию
+// вместе с storage.ErrAlreadyCheckedIn
+func (s *Storage) CheckIn(ctx context.Context, registrationID, eventID string, now time.Time) (reg models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCheckIn))
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `select * from registration where id = $1 and event_id = $2 for update`
+	err = tx.GetContext(ctx, &reg, query, registrationID, eventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, storage.ErrRegistrationNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCheckIn))
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	if reg.Status != models.RegistrationConfirmed {
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, storage.ErrRegistrationNotConfirmed)
+	}
+	if reg.CheckedInAt != nil {
+		if err = s.attachUsers(ctx, tx, &reg); err != nil {
+			return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+		}
+		return reg, fmt.Errorf("%s: %w", opCheckIn, storage.ErrAlreadyCheckedIn)
+	}
+
+	err = tx.GetContext(ctx, &reg, `update registration set checked_in_at = $2 where id = $1 returning *`, reg.ID, now)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCheckIn))
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	// Опоздавший пользователь мог быть уже отмечен как не пришедший
+	query = `insert into attendance (registration_id, event_id, session_id, chat_id, status, recorded_at)
+		values ($1, $2, $3, $4, $5, $6)
+		on conflict (registration_id) do update set status = excluded.status, recorded_at = excluded.recorded_at`
+	_, err = tx.ExecContext(ctx, query, reg.ID, reg.EventID, reg.SessionID, reg.ChatID, models.AttendanceAttended, now)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCheckIn))
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	if err = s.attachUsers(ctx, tx, &reg); err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCheckIn))
+		return models.Registration{}, fmt.Errorf("%s: %w", opCheckIn, err)
+	}
+	return reg, nil
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opCreateDeepLink      = "postgres.createDeepLink"
+	opGetEventDeepLinks   = "postgres.getEventDeepLinks"
+	opRecordDeepLinkClick = "postgres.recordDeepLinkClick"
+)
+
+// deepLinksQuery выбирает ссылки на бота вместе со статистикой открытий и регистраций
+const deepLinksQuery = `select l.*,
+		(select count(*) from deep_link_clicks c where c.link_id = l.id) as clicks,
+		(select count(distinct c.chat_id) + count(*) filter (where c.chat_id is null)
+			from deep_link_clicks c where c.link_id = l.id) as visitors,
+		(select count(*) from registration r where r.link_id = l.id and r.status <> 'rejected') as registrations
+	from deep_links l`
+
+// CreateDeepLink создаёт ссылку на бота для события
+func (s *Storage) CreateDeepLink(ctx context.Context, eventID, campaign, source string) (models.DeepLink, error) {
+	query := `insert into deep_links (event_id, campaign, source) values ($1, $2, $3) returning *`
+	var created models.DeepLink
+	err := s.DB.GetContext(ctx, &created, query, eventID, campaign, source)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opCreateDeepLink, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateDeepLink))
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opCreateDeepLink, err)
+	}
+	return created, nil
+}
+
+// GetEventDeepLinks возвращает ссылки на событие со статистикой в порядке создания
+func (s *Storage) GetEventDeepLinks(ctx context.Context, eventID string) ([]models.DeepLink, error) {
+	var exists bool
+	err := s.DB.GetContext(ctx, &exists, `select exists (select 1 from events where id = $1)`, eventID)
+	if isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opGetEventDeepLinks, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventDeepLinks))
+		return nil, fmt.Errorf("%s: %w", opGetEventDeepLinks, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", opGetEventDeepLinks, storage.ErrEventNotFound)
+	}
+
+	links := []models.DeepLink{}
+	query := deepLinksQuery + ` where l.event_id = $1 order by l.created_at, l.id`
+	if err = s.DB.SelectContext(ctx, &links, query, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventDeepLinks))
+		return nil, fmt.Errorf("%s: %w", opGetEventDeepLinks, err)
+	}
+	return links, nil
+}
+
+// RecordDeepLinkClick учитывает открытие ссылки и возвращает её. chatID - пользователь, открывший ссылку,
+// nil - неизвестен
+func (s *Storage) RecordDeepLinkClick(ctx context.Context, linkID int64, chatID *int64, at time.Time) (models.DeepLink, error) {
+	query := `with link as (select * from deep_links where id = $1),
+		click as (insert into deep_link_clicks (link_id, chat_id, clicked_at) select id, $2, $3 from link)
+		select * from link`
+	var link models.DeepLink
+	err := s.DB.GetContext(ctx, &link, query, linkID, chatID, at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opRecordDeepLinkClick, storage.ErrDeepLinkNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRecordDeepLinkClick))
+		return models.DeepLink{}, fmt.Errorf("%s: %w", opRecordDeepLinkClick, err)
+	}
+	return link, nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Константы для описания операций
+const (
+	opSetEventMaxGuests = "postgres.setEventMaxGuests"
+	opUpdateGuests      = "postgres.updateGuests"
+)
+
+// SetEventMaxGuests задаёт, сколько гостей пользователь может привести на событие. Уже зарегистрированные
+// гости сохраняются, даже если их больше нового максимума
+func (s *Storage) SetEventMaxGuests(ctx context.Context, eventID string, maxGuests int) error {
+	res, err := s.DB.ExecContext(ctx, `update events set max_guests = $2 where id = $1`, eventID, maxGuests)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opSetEventMaxGuests, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventMaxGuests))
+		return fmt.Errorf("%s: %w", opSetEventMaxGuests, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opSetEventMaxGuests, storage.ErrEventNotFound)
+	}
+	return nil
+}
+
+// UpdateGuests изменяет количество гостей в регистрации пользователя на событие или на сессию события.
+// Регистрация сохраняется, поэтому пользователь не теряет своё место. При увеличении количества гостей
+// проверяется, что в сессии и в типе билета хватает мест на новых гостей
+func (s *Storage) UpdateGuests(ctx context.Context, eventID string, chatID int64, sessionID *string, guests int) (reg models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateGuests))
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `select r.* from registration r
+		where r.event_id = $1 and r.chat_id = $2 and r.session_id is not distinct from $3
+		for update`
+	err = tx.GetContext(ctx, &reg, query, eventID, chatID, sessionID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, storage.ErrRegistrationNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateGuests))
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+	if reg.Status != models.RegistrationConfirmed && reg.Status != models.RegistrationPendingApproval {
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, storage.ErrGuestsPaidTicket)
+	}
+
+	var maxGuests int
+	if err = tx.GetContext(ctx, &maxGuests, `select max_guests from events where id = $1`, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateGuests))
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+	if guests > reg.Guests && guests > maxGuests {
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, storage.ErrTooManyGuests)
+	}
+	// Заявка, ожидающая одобрения, мест не занимает: они проверяются при одобрении
+	if reg.Status == models.RegistrationConfirmed {
+		ticketType, err := s.reserveSeats(ctx, tx, opUpdateGuests, reg, guests-reg.Guests)
+		if err != nil {
+			return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+		}
+		// Гостей платной регистрации изменить нельзя: сумма платежа уже зафиксирована
+		if ticketType.PriceAmount > 0 {
+			return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, storage.ErrGuestsPaidTicket)
+		}
+	}
+
+	err = tx.GetContext(ctx, &reg, `update registration set guests = $2 where id = $1 returning *`, reg.ID, guests)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateGuests))
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+	if err = s.attachUsers(ctx, tx, &reg); err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateGuests))
+		return models.Registration{}, fmt.Errorf("%s: %w", opUpdateGuests, err)
+	}
+	return reg, nil
+}
+
+// reserveSeats блокирует сессию и тип билета регистрации до конца транзакции и проверяет, что в них
+// есть seats свободных мест. Возвращает тип билета регистрации
+func (s *Storage) reserveSeats(ctx context.Context, tx *sqlx.Tx, op string, reg models.Registration, seats int) (models.TicketType, error) {
+	if reg.SessionID != nil {
+		var session models.Session
+		query := sessionsQuery + ` where es.id = $1 for update of es`
+		if err := tx.GetContext(ctx, &session, query, *reg.SessionID); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", op))
+			return models.TicketType{}, err
+		}
+		if remaining := session.Remaining(); seats > 0 && remaining != nil && *remaining < seats {
+			return models.TicketType{}, storage.ErrSessionFull
+		}
+	}
+	var ticketType models.TicketType
+	if reg.TicketTypeID != nil {
+		query := ticketTypesQuery + ` where tt.id = $1 for update of tt`
+		if err := tx.GetContext(ctx, &ticketType, query, *reg.TicketTypeID); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", op))
+			return models.TicketType{}, err
+		}
+		if remaining := ticketType.Remaining(); seats > 0 && remaining != nil && *remaining < seats {
+			return models.TicketType{}, storage.ErrTicketTypeSoldOut
+		}
+	}
+	return ticketType, nil
+}
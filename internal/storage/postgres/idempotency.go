@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opClaimIdempotencyKey    = "postgres.claimIdempotencyKey"
+	opCompleteIdempotencyKey = "postgres.completeIdempotencyKey"
+	opReleaseIdempotencyKey  = "postgres.releaseIdempotencyKey"
+	opDeleteIdempotencyKeys  = "postgres.deleteExpiredIdempotencyKeys"
+)
+
+// ClaimIdempotencyKey занимает ключ идемпотентности для выполнения запроса. Ключ занимается, если его нет,
+// если он истёк или если он завис в выполнении с тем же запросом дольше, чем до staleBefore.
+// Возвращает запись ключа и true, если ключ занят этим вызовом, или существующую запись и false
+func (s *Storage) ClaimIdempotencyKey(ctx context.Context, k models.IdempotencyKey, staleBefore time.Time) (models.IdempotencyKey, bool, error) {
+	query := `insert into idempotency_keys (key, request_hash, created_at, expires_at) values ($1, $2, $3, $4)
+		on conflict (key) do update set
+			request_hash = excluded.request_hash, response = null,
+			created_at = excluded.created_at, expires_at = excluded.expires_at
+		where idempotency_keys.expires_at <= excluded.created_at
+			or (idempotency_keys.response is null and idempotency_keys.request_hash = excluded.request_hash
+				and idempotency_keys.created_at <= $5)
+		returning *`
+	var claimed models.IdempotencyKey
+	err := s.DB.GetContext(ctx, &claimed, query, k.Key, k.RequestHash, k.CreatedAt, k.ExpiresAt, staleBefore)
+	if err == nil {
+		return claimed, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		s.log.Error("error", err.Error(), slog.String("operation", opClaimIdempotencyKey))
+		return models.IdempotencyKey{}, false, fmt.Errorf("%s: %w", opClaimIdempotencyKey, err)
+	}
+
+	var existing models.IdempotencyKey
+	err = s.DB.GetContext(ctx, &existing, `select * from idempotency_keys where key = $1`, k.Key)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Ключ удалён между запросами: запрос с ним только что завершился ошибкой
+		return models.IdempotencyKey{}, false, fmt.Errorf("%s: %w", opClaimIdempotencyKey, storage.ErrIdempotencyKeyBusy)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opClaimIdempotencyKey))
+		return models.IdempotencyKey{}, false, fmt.Errorf("%s: %w", opClaimIdempotencyKey, err)
+	}
+	return existing, false, nil
+}
+
+// CompleteIdempotencyKey сохраняет ответ на запрос, выполненный с ключом идемпотентности
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key, requestHash string, response []byte) error {
+	query := `update idempotency_keys set response = $3 where key = $1 and request_hash = $2 and response is null`
+	if _, err := s.DB.ExecContext(ctx, query, key, requestHash, string(response)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCompleteIdempotencyKey))
+		return fmt.Errorf("%s: %w", opCompleteIdempotencyKey, err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey освобождает ключ идемпотентности запроса, завершившегося ошибкой,
+// чтобы повтор запроса выполнился заново
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, key, requestHash string) error {
+	query := `delete from idempotency_keys where key = $1 and request_hash = $2 and response is null`
+	if _, err := s.DB.ExecContext(ctx, query, key, requestHash); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opReleaseIdempotencyKey))
+		return fmt.Errorf("%s: %w", opReleaseIdempotencyKey, err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys удаляет истёкшие к моменту now ключи идемпотентности. Возвращает количество удалённых
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.DB.ExecContext(ctx, `delete from idempotency_keys where expires_at <= $1`, now)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opDeleteIdempotencyKeys))
+		return 0, fmt.Errorf("%s: %w", opDeleteIdempotencyKeys, err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
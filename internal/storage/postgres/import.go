@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+)
+
+// Константы для описания операций
+const (
+	opUpsertEvents = "postgres.upsertEvents"
+)
+
+// UpsertEvents вставляет или обновляет события по внешнему идентификатору в рамках одной транзакции.
+// Возвращает количество вставленных и обновлённых записей
+func (s *Storage) UpsertEvents(ctx context.Context, events []models.Event) (inserted, updated int, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpsertEvents))
+		return 0, 0, fmt.Errorf("%s: %w", opUpsertEvents, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// xmax = 0 только у только что вставленной строки, у обновлённой он равен id текущей транзакции
+	query := `insert into events (id, external_id, title, description, starts_at, time_zone)
+		values (gen_random_uuid(), $1, $2, $3, $4, $5)
+		on conflict (external_id) do update
+		set title = excluded.title, description = excluded.description,
+			starts_at = excluded.starts_at, time_zone = excluded.time_zone
+		returning (xmax = 0) as inserted`
+	for _, e := range events {
+		var isInserted bool
+		if err = tx.GetContext(ctx, &isInserted, query, e.ExternalID, e.Title, e.Description, e.StartsAt, e.TimeZone); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opUpsertEvents))
+			return 0, 0, fmt.Errorf("%s: %w", opUpsertEvents, err)
+		}
+		if isInserted {
+			inserted++
+		} else {
+			updated++
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpsertEvents))
+		return 0, 0, fmt.Errorf("%s: %w", opUpsertEvents, err)
+	}
+	return inserted, updated, nil
+}
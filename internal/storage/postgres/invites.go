@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Константы для описания операций
+const (
+	opSetEventVisibility  = "postgres.setEventVisibility"
+	opCreateInviteCode    = "postgres.createInviteCode"
+	opGetEventInviteCodes = "postgres.getEventInviteCodes"
+	opGetInviteCode       = "postgres.getInviteCode"
+	opRevokeInviteCode    = "postgres.revokeInviteCode"
+)
+
+// SetEventVisibility задаёт видимость события
+func (s *Storage) SetEventVisibility(ctx context.Context, eventID string, visibility models.Visibility) error {
+	res, err := s.DB.ExecContext(ctx, `update events set visibility = $2 where id = $1`, eventID, visibility)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opSetEventVisibility, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventVisibility))
+		return fmt.Errorf("%s: %w", opSetEventVisibility, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opSetEventVisibility, storage.ErrEventNotFound)
+	}
+	return nil
+}
+
+// CreateInviteCode сохраняет код приглашения на событие. Код должен быть уникальным
+func (s *Storage) CreateInviteCode(ctx context.Context, c models.InviteCode) (models.InviteCode, error) {
+	query := `insert into invite_codes (event_id, code, max_uses, expires_at) values ($1, $2, $3, $4) returning *`
+	var created models.InviteCode
+	err := s.DB.GetContext(ctx, &created, query, c.EventID, c.Code, c.MaxUses, c.ExpiresAt)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opCreateInviteCode, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateInviteCode))
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opCreateInviteCode, err)
+	}
+	return created, nil
+}
+
+// GetEventInviteCodes возвращает коды приглашения на событие в порядке создания, включая отозванные
+func (s *Storage) GetEventInviteCodes(ctx context.Context, eventID string) ([]models.InviteCode, error) {
+	var exists bool
+	err := s.DB.GetContext(ctx, &exists, `select exists (select 1 from events where id = $1)`, eventID)
+	if isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opGetEventInviteCodes, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventInviteCodes))
+		return nil, fmt.Errorf("%s: %w", opGetEventInviteCodes, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", opGetEventInviteCodes, storage.ErrEventNotFound)
+	}
+
+	codes := []models.InviteCode{}
+	query := `select * from invite_codes where event_id = $1 order by created_at`
+	if err = s.DB.SelectContext(ctx, &codes, query, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventInviteCodes))
+		return nil, fmt.Errorf("%s: %w", opGetEventInviteCodes, err)
+	}
+	return codes, nil
+}
+
+// GetInviteCode возвращает код приглашения по его значению
+func (s *Storage) GetInviteCode(ctx context.Context, code string) (models.InviteCode, error) {
+	var c models.InviteCode
+	err := s.DB.GetContext(ctx, &c, `select * from invite_codes where code = $1`, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opGetInviteCode, storage.ErrInviteCodeNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetInviteCode))
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opGetInviteCode, err)
+	}
+	return c, nil
+}
+
+// RevokeInviteCode отзывает код приглашения. Регистрации, выполненные по коду, сохраняются
+func (s *Storage) RevokeInviteCode(ctx context.Context, inviteID string, now time.Time) (models.InviteCode, error) {
+	query := `update invite_codes set revoked_at = coalesce(revoked_at, $2) where id = $1 returning *`
+	var revoked models.InviteCode
+	err := s.DB.GetContext(ctx, &revoked, query, inviteID, now)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opRevokeInviteCode, storage.ErrInviteCodeNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRevokeInviteCode))
+		return models.InviteCode{}, fmt.Errorf("%s: %w", opRevokeInviteCode, err)
+	}
+	return revoked, nil
+}
+
+// useInviteCode блокирует код приглашения до конца транзакции, проверяет, что он выдан на событие
+// и действует, и расходует одно использование. Возвращает ID кода
+func (s *Storage) useInviteCode(ctx context.Context, tx *sqlx.Tx, req models.RegistrationRequest, now time.Time) (*string, error) {
+	var c models.InviteCode
+	query := `select * from invite_codes where code = $1 and event_id = $2 for update`
+	err := tx.GetContext(ctx, &c, query, req.InviteCode, req.EventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return nil, storage.ErrInviteCodeInvalid
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return nil, err
+	}
+	if !c.Valid(now) {
+		return nil, storage.ErrInviteCodeInvalid
+	}
+	if _, err = tx.ExecContext(ctx, `update invite_codes set uses = uses + 1 where id = $1`, c.ID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return nil, err
+	}
+	return &c.ID, nil
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+alter table events add column if not exists external_id varchar;
+
+create unique index if not exists events_external_id_uindex on events (external_id);
+
+-- +goose Down
+drop index if exists events_external_id_uindex;
+
+alter table events drop column if exists external_id;
@@ -0,0 +1,39 @@
+-- +goose Up
+alter table events add column if not exists updated_at timestamptz not null default now();
+
+-- sequence увеличивается при каждом изменении времени или текста события, чтобы календари подхватывали обновления
+alter table events add column if not exists sequence int not null default 0;
+
+-- +goose StatementBegin
+create or replace function events_touch() returns trigger as $$
+begin
+    new.updated_at = now();
+    if new.starts_at is distinct from old.starts_at
+        or new.title is distinct from old.title
+        or new.description is distinct from old.description then
+        new.sequence = old.sequence + 1;
+    end if;
+    return new;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+create trigger events_touch before update on events
+    for each row execute function events_touch();
+
+create table if not exists calendar_feeds (
+    chat_id bigint primary key,
+    token_hash varchar not null unique,
+    created_at timestamptz not null default now()
+);
+
+-- +goose Down
+drop table if exists calendar_feeds;
+
+drop trigger if exists events_touch on events;
+
+drop function if exists events_touch();
+
+alter table events drop column if exists sequence;
+
+alter table events drop column if exists updated_at;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Существующие события заведены по московскому времени
+alter table events add column if not exists time_zone varchar not null default 'Europe/Moscow';
+
+alter table events alter column starts_at type timestamptz using starts_at at time zone time_zone;
+
+alter table events alter column time_zone drop default;
+
+-- created_at заполнялся сервисом, запущенным в UTC
+alter table registration alter column created_at type timestamptz using created_at at time zone 'UTC';
+
+-- +goose Down
+alter table registration alter column created_at type timestamp using created_at at time zone 'UTC';
+
+alter table events alter column starts_at type timestamp using starts_at at time zone time_zone;
+
+alter table events drop column if exists time_zone;
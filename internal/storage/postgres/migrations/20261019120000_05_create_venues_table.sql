@@ -0,0 +1,27 @@
+-- +goose Up
+create table if not exists venues (
+    id uuid primary key default gen_random_uuid(),
+    name varchar not null,
+    address varchar not null default '',
+    city varchar not null default '',
+    latitude double precision not null check (latitude between -90 and 90),
+    longitude double precision not null check (longitude between -180 and 180),
+    capacity int check (capacity > 0),
+    -- часовой пояс площадки в формате IANA, если отличается от пояса по умолчанию
+    time_zone varchar,
+    created_at timestamptz not null default now()
+);
+
+-- Индекс для предварительной фильтрации площадок по ограничивающему прямоугольнику при поиске рядом
+create index if not exists venues_coordinates_index on venues (latitude, longitude);
+
+alter table events add column if not exists venue_id uuid references venues(id) on delete set null;
+
+create index if not exists events_venue_id_index on events (venue_id);
+
+-- +goose Down
+drop index if exists events_venue_id_index;
+
+alter table events drop column if exists venue_id;
+
+drop table if exists venues;
@@ -0,0 +1,76 @@
+-- +goose Up
+create table if not exists categories (
+    id uuid primary key default gen_random_uuid(),
+    slug varchar not null unique,
+    name varchar not null,
+    -- position задаёт порядок категорий в меню бота
+    position int not null default 0
+);
+
+create table if not exists event_categories (
+    event_id uuid not null references events(id) on delete cascade,
+    category_id uuid not null references categories(id) on delete cascade,
+    primary key (event_id, category_id)
+);
+
+create index if not exists event_categories_category_id_index on event_categories (category_id);
+
+create table if not exists tags (
+    id uuid primary key default gen_random_uuid(),
+    name varchar not null unique
+);
+
+create table if not exists event_tags (
+    event_id uuid not null references events(id) on delete cascade,
+    tag_id uuid not null references tags(id) on delete cascade,
+    primary key (event_id, tag_id)
+);
+
+create index if not exists event_tags_tag_id_index on event_tags (tag_id);
+
+insert into categories (slug, name, position)
+values ('music', 'Музыка', 10),
+       ('lectures', 'Лекции', 20),
+       ('workshops', 'Мастер-классы', 30),
+       ('theatre', 'Театр и шоу', 40),
+       ('festivals', 'Фестивали', 50),
+       ('exhibitions', 'Выставки', 60),
+       ('wellness', 'Спорт и здоровье', 70),
+       ('food', 'Еда и напитки', 80)
+on conflict (slug) do nothing;
+
+-- Раскладываем по категориям события из начального наполнения
+insert into event_categories (event_id, category_id)
+select e.id, c.id
+from (values ('63dadad4-3361-4a2f-a829-95eba09f6bde', 'theatre'),
+             ('a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'music'),
+             ('b1c2d3e4-f5a6-7890-bcde-f1234567890a', 'wellness'),
+             ('c3d4e5f6-a7b8-4901-cdef-0123456789ab', 'workshops'),
+             ('d5e6f7a8-b9c0-4123-def0-123456789abc', 'festivals'),
+             ('e7f8a9b0-c1d2-4345-ef01-23456789abcd', 'lectures'),
+             ('f9a0b1c2-d3e4-4567-8901-23456789bcde', 'workshops'),
+             ('f9a0b1c2-d3e4-4567-8901-23456789bcde', 'food'),
+             ('0a1b2c3d-4e5f-6789-0123-456789abcdef', 'theatre'),
+             ('1b2c3d4e-5f60-7890-1234-56789abcdef0', 'lectures'),
+             ('2c3d4e5f-6071-8901-2345-6789abcdef01', 'music'),
+             ('2c3d4e5f-6071-8901-2345-6789abcdef01', 'festivals'),
+             ('3d4e5f60-7182-9012-3456-789abcdef012', 'workshops'),
+             ('4e5f6071-8293-a123-4567-89abcdef0123', 'exhibitions'),
+             ('5f607182-93a4-b234-5678-9abcdef01234', 'food'),
+             ('60718293-a4b5-c345-6789-abcdef012345', 'festivals'),
+             ('60718293-a4b5-c345-6789-abcdef012345', 'lectures'),
+             ('718293a4-b5c6-d456-789a-bcdef0123456', 'theatre'),
+             ('8293a4b5-c6d7-e567-89ab-cdef01234567', 'music'),
+             ('8293a4b5-c6d7-e567-89ab-cdef01234567', 'lectures')) as v (event_id, slug)
+join events e on e.id = v.event_id::uuid
+join categories c on c.slug = v.slug
+on conflict do nothing;
+
+-- +goose Down
+drop table if exists event_tags;
+
+drop table if exists tags;
+
+drop table if exists event_categories;
+
+drop table if exists categories;
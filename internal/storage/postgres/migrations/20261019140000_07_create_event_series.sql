@@ -0,0 +1,79 @@
+-- +goose Up
+create table if not exists event_series (
+    id uuid primary key default gen_random_uuid(),
+    title varchar not null,
+    description text not null default '',
+    time_zone varchar not null,
+    -- starts_at начало первого повторения, его местное время задаёт время всех повторений
+    starts_at timestamptz not null,
+    -- rrule правило повторения в формате RFC 5545 (например, FREQ=WEEKLY;BYDAY=TU,TH)
+    rrule varchar not null,
+    venue_id uuid references venues(id) on delete set null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+-- Исключения: местные даты, на которые повторение серии отменено
+create table if not exists event_series_exceptions (
+    series_id uuid not null references event_series(id) on delete cascade,
+    occurrence_date date not null,
+    primary key (series_id, occurrence_date)
+);
+
+-- Подписки на все повторения серии. Подписчики регистрируются на каждое новое повторение
+create table if not exists series_registrations (
+    series_id uuid not null references event_series(id) on delete cascade,
+    chat_id bigint not null,
+    username varchar,
+    created_at timestamptz not null default now(),
+    primary key (series_id, chat_id)
+);
+
+-- occurrence_date местная дата повторения, по ней повторение сопоставляется с правилом серии
+alter table events add column if not exists series_id uuid references event_series(id) on delete set null;
+alter table events add column if not exists occurrence_date date;
+
+create unique index if not exists events_series_occurrence_uindex on events (series_id, occurrence_date);
+
+-- Повторные регистрации пользователя на одно событие переносятся в архив перед добавлением ограничения:
+-- остаётся первая регистрация, остальные сохраняются в registration_duplicates для ручного разбора.
+-- Колонки перечислены явно: порядок колонок registration меняется последующими миграциями
+create table if not exists registration_duplicates (
+    id uuid primary key,
+    event_id uuid,
+    chat_id bigint,
+    username varchar,
+    created_at timestamptz,
+    archived_at timestamptz not null default now()
+);
+
+with moved as (
+    delete from registration a
+    where exists (
+        select 1 from registration b
+        where b.event_id = a.event_id and b.chat_id = a.chat_id and b.ctid < a.ctid
+    )
+    returning a.id, a.event_id, a.chat_id, a.username, a.created_at
+)
+insert into registration_duplicates (id, event_id, chat_id, username, created_at)
+select id, event_id, chat_id, username, created_at from moved;
+
+create unique index if not exists registration_event_chat_uindex on registration (event_id, chat_id);
+
+-- +goose Down
+drop index if exists registration_event_chat_uindex;
+
+insert into registration (id, event_id, chat_id, username, created_at)
+select id, event_id, chat_id, username, created_at from registration_duplicates;
+drop table if exists registration_duplicates;
+
+drop index if exists events_series_occurrence_uindex;
+
+alter table events drop column if exists occurrence_date;
+alter table events drop column if exists series_id;
+
+drop table if exists series_registrations;
+
+drop table if exists event_series_exceptions;
+
+drop table if exists event_series;
@@ -0,0 +1,39 @@
+-- +goose Up
+create table if not exists event_sessions (
+    id uuid primary key default gen_random_uuid(),
+    event_id uuid not null references events(id) on delete cascade,
+    title varchar not null default '',
+    starts_at timestamptz not null,
+    ends_at timestamptz not null,
+    -- capacity максимальное количество регистраций на сессию, null - без ограничения
+    capacity int check (capacity > 0),
+    created_at timestamptz not null default now(),
+    check (ends_at > starts_at)
+);
+
+create index if not exists event_sessions_event_id_index on event_sessions (event_id, starts_at);
+
+-- Сессию с регистрациями удалить нельзя
+alter table registration add column if not exists session_id uuid references event_sessions(id);
+
+-- На событие без сессий пользователь регистрируется один раз, на событие с сессиями - один раз на каждую сессию
+drop index if exists registration_event_chat_uindex;
+create unique index if not exists registration_event_chat_uindex on registration (event_id, chat_id) where session_id is null;
+create unique index if not exists registration_session_chat_uindex on registration (session_id, chat_id) where session_id is not null;
+
+-- Индекс для проверки пересечения сессий, на которые зарегистрирован пользователь
+create index if not exists registration_chat_id_index on registration (chat_id);
+
+-- +goose Down
+drop index if exists registration_chat_id_index;
+
+drop index if exists registration_session_chat_uindex;
+
+delete from registration where session_id is not null;
+
+drop index if exists registration_event_chat_uindex;
+create unique index if not exists registration_event_chat_uindex on registration (event_id, chat_id);
+
+alter table registration drop column if exists session_id;
+
+drop table if exists event_sessions;
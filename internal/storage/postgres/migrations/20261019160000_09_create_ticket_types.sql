@@ -0,0 +1,31 @@
+-- +goose Up
+create table if not exists ticket_types (
+    id uuid primary key default gen_random_uuid(),
+    event_id uuid not null references events(id) on delete cascade,
+    name varchar not null,
+    description text not null default '',
+    -- price_amount цена в минимальных единицах валюты (копейках), 0 - бесплатный билет
+    price_amount bigint not null default 0 check (price_amount >= 0),
+    currency varchar(3) not null default 'RUB',
+    -- capacity количество билетов этого типа, null - без ограничения
+    capacity int check (capacity > 0),
+    -- окно продаж, null - без ограничения с соответствующей стороны
+    sales_start_at timestamptz,
+    sales_end_at timestamptz,
+    position int not null default 0,
+    created_at timestamptz not null default now(),
+    unique (event_id, name),
+    check (sales_start_at is null or sales_end_at is null or sales_end_at > sales_start_at)
+);
+
+-- Тип билета с регистрациями удалить нельзя
+alter table registration add column if not exists ticket_type_id uuid references ticket_types(id);
+
+create index if not exists registration_ticket_type_id_index on registration (ticket_type_id);
+
+-- +goose Down
+drop index if exists registration_ticket_type_id_index;
+
+alter table registration drop column if exists ticket_type_id;
+
+drop table if exists ticket_types;
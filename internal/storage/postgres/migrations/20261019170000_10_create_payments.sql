@@ -0,0 +1,38 @@
+-- +goose Up
+-- status: confirmed - регистрация действует, pending_payment - место удерживается до оплаты или до expires_at
+alter table registration add column if not exists status varchar not null default 'confirmed';
+alter table registration add column if not exists expires_at timestamptz;
+
+create index if not exists registration_pending_expires_at_index on registration (expires_at) where status = 'pending_payment';
+
+create table if not exists payments (
+    id uuid primary key default gen_random_uuid(),
+    -- registration_id обнуляется, когда неоплаченная регистрация истекает или отменяется
+    registration_id uuid references registration(id) on delete set null,
+    event_id uuid not null references events(id) on delete cascade,
+    chat_id bigint not null,
+    provider varchar not null,
+    provider_payment_id varchar not null,
+    -- amount сумма в минимальных единицах валюты (копейках)
+    amount bigint not null check (amount > 0),
+    currency varchar(3) not null,
+    -- status: pending, succeeded, failed, expired
+    status varchar not null default 'pending',
+    confirmation_url text not null default '',
+    expires_at timestamptz not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    unique (provider, provider_payment_id)
+);
+
+create index if not exists payments_registration_id_index on payments (registration_id);
+
+-- +goose Down
+drop table if exists payments;
+
+drop index if exists registration_pending_expires_at_index;
+
+delete from registration where status <> 'confirmed';
+
+alter table registration drop column if exists expires_at;
+alter table registration drop column if exists status;
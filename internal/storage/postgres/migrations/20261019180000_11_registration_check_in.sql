@@ -0,0 +1,6 @@
+-- +goose Up
+-- checked_in_at момент прохода по билету, null - пользователь ещё не пришёл
+alter table registration add column if not exists checked_in_at timestamptz;
+
+-- +goose Down
+alter table registration drop column if exists checked_in_at;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- История посещений пользователей. Записи сохраняются, даже если регистрация позже удалена
+create table if not exists attendance (
+    id uuid primary key default gen_random_uuid(),
+    registration_id uuid unique references registration(id) on delete set null,
+    event_id uuid not null references events(id) on delete cascade,
+    session_id uuid references event_sessions(id) on delete set null,
+    chat_id bigint not null,
+    -- status: attended - пользователь прошёл по билету, no_show - не пришёл
+    status varchar not null,
+    recorded_at timestamptz not null default now()
+);
+
+create index if not exists attendance_chat_id_index on attendance (chat_id, recorded_at);
+create index if not exists attendance_event_id_index on attendance (event_id);
+
+insert into attendance (registration_id, event_id, session_id, chat_id, status, recorded_at)
+select id, event_id, session_id, chat_id, 'attended', checked_in_at from registration where checked_in_at is not null
+on conflict (registration_id) do nothing;
+
+-- +goose Down
+drop table if exists attendance;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- guests количество гостей, которых пользователь приводит с собой. Каждый гость занимает место
+alter table registration add column if not exists guests int not null default 0 check (guests >= 0);
+
+-- max_guests сколько гостей можно привести на событие, 0 - только сам пользователь
+alter table events add column if not exists max_guests int not null default 0 check (max_guests >= 0);
+
+-- +goose Down
+alter table events drop column if exists max_guests;
+
+alter table registration drop column if exists guests;
@@ -0,0 +1,31 @@
+-- +goose Up
+create table if not exists event_questions (
+    id uuid primary key default gen_random_uuid(),
+    event_id uuid not null references events(id) on delete cascade,
+    -- type тип ответа: text, number, boolean, single_choice или multiple_choice
+    type varchar not null check (type in ('text', 'number', 'boolean', 'single_choice', 'multiple_choice')),
+    label text not null,
+    -- options варианты ответа для вопросов с выбором
+    options text[] not null default '{}',
+    required boolean not null default false,
+    position int not null default 0,
+    created_at timestamptz not null default now()
+);
+
+create index if not exists event_questions_event_id_index on event_questions (event_id);
+
+-- Вопрос с ответами удалить нельзя, ответы удаляются вместе с регистрацией
+create table if not exists registration_answers (
+    registration_id uuid not null references registration(id) on delete cascade,
+    question_id uuid not null references event_questions(id),
+    -- answer выбранные варианты, для остальных типов - одно значение
+    answer text[] not null,
+    primary key (registration_id, question_id)
+);
+
+create index if not exists registration_answers_question_id_index on registration_answers (question_id);
+
+-- +goose Down
+drop table if exists registration_answers;
+
+drop table if exists event_questions;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- requires_approval регистрации на событие подтверждает организатор
+alter table events add column if not exists requires_approval boolean not null default false;
+
+-- status: pending_approval - заявка ожидает решения организатора и не занимает место, rejected - заявка отклонена.
+-- decision_reason и decided_at - причина и момент решения по заявке
+alter table registration add column if not exists decision_reason text;
+alter table registration add column if not exists decided_at timestamptz;
+
+create index if not exists registration_pending_approval_index on registration (event_id) where status = 'pending_approval';
+
+-- +goose Down
+drop index if exists registration_pending_approval_index;
+
+delete from registration where status in ('pending_approval', 'rejected');
+
+alter table registration drop column if exists decided_at;
+alter table registration drop column if exists decision_reason;
+
+alter table events drop column if exists requires_approval;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Окно регистрации: registration_opens_at - момент открытия, registration_closes_at - момент закрытия,
+-- registration_closes_before - за сколько минут до начала события закрывается регистрация. null - без ограничения
+alter table events add column if not exists registration_opens_at timestamptz;
+alter table events add column if not exists registration_closes_at timestamptz;
+alter table events add column if not exists registration_closes_before int check (registration_closes_before >= 0);
+
+-- registration_open_announced_at момент публикации сообщения об открытии регистрации
+alter table events add column if not exists registration_open_announced_at timestamptz;
+
+create index if not exists events_registration_opens_at_index on events (registration_opens_at)
+    where registration_open_announced_at is null;
+
+-- +goose Down
+drop index if exists events_registration_opens_at_index;
+
+alter table events drop column if exists registration_open_announced_at;
+alter table events drop column if exists registration_closes_before;
+alter table events drop column if exists registration_closes_at;
+alter table events drop column if exists registration_opens_at;
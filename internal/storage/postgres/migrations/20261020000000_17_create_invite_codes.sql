@@ -0,0 +1,28 @@
+-- +goose Up
+-- visibility: public - событие в списке, unlisted - только по ID, private - только по коду приглашения
+alter table events add column if not exists visibility varchar not null default 'public'
+    check (visibility in ('public', 'unlisted', 'private'));
+
+create table if not exists invite_codes (
+    id uuid primary key default gen_random_uuid(),
+    event_id uuid not null references events(id) on delete cascade,
+    code varchar not null unique,
+    -- max_uses сколько регистраций можно выполнить по коду, null - без ограничения
+    max_uses int check (max_uses > 0),
+    uses int not null default 0,
+    expires_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+create index if not exists invite_codes_event_id_index on invite_codes (event_id);
+
+-- invite_code_id код приглашения, по которому выполнена регистрация
+alter table registration add column if not exists invite_code_id uuid references invite_codes(id) on delete set null;
+
+-- +goose Down
+alter table registration drop column if exists invite_code_id;
+
+drop table if exists invite_codes;
+
+alter table events drop column if exists visibility;
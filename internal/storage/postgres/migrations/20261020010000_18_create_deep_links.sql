@@ -0,0 +1,35 @@
+-- +goose Up
+-- Ссылки на бота, открывающие событие. Короткий числовой id входит в подписанный токен ссылки
+create table if not exists deep_links (
+    id bigint generated always as identity primary key,
+    event_id uuid not null references events(id) on delete cascade,
+    -- campaign и source помечают, где опубликована ссылка
+    campaign varchar not null default '',
+    source varchar not null default '',
+    created_at timestamptz not null default now()
+);
+
+create index if not exists deep_links_event_id_index on deep_links (event_id);
+
+-- Открытия ссылок. chat_id не известен, если бот не передал его при открытии
+create table if not exists deep_link_clicks (
+    link_id bigint not null references deep_links(id) on delete cascade,
+    chat_id bigint,
+    clicked_at timestamptz not null default now()
+);
+
+create index if not exists deep_link_clicks_link_id_index on deep_link_clicks (link_id);
+
+-- link_id ссылка, по которой пользователь пришёл к регистрации
+alter table registration add column if not exists link_id bigint references deep_links(id) on delete set null;
+
+create index if not exists registration_link_id_index on registration (link_id) where link_id is not null;
+
+-- +goose Down
+drop index if exists registration_link_id_index;
+
+alter table registration drop column if exists link_id;
+
+drop table if exists deep_link_clicks;
+
+drop table if exists deep_links;
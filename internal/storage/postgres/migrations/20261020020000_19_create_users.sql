@@ -0,0 +1,43 @@
+-- +goose Up
+-- Профили пользователей Telegram. Пустая строка - значение неизвестно (например, у пользователя нет username)
+create table if not exists users (
+    chat_id bigint primary key,
+    username varchar not null default '',
+    first_name varchar not null default '',
+    last_name varchar not null default '',
+    -- language_code код языка интерфейса Telegram (IETF, например ru или en)
+    language_code varchar not null default '',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+-- Переносим последний известный username каждого пользователя из регистраций и подписок на серии
+insert into users (chat_id, username, created_at, updated_at)
+select distinct on (chat_id) chat_id, coalesce(username, ''),
+    coalesce(min(created_at) over (partition by chat_id), now()),
+    coalesce(max(created_at) over (partition by chat_id), now())
+from (
+    select chat_id, username, created_at from registration where chat_id is not null
+    union all
+    select chat_id, username, created_at from series_registrations
+) seen
+order by chat_id, created_at desc nulls last
+on conflict (chat_id) do nothing;
+
+alter table registration add constraint registration_chat_id_fkey foreign key (chat_id) references users(chat_id);
+alter table series_registrations add constraint series_registrations_chat_id_fkey foreign key (chat_id) references users(chat_id);
+
+alter table registration drop column if exists username;
+alter table series_registrations drop column if exists username;
+
+-- +goose Down
+alter table series_registrations add column if not exists username varchar;
+alter table registration add column if not exists username varchar;
+
+update registration r set username = nullif(u.username, '') from users u where u.chat_id = r.chat_id;
+update series_registrations sr set username = nullif(u.username, '') from users u where u.chat_id = sr.chat_id;
+
+alter table series_registrations drop constraint if exists series_registrations_chat_id_fkey;
+alter table registration drop constraint if exists registration_chat_id_fkey;
+
+drop table if exists users;
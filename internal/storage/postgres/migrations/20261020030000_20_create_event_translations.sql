@@ -0,0 +1,13 @@
+-- +goose Up
+-- Переводы текстов событий. language - код языка IETF в нижнем регистре (например, en или pt-br)
+create table if not exists event_translations (
+    event_id uuid not null references events(id) on delete cascade,
+    language varchar not null,
+    title varchar not null,
+    description text not null default '',
+    updated_at timestamptz not null default now(),
+    primary key (event_id, language)
+);
+
+-- +goose Down
+drop table if exists event_translations;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Пользователи, которым запрещена регистрация. blocked_until null - бессрочная блокировка
+create table if not exists blocked_chats (
+    chat_id bigint primary key,
+    reason varchar not null default '',
+    blocked_until timestamptz,
+    created_at timestamptz not null default now()
+);
+
+-- +goose Down
+drop table if exists blocked_chats;
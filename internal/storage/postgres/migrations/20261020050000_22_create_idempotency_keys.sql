@@ -0,0 +1,15 @@
+-- +goose Up
+-- Ключи идемпотентности запросов на регистрацию. response null - запрос ещё выполняется
+create table if not exists idempotency_keys (
+    key varchar primary key,
+    -- request_hash хеш запроса, с которым впервые передан ключ
+    request_hash varchar not null,
+    response jsonb,
+    created_at timestamptz not null default now(),
+    expires_at timestamptz not null
+);
+
+create index if not exists idempotency_keys_expires_at_idx on idempotency_keys (expires_at);
+
+-- +goose Down
+drop table if exists idempotency_keys;
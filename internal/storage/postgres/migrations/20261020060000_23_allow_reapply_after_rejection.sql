@@ -0,0 +1,22 @@
+-- +goose Up
+-- Отклонённая заявка не занимает регистрацию: пользователь может подать заявку на событие или сессию повторно
+drop index if exists registration_event_chat_uindex;
+create unique index if not exists registration_event_chat_uindex on registration (event_id, chat_id)
+    where session_id is null and status <> 'rejected';
+drop index if exists registration_session_chat_uindex;
+create unique index if not exists registration_session_chat_uindex on registration (session_id, chat_id)
+    where session_id is not null and status <> 'rejected';
+
+-- +goose Down
+-- Остаётся одна регистрация пользователя на событие или сессию: действующая, а если её нет - последняя отклонённая
+delete from registration r
+where r.status = 'rejected' and exists (
+    select 1 from registration o
+    where o.id <> r.id and o.event_id = r.event_id and o.chat_id = r.chat_id
+        and o.session_id is not distinct from r.session_id
+        and (o.status <> 'rejected' or (o.created_at, o.id) > (r.created_at, r.id)));
+
+drop index if exists registration_session_chat_uindex;
+create unique index if not exists registration_session_chat_uindex on registration (session_id, chat_id) where session_id is not null;
+drop index if exists registration_event_chat_uindex;
+create unique index if not exists registration_event_chat_uindex on registration (event_id, chat_id) where session_id is null;
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opCreatePayment  = "postgres.createPayment"
+	opCancelPending  = "postgres.cancelPendingRegistration"
+	opConfirmPayment = "postgres.confirmPayment"
+	opFailPayment    = "postgres.failPayment"
+	opExpirePending  = "postgres.expirePendingRegistrations"
+)
+
+// CreatePayment сохраняет платёж, созданный у провайдера
+func (s *Storage) CreatePayment(ctx context.Context, p models.Payment) (models.Payment, error) {
+	query := `insert into payments
+		(registration_id, event_id, chat_id, provider, provider_payment_id, amount, currency, confirmation_url, expires_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9) returning *`
+	var created models.Payment
+	err := s.DB.GetContext(ctx, &created, query, p.RegistrationID, p.EventID, p.ChatID, p.Provider, p.ProviderPaymentID,
+		p.Amount, p.Currency, p.ConfirmationURL, p.ExpiresAt)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreatePayment))
+		return models.Payment{}, fmt.Errorf("%s: %w", opCreatePayment, err)
+	}
+	return created, nil
+}
+
+// CancelPendingRegistration удаляет регистрацию, ожидающую оплаты, и освобождает место
+func (s *Storage) CancelPendingRegistration(ctx context.Context, registrationID string) error {
+	_, err := s.DB.ExecContext(ctx, `delete from registration where id = $1 and status = $2`,
+		registrationID, models.RegistrationPendingPayment)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCancelPending))
+		return fmt.Errorf("%s: %w", opCancelPending, err)
+	}
+	return nil
+}
+
+// ConfirmPayment отмечает платёж успешным и подтверждает оплаченную регистрацию. Возвращает подтверждённую
+// регистрацию и платёж. Если место уже освобождено по истечении срока оплаты, возвращает storage.ErrPaymentExpired
+func (s *Storage) ConfirmPayment(ctx context.Context, provider, providerPaymentID string, now time.Time) (reg models.Registration, p models.Payment, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opConfirmPayment))
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	p, err = s.lockPayment(ctx, tx, opConfirmPayment, provider, providerPaymentID)
+	if err != nil {
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+	switch p.Status {
+	case models.PaymentExpired:
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, storage.ErrPaymentExpired)
+	case models.PaymentSucceeded, models.PaymentFailed:
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, storage.ErrPaymentProcessed)
+	}
+	if p.RegistrationID == nil {
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, storage.ErrPaymentExpired)
+	}
+
+	err = tx.GetContext(ctx, &reg, `select * from registration where id = $1 for update`, *p.RegistrationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, storage.ErrPaymentExpired)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opConfirmPayment))
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+	if reg.Status == models.RegistrationPendingPayment && reg.ExpiresAt != nil && !now.Before(*reg.ExpiresAt) {
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, storage.ErrPaymentExpired)
+	}
+
+	err = tx.GetContext(ctx, &reg, `update registration set status = $2, expires_at = null where id = $1 returning *`,
+		reg.ID, models.RegistrationConfirmed)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opConfirmPayment))
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+	err = tx.GetContext(ctx, &p, `update payments set status = $2, updated_at = $3 where id = $1 returning *`,
+		p.ID, models.PaymentSucceeded, now)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opConfirmPayment))
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+	if err = s.attachUsers(ctx, tx, &reg); err != nil {
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opConfirmPayment))
+		return models.Registration{}, models.Payment{}, fmt.Errorf("%s: %w", opConfirmPayment, err)
+	}
+	return reg, p, nil
+}
+
+// FailPayment отмечает платёж неуспешным и сразу освобождает место, удерживаемое за регистрацией
+func (s *Storage) FailPayment(ctx context.Context, provider, providerPaymentID string, now time.Time) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opFailPayment))
+		return fmt.Errorf("%s: %w", opFailPayment, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	p, err := s.lockPayment(ctx, tx, opFailPayment, provider, providerPaymentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opFailPayment, err)
+	}
+	if p.Status != models.PaymentPending {
+		return fmt.Errorf("%s: %w", opFailPayment, storage.ErrPaymentProcessed)
+	}
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`update payments set status = $2, updated_at = $3 where id = $1`, []any{p.ID, models.PaymentFailed, now}},
+		{`delete from registration where id = $1 and status = $2`, []any{p.RegistrationID, models.RegistrationPendingPayment}},
+	}
+	for _, st := range statements {
+		if _, err = tx.ExecContext(ctx, st.query, st.args...); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opFailPayment))
+			return fmt.Errorf("%s: %w", opFailPayment, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opFailPayment))
+		return fmt.Errorf("%s: %w", opFailPayment, err)
+	}
+	return nil
+}
+
+// ExpirePendingRegistrations удаляет регистрации, срок оплаты которых истёк к моменту now, и отмечает
+// их платежи истёкшими. Возвращает количество освобождённых мест
+func (s *Storage) ExpirePendingRegistrations(ctx context.Context, now time.Time) (expired int, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opExpirePending))
+		return 0, fmt.Errorf("%s: %w", opExpirePending, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Регистрации, которые сейчас подтверждаются, пропускаются и будут проверены при следующем запуске
+	var ids []string
+	query := `select id from registration where status = $1 and expires_at <= $2 for update skip locked`
+	if err = tx.SelectContext(ctx, &ids, query, models.RegistrationPendingPayment, now); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opExpirePending))
+		return 0, fmt.Errorf("%s: %w", opExpirePending, err)
+	}
+	if len(ids) == 0 {
+		return 0, tx.Commit()
+	}
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`update payments set status = $2, updated_at = $3 where registration_id = any($1::uuid[]) and status = $4`,
+			[]any{pq.Array(ids), models.PaymentExpired, now, models.PaymentPending}},
+		{`delete from registration where id = any($1::uuid[])`, []any{pq.Array(ids)}},
+	}
+	for _, st := range statements {
+		if _, err = tx.ExecContext(ctx, st.query, st.args...); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opExpirePending))
+			return 0, fmt.Errorf("%s: %w", opExpirePending, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opExpirePending))
+		return 0, fmt.Errorf("%s: %w", opExpirePending, err)
+	}
+	return len(ids), nil
+}
+
+// lockPayment блокирует платёж до конца транзакции
+func (s *Storage) lockPayment(ctx context.Context, tx *sqlx.Tx, op, provider, providerPaymentID string) (models.Payment, error) {
+	var p models.Payment
+	query := `select * from payments where provider = $1 and provider_payment_id = $2 for update`
+	err := tx.GetContext(ctx, &p, query, provider, providerPaymentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Payment{}, storage.ErrPaymentNotFound
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", op))
+		return models.Payment{}, err
+	}
+	return p, nil
+}
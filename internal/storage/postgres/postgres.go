@@ -2,15 +2,15 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
-	"time"
 
 	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
-	"github.com/Telegram-bot-for-register-on-events/shared-proto/pb/event"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/lib/pq"
 )
 
 // Константы для описания операций
@@ -19,7 +19,6 @@ const (
 	opCloseConnection = "postgres.closeConnection"
 	opGetEvents       = "postgres.getEvents"
 	opGetEvent        = "postgres.getEvent"
-	opRegister        = "postgres.register"
 )
 
 // Storage описывает слой взаимодействия с базой данных
@@ -55,52 +54,80 @@ func (s *Storage) Close() {
 	}
 }
 
-func (s *Storage) GetEvents(ctx context.Context) ([]*event.Event, error) {
-	var eventsDB []models.Event
-	err := s.DB.SelectContext(ctx, &eventsDB, `select * from events`)
+// GetEvents возвращает публичные события, подходящие под фильтр
+func (s *Storage) GetEvents(ctx context.Context, filter models.EventFilter) ([]models.Event, error) {
+	var events []models.Event
+	query := `select * from events e
+		where e.visibility = 'public'
+		and ($1 = '' or exists (
+			select 1 from event_categories ec join categories c on c.id = ec.category_id
+			where ec.event_id = e.id and c.slug = $1))
+		and (coalesce(cardinality($2::text[]), 0) = 0 or (
+			select count(*) from event_tags et join tags t on t.id = et.tag_id
+			where et.event_id = e.id and t.name = any($2::text[])) = cardinality($2::text[]))
+		order by e.starts_at`
+	err := s.DB.SelectContext(ctx, &events, query, filter.Category, pq.Array(filter.Tags))
 	if err != nil {
 		s.log.Error("error", err.Error(), slog.String("operation", opGetEvents))
 		return nil, fmt.Errorf("%s: %w", opGetEvents, err)
 	}
-	// Преобразуем DB-структуры в protobuf-структуры
-	events := make([]*event.Event, 0, len(eventsDB))
-	for _, e := range eventsDB {
-		events = append(events, convertingEventsStruct(e))
+	if err = s.attachDetails(ctx, eventPtrs(events)...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetEvents, err)
 	}
 	return events, nil
 }
 
-func (s *Storage) GetEvent(ctx context.Context, eventID string) (*event.Event, error) {
+func (s *Storage) GetEvent(ctx context.Context, eventID string) (models.Event, error) {
 	var e models.Event
 	err := s.DB.GetContext(ctx, &e, `select * from events where id = $1`, eventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Event{}, fmt.Errorf("%s: %w", opGetEvent, storage.ErrEventNotFound)
+	}
 	if err != nil {
 		s.log.Error("error", err.Error(), slog.String("operation", opGetEvent))
-		return nil, fmt.Errorf("%s: %w", opGetEvent, err)
+		return models.Event{}, fmt.Errorf("%s: %w", opGetEvent, err)
+	}
+	if err = s.attachDetails(ctx, &e); err != nil {
+		return models.Event{}, fmt.Errorf("%s: %w", opGetEvent, err)
 	}
-	return convertingEventsStruct(e), nil
+	return e, nil
 }
 
-func (s *Storage) RegisterUser(ctx context.Context, eventID string, chatID int64, username string) error {
-	query := `insert into registration (event_id, chat_id, username, created_at) values ($1, $2, $3, $4)`
-	reg := &models.Registration{
-		EventID:   eventID,
-		ChatID:    chatID,
-		Username:  username,
-		CreatedAt: time.Now(),
+// attachDetails заполняет связанные с событиями данные: площадки, категории, теги, сессии, типы билетов,
+// вопросы анкеты и переводы
+func (s *Storage) attachDetails(ctx context.Context, events ...*models.Event) error {
+	if err := s.attachVenues(ctx, events...); err != nil {
+		return err
 	}
-	_, err := s.DB.NamedExecContext(ctx, query, reg)
-	if err != nil {
-		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
-		return fmt.Errorf("%s: %w", opRegister, err)
+	if err := s.attachTaxonomy(ctx, events...); err != nil {
+		return err
+	}
+	if err := s.attachSessions(ctx, events...); err != nil {
+		return err
+	}
+	if err := s.attachTicketTypes(ctx, events...); err != nil {
+		return err
 	}
-	return nil
+	if err := s.attachQuestions(ctx, events...); err != nil {
+		return err
+	}
+	return s.attachTranslations(ctx, events...)
 }
 
-func convertingEventsStruct(eventDB models.Event) *event.Event {
-	return &event.Event{
-		Id:          eventDB.ID,
-		Title:       eventDB.Title,
-		Description: eventDB.Description,
-		StartsAt:    timestamppb.New(eventDB.StartsAt),
-	}
+// isInvalidInput проверяет, что ошибка вызвана значением неверного формата (например, невалидным UUID)
+func isInvalidInput(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "22P02"
+}
+
+// isForeignKeyViolation проверяет, что ошибка вызвана ссылкой на несуществующую запись
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// isUniqueViolation проверяет, что ошибка вызвана нарушением уникальности
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
 }
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opCreateQuestion    = "postgres.createQuestion"
+	opUpdateQuestion    = "postgres.updateQuestion"
+	opDeleteQuestion    = "postgres.deleteQuestion"
+	opGetEventQuestions = "postgres.getEventQuestions"
+	opAttachQuestions   = "postgres.attachQuestions"
+)
+
+// questionRow описывает строку таблицы event_questions. Варианты ответа хранятся в массиве Postgres
+type questionRow struct {
+	models.Question
+	Options pq.StringArray `db:"options"`
+}
+
+// toModel преобразует строку таблицы в доменную структуру
+func (r questionRow) toModel() models.Question {
+	q := r.Question
+	q.Options = []string(r.Options)
+	if q.Options == nil {
+		q.Options = []string{}
+	}
+	return q
+}
+
+// CreateQuestion создаёт вопрос анкеты события
+func (s *Storage) CreateQuestion(ctx context.Context, q models.Question) (models.Question, error) {
+	query := `insert into event_questions (event_id, type, label, options, required, position)
+		values ($1, $2, $3, $4, $5, $6) returning *`
+	var created questionRow
+	err := s.DB.GetContext(ctx, &created, query, q.EventID, q.Type, q.Label, pq.Array(q.Options), q.Required, q.Position)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.Question{}, fmt.Errorf("%s: %w", opCreateQuestion, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateQuestion))
+		return models.Question{}, fmt.Errorf("%s: %w", opCreateQuestion, err)
+	}
+	return created.toModel(), nil
+}
+
+// UpdateQuestion обновляет вопрос анкеты. Уже полученные ответы сохраняются
+func (s *Storage) UpdateQuestion(ctx context.Context, q models.Question) (models.Question, error) {
+	query := `update event_questions set type = $2, label = $3, options = $4, required = $5, position = $6
+		where id = $1 returning *`
+	var updated questionRow
+	err := s.DB.GetContext(ctx, &updated, query, q.ID, q.Type, q.Label, pq.Array(q.Options), q.Required, q.Position)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Question{}, fmt.Errorf("%s: %w", opUpdateQuestion, storage.ErrQuestionNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateQuestion))
+		return models.Question{}, fmt.Errorf("%s: %w", opUpdateQuestion, err)
+	}
+	return updated.toModel(), nil
+}
+
+// DeleteQuestion удаляет вопрос анкеты, если на него ещё никто не ответил
+func (s *Storage) DeleteQuestion(ctx context.Context, questionID string) error {
+	res, err := s.DB.ExecContext(ctx, `delete from event_questions where id = $1`, questionID)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opDeleteQuestion, storage.ErrQuestionNotFound)
+	}
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%s: %w", opDeleteQuestion, storage.ErrQuestionInUse)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opDeleteQuestion))
+		return fmt.Errorf("%s: %w", opDeleteQuestion, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opDeleteQuestion, storage.ErrQuestionNotFound)
+	}
+	return nil
+}
+
+// GetEventQuestions возвращает вопросы анкеты события в порядке показа
+func (s *Storage) GetEventQuestions(ctx context.Context, eventID string) ([]models.Question, error) {
+	var rows []questionRow
+	query := `select * from event_questions where event_id = $1 order by position, created_at`
+	err := s.DB.SelectContext(ctx, &rows, query, eventID)
+	if isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opGetEventQuestions, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventQuestions))
+		return nil, fmt.Errorf("%s: %w", opGetEventQuestions, err)
+	}
+	questions := make([]models.Question, 0, len(rows))
+	for _, row := range rows {
+		questions = append(questions, row.toModel())
+	}
+	return questions, nil
+}
+
+// attachQuestions загружает вопросы анкет событий одним запросом и заполняет поле Questions
+func (s *Storage) attachQuestions(ctx context.Context, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(events))
+	byID := make(map[string][]*models.Event, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+		byID[e.ID] = append(byID[e.ID], e)
+	}
+
+	var rows []questionRow
+	query := `select * from event_questions where event_id = any($1::uuid[]) order by position, created_at`
+	if err := s.DB.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachQuestions))
+		return fmt.Errorf("%s: %w", opAttachQuestions, err)
+	}
+	for _, row := range rows {
+		for _, e := range byID[row.EventID] {
+			e.Questions = append(e.Questions, row.toModel())
+		}
+	}
+	return nil
+}
+
+// insertAnswers сохраняет ответы на анкету в транзакции регистрации
+func insertAnswers(ctx context.Context, tx *sqlx.Tx, registrationID string, answers []models.Answer) error {
+	query := `insert into registration_answers (registration_id, question_id, answer) values ($1, $2, $3)`
+	for _, a := range answers {
+		if _, err := tx.ExecContext(ctx, query, registrationID, a.QuestionID, pq.Array(a.Values)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
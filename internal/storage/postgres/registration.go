@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Константы для описания операций
+const (
+	opRegister = "postgres.register"
+)
+
+// Пространства ключей pg_advisory_xact_lock. Ключ блокировки - хеш идентификатора внутри своего пространства,
+// поэтому блокировки пользователя и события с совпавшими ключами не мешают друг другу
+const (
+	lockChatRegistrations  = 1
+	lockEventRegistrations = 2
+)
+
+// RegisterUser регистрирует пользователя на событие. На событие с сессиями регистрация выполняется
+// на конкретную сессию с проверкой её вместимости и пересечения с другими сессиями пользователя,
+// на событие с типами билетов - с указанием типа билета, проверкой окна продаж и оставшихся билетов.
+// Регистрация с платным билетом создаётся в состоянии ожидания оплаты со сроком req.PaymentTTL,
+// на событие с одобрением организатора - в состоянии заявки, ожидающей решения.
+// Гости занимают места наравне с пользователем, ответы на анкету сохраняются вместе с регистрацией.
+// На сессию или тип билета с ограниченной вместимостью не регистрируются пользователи, у которых
+// с req.NoShowsSince накопилось req.MaxNoShows неявок. На закрытое событие регистрация выполняется
+// только по действующему коду приглашения, каждая регистрация расходует одно использование кода.
+// Профиль пользователя обновляется по данным запроса
+func (s *Storage) RegisterUser(ctx context.Context, req models.RegistrationRequest) (reg models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var options struct {
+		HasSessions      bool              `db:"has_sessions"`
+		HasTicketTypes   bool              `db:"has_ticket_types"`
+		MaxGuests        int               `db:"max_guests"`
+		RequiresApproval bool              `db:"requires_approval"`
+		Visibility       models.Visibility `db:"visibility"`
+	}
+	query := `select exists (select 1 from event_sessions where event_id = $1) as has_sessions,
+		exists (select 1 from ticket_types where event_id = $1) as has_ticket_types,
+		coalesce((select max_guests from events where id = $1), 0) as max_guests,
+		coalesce((select requires_approval from events where id = $1), false) as requires_approval,
+		coalesce((select visibility from events where id = $1), 'public') as visibility`
+	err = tx.GetContext(ctx, &options, query, req.EventID)
+	if isInvalidInput(err) {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+
+	if req.Guests > options.MaxGuests {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, storage.ErrTooManyGuests)
+	}
+
+	now := time.Now()
+	user, err := upsertUser(ctx, tx, req.User, now)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	var inviteCodeID *string
+	if options.Visibility == models.VisibilityPrivate {
+		if req.InviteCode == "" {
+			return models.Registration{}, fmt.Errorf("%s: %w", opRegister, storage.ErrInviteCodeRequired)
+		}
+		if inviteCodeID, err = s.useInviteCode(ctx, tx, req, now); err != nil {
+			return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+		}
+	}
+	var session models.Session
+	switch {
+	case req.SessionID == nil && options.HasSessions:
+		err = storage.ErrSessionRequired
+	case req.SessionID != nil:
+		session, err = s.checkSession(ctx, tx, req)
+	}
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	var ticketType models.TicketType
+	switch {
+	case req.TicketTypeID == nil && options.HasTicketTypes:
+		err = storage.ErrTicketTypeRequired
+	case req.TicketTypeID != nil:
+		ticketType, err = s.checkTicketType(ctx, tx, req, now)
+	}
+	if err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	limited := session.Capacity != nil || ticketType.Capacity != nil
+	if err = s.checkLimits(ctx, tx, req.EventID, req.User.ChatID, req.RegistrationLimits, limited, now); err != nil {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+
+	status, expiresAt := models.RegistrationConfirmed, (*time.Time)(nil)
+	switch {
+	case options.RequiresApproval:
+		// Оплата запрашивается после одобрения заявки
+		status = models.RegistrationPendingApproval
+	case ticketType.PriceAmount > 0:
+		deadline := now.Add(req.PaymentTTL)
+		status, expiresAt = models.RegistrationPendingPayment, &deadline
+	}
+
+	query = `insert into registration
+		(event_id, chat_id, created_at, session_id, ticket_type_id, status, expires_at, guests, invite_code_id, link_id)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9,
+			(select id from deep_links where id = $10 and event_id = $1)) returning *`
+	err = tx.GetContext(ctx, &reg, query, req.EventID, req.User.ChatID, now, req.SessionID, req.TicketTypeID,
+		status, expiresAt, req.Guests, inviteCodeID, req.LinkID)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, storage.ErrEventNotFound)
+	}
+	if isUniqueViolation(err) {
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, storage.ErrAlreadyRegistered)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	if err = insertAnswers(ctx, tx, reg.ID, req.Answers); err != nil {
+		// Вопрос мог быть удалён после проверки ответов
+		if isForeignKeyViolation(err) {
+			return models.Registration{}, fmt.Errorf("%s: %w", opRegister, storage.ErrQuestionNotFound)
+		}
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Registration{}, fmt.Errorf("%s: %w", opRegister, err)
+	}
+	reg.PriceAmount, reg.Currency = ticketType.PriceAmount*int64(1+req.Guests), ticketType.Currency
+	reg.User = user
+	return reg, nil
+}
+
+// checkLimits проверяет ограничения, общие для регистрации на событие и на повторения серии: пользователь
+// chatID не заблокирован, частота регистраций на событие eventID не превышена, а на события с ограниченной
+// вместимостью (limited) у пользователя не накопилось слишком много неявок
+func (s *Storage) checkLimits(ctx context.Context, tx *sqlx.Tx, eventID string, chatID int64, limits models.RegistrationLimits, limited bool, now time.Time) error {
+	if err := s.checkBlocked(ctx, tx, chatID, now); err != nil {
+		return err
+	}
+	if limits.EventRateLimit > 0 {
+		if err := s.checkEventRate(ctx, tx, eventID, limits); err != nil {
+			return err
+		}
+	}
+	if limited && limits.MaxNoShows > 0 {
+		return s.checkNoShows(ctx, tx, chatID, limits)
+	}
+	return nil
+}
+
+// isLimitError сообщает, что регистрация отклонена checkLimits, а не ошибкой хранилища
+func isLimitError(err error) bool {
+	return errors.Is(err, storage.ErrChatBlocked) || errors.Is(err, storage.ErrEventRateLimited) ||
+		errors.Is(err, storage.ErrTooManyNoShows)
+}
+
+// checkEventRate проверяет, что на событие за окно с момента limits.EventRateSince зарегистрировалось меньше
+// limits.EventRateLimit пользователей. Регистрации на событие выполняются последовательно до конца транзакции,
+// чтобы параллельные запросы не превысили лимит
+func (s *Storage) checkEventRate(ctx context.Context, tx *sqlx.Tx, eventID string, limits models.RegistrationLimits) error {
+	query := `select pg_advisory_xact_lock($1, hashtext($2))`
+	if _, err := tx.ExecContext(ctx, query, lockEventRegistrations, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return err
+	}
+	var count int
+	query = `select count(*) from registration where event_id = $1 and created_at > $2`
+	if err := tx.GetContext(ctx, &count, query, eventID, limits.EventRateSince); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return err
+	}
+	if count >= limits.EventRateLimit {
+		return storage.ErrEventRateLimited
+	}
+	return nil
+}
+
+// checkSession блокирует сессию до конца транзакции и проверяет, что на неё можно зарегистрироваться:
+// сессия относится к событию, в ней есть места для пользователя и гостей и она не пересекается с другими сессиями пользователя.
+// Возвращает сессию
+func (s *Storage) checkSession(ctx context.Context, tx *sqlx.Tx, req models.RegistrationRequest) (models.Session, error) {
+	// Регистрации одного пользователя выполняются последовательно, чтобы параллельные запросы
+	// на разные сессии не обошли проверку пересечения
+	query := `select pg_advisory_xact_lock($1, hashtext($2::text))`
+	if _, err := tx.ExecContext(ctx, query, lockChatRegistrations, req.User.ChatID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Session{}, err
+	}
+
+	var session models.Session
+	query = sessionsQuery + ` where es.id = $1 and es.event_id = $2 for update of es`
+	err := tx.GetContext(ctx, &session, query, *req.SessionID, req.EventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Session{}, storage.ErrSessionNotFound
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Session{}, err
+	}
+	if remaining := session.Remaining(); remaining != nil && *remaining < 1+req.Guests {
+		return models.Session{}, storage.ErrSessionFull
+	}
+
+	var overlaps bool
+	query = `select exists (
+		select 1 from registration r join event_sessions es on es.id = r.session_id
+		where r.chat_id = $1 and es.id <> $2 and es.starts_at < $4 and es.ends_at > $3 and r.status <> $5)`
+	err = tx.GetContext(ctx, &overlaps, query, req.User.ChatID, session.ID, session.StartsAt, session.EndsAt, models.RegistrationRejected)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.Session{}, err
+	}
+	if overlaps {
+		return models.Session{}, storage.ErrSessionOverlap
+	}
+	return session, nil
+}
+
+// checkTicketType блокирует тип билета до конца транзакции и проверяет, что он относится к событию,
+// продажи открыты и билетов хватает на пользователя и гостей. Возвращает тип билета
+func (s *Storage) checkTicketType(ctx context.Context, tx *sqlx.Tx, req models.RegistrationRequest, now time.Time) (models.TicketType, error) {
+	var ticketType models.TicketType
+	query := ticketTypesQuery + ` where tt.id = $1 and tt.event_id = $2 for update of tt`
+	err := tx.GetContext(ctx, &ticketType, query, *req.TicketTypeID, req.EventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.TicketType{}, storage.ErrTicketTypeNotFound
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return models.TicketType{}, err
+	}
+	if !ticketType.OnSale(now) {
+		return models.TicketType{}, storage.ErrTicketSalesClosed
+	}
+	if remaining := ticketType.Remaining(); remaining != nil && *remaining < 1+req.Guests {
+		return models.TicketType{}, storage.ErrTicketTypeSoldOut
+	}
+	return ticketType, nil
+}
+
+// checkNoShows проверяет, что у пользователя не накопилось limits.MaxNoShows неявок с момента limits.NoShowsSince
+func (s *Storage) checkNoShows(ctx context.Context, tx *sqlx.Tx, chatID int64, limits models.RegistrationLimits) error {
+	var noShows int
+	query := `select count(*) from attendance where chat_id = $1 and status = $2 and recorded_at >= $3`
+	if err := tx.GetContext(ctx, &noShows, query, chatID, models.AttendanceNoShow, limits.NoShowsSince); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegister))
+		return err
+	}
+	if noShows >= limits.MaxNoShows {
+		return storage.ErrTooManyNoShows
+	}
+	return nil
+}
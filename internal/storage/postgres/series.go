@@ -0,0 +1,387 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opCreateSeries          = "postgres.createSeries"
+	opUpdateSeries          = "postgres.updateSeries"
+	opGetSeries             = "postgres.getSeries"
+	opSyncSeriesOccurrences = "postgres.syncSeriesOccurrences"
+	opRegisterSeries        = "postgres.registerSeries"
+)
+
+// CreateSeries создаёт серию событий вместе с исключениями
+func (s *Storage) CreateSeries(ctx context.Context, sr models.Series) (created models.Series, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `insert into event_series (title, description, time_zone, starts_at, rrule, venue_id)
+		values ($1, $2, $3, $4, $5, $6) returning *`
+	err = tx.GetContext(ctx, &created, query, sr.Title, sr.Description, sr.TimeZone, sr.StartsAt, sr.RRule, sr.VenueID)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, storage.ErrVenueNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+	if err = setSeriesExceptions(ctx, tx, created.ID, sr.Exceptions); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opCreateSeries, err)
+	}
+	created.Exceptions = sr.Exceptions
+	return created, nil
+}
+
+// UpdateSeries обновляет серию событий и заменяет её исключения. Уже созданные повторения
+// не изменяются, их синхронизирует SyncSeriesOccurrences
+func (s *Storage) UpdateSeries(ctx context.Context, sr models.Series) (updated models.Series, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `update event_series
+		set title = $2, description = $3, time_zone = $4, starts_at = $5, rrule = $6, venue_id = $7, updated_at = now()
+		where id = $1 returning *`
+	err = tx.GetContext(ctx, &updated, query, sr.ID, sr.Title, sr.Description, sr.TimeZone, sr.StartsAt, sr.RRule, sr.VenueID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, storage.ErrSeriesNotFound)
+	}
+	if isForeignKeyViolation(err) {
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, storage.ErrVenueNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	if _, err = tx.ExecContext(ctx, `delete from event_series_exceptions where series_id = $1`, sr.ID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	if err = setSeriesExceptions(ctx, tx, sr.ID, sr.Exceptions); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateSeries))
+		return models.Series{}, fmt.Errorf("%s: %w", opUpdateSeries, err)
+	}
+	updated.Exceptions = sr.Exceptions
+	return updated, nil
+}
+
+// GetSeries возвращает все серии событий вместе с исключениями
+func (s *Storage) GetSeries(ctx context.Context) ([]models.Series, error) {
+	var series []models.Series
+	if err := s.DB.SelectContext(ctx, &series, `select * from event_series order by created_at`); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetSeries))
+		return nil, fmt.Errorf("%s: %w", opGetSeries, err)
+	}
+
+	var exceptions []struct {
+		SeriesID string `db:"series_id"`
+		Date     string `db:"occurrence_date"`
+	}
+	query := `select series_id, to_char(occurrence_date, 'YYYY-MM-DD') as occurrence_date
+		from event_series_exceptions order by occurrence_date`
+	if err := s.DB.SelectContext(ctx, &exceptions, query); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetSeries))
+		return nil, fmt.Errorf("%s: %w", opGetSeries, err)
+	}
+	byID := make(map[string]*models.Series, len(series))
+	for i := range series {
+		series[i].Exceptions = []string{}
+		byID[series[i].ID] = &series[i]
+	}
+	for _, e := range exceptions {
+		if sr, ok := byID[e.SeriesID]; ok {
+			sr.Exceptions = append(sr.Exceptions, e.Date)
+		}
+	}
+	return series, nil
+}
+
+// seriesRegistrable условие, при котором подписчик серии автоматически регистрируется на повторение e:
+// повторение не закрытое, не требует одобрения, у него нет сессий, типов билетов и обязательных вопросов анкеты.
+// Вместимость, оплата и лимит неявок в RegisterUser проверяются только для сессий и типов билетов, поэтому
+// на повторения, не подходящие под условие, пользователь регистрируется сам. Окно регистрации проверяется
+// отдельно в registrableOccurrences
+const seriesRegistrable = `e.visibility <> 'private' and not e.requires_approval
+	and not exists (select 1 from event_sessions es where es.event_id = e.id)
+	and not exists (select 1 from ticket_types tt where tt.event_id = e.id)
+	and not exists (select 1 from event_questions q where q.event_id = e.id and q.required)`
+
+// seriesOccurrence описывает повторение серии с окном регистрации
+type seriesOccurrence struct {
+	ID string `db:"id"`
+	models.RegistrationWindow
+}
+
+// registrableOccurrences возвращает идентификаторы повторений серии, которые начинаются не раньше at,
+// подходят под seriesRegistrable и регистрация на которые открыта в момент at. Повторения, регистрация
+// на которые ещё не открылась, подхватываются материализацией после открытия
+func registrableOccurrences(ctx context.Context, tx *sqlx.Tx, seriesID string, at time.Time) ([]string, error) {
+	var occurrences []seriesOccurrence
+	query := `select e.id, e.starts_at, e.registration_opens_at, e.registration_closes_at, e.registration_closes_before
+		from events e where e.series_id = $1 and e.starts_at >= $2 and ` + seriesRegistrable
+	if err := tx.SelectContext(ctx, &occurrences, query, seriesID, at); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(occurrences))
+	for _, o := range occurrences {
+		if o.State(at) == models.RegistrationOpen {
+			ids = append(ids, o.ID)
+		}
+	}
+	// checkEventRate блокирует повторения по одному, единый порядок исключает взаимоблокировку транзакций
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// registerOccurrence регистрирует пользователя chatID на повторение серии eventID, если это допускают
+// ограничения checkLimits. Возвращает созданную регистрацию и false, если пользователь уже зарегистрирован
+func (s *Storage) registerOccurrence(ctx context.Context, tx *sqlx.Tx, eventID string, chatID int64, limits models.RegistrationLimits, now time.Time) (models.Registration, bool, error) {
+	// У повторений из registrableOccurrences нет сессий и типов билетов, поэтому вместимость не ограничена
+	if err := s.checkLimits(ctx, tx, eventID, chatID, limits, false, now); err != nil {
+		return models.Registration{}, false, err
+	}
+	var regs []models.Registration
+	query := `insert into registration (event_id, chat_id, created_at) values ($1, $2, $3)
+		on conflict (event_id, chat_id) where session_id is null and status <> 'rejected' do nothing
+		returning *`
+	if err := tx.SelectContext(ctx, &regs, query, eventID, chatID, now); err != nil {
+		return models.Registration{}, false, err
+	}
+	if len(regs) == 0 {
+		return models.Registration{}, false, nil
+	}
+	return regs[0], true, nil
+}
+
+// SyncSeriesOccurrences приводит повторения серии, которые начинаются в промежутке [from, to), к списку
+// occurrences: создаёт недостающие, обновляет изменившиеся и удаляет лишние. Прошедшие повторения
+// не затрагиваются, а лишние повторения с регистрациями сохраняются. Подписчики серии регистрируются
+// на повторения из registrableOccurrences в момент from с теми же ограничениями limits, что и при
+// регистрации на событие: подписчик, не прошедший checkLimits, пропускается. Возвращает количество
+// созданных повторений и созданные регистрации подписчиков
+func (s *Storage) SyncSeriesOccurrences(ctx context.Context, seriesID string, occurrences []models.Event, limits models.RegistrationLimits, from, to time.Time) (created int, regs []models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Блокируем серию, чтобы фоновая материализация и изменение серии администратором не пересекались
+	var id string
+	err = tx.GetContext(ctx, &id, `select id from event_series where id = $1 for update`, seriesID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, storage.ErrSeriesNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+
+	// Обновляются только будущие повторения и только при фактическом изменении,
+	// чтобы не менять updated_at и sequence при каждом запуске материализации
+	query := `insert into events (id, title, description, starts_at, time_zone, venue_id, series_id, occurrence_date)
+		values (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7::date)
+		on conflict (series_id, occurrence_date) do update
+		set title = excluded.title, description = excluded.description, starts_at = excluded.starts_at,
+			time_zone = excluded.time_zone, venue_id = excluded.venue_id
+		where events.starts_at >= $8
+			and (events.title, events.description, events.starts_at, events.time_zone, events.venue_id)
+				is distinct from (excluded.title, excluded.description, excluded.starts_at, excluded.time_zone, excluded.venue_id)
+		returning (xmax = 0) as inserted`
+	dates := make([]string, 0, len(occurrences))
+	for _, e := range occurrences {
+		date := e.OccurrenceDate.Format(time.DateOnly)
+		dates = append(dates, date)
+
+		var inserted []bool
+		err = tx.SelectContext(ctx, &inserted, query, e.Title, e.Description, e.StartsAt, e.TimeZone, e.VenueID, seriesID, date, from)
+		if err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+			return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+		}
+		if len(inserted) == 1 && inserted[0] {
+			created++
+		}
+	}
+
+	query = `delete from events e
+		where e.series_id = $1 and e.starts_at >= $2 and e.starts_at < $3
+			and e.occurrence_date <> all($4::date[])
+			and not exists (select 1 from registration r where r.event_id = e.id)`
+	if _, err = tx.ExecContext(ctx, query, seriesID, from, to, pq.Array(dates)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+
+	ids, err := registrableOccurrences(ctx, tx, seriesID, from)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+	var subscribers []struct {
+		EventID string `db:"event_id"`
+		ChatID  int64  `db:"chat_id"`
+	}
+	query = `select e.id as event_id, sr.chat_id
+		from events e join series_registrations sr on sr.series_id = e.series_id
+		where e.id = any($1::uuid[])
+			and not exists (select 1 from registration r
+				where r.event_id = e.id and r.chat_id = sr.chat_id and r.session_id is null and r.status <> 'rejected')
+		order by e.id, sr.created_at`
+	if err = tx.SelectContext(ctx, &subscribers, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+	for _, sub := range subscribers {
+		reg, ok, err := s.registerOccurrence(ctx, tx, sub.EventID, sub.ChatID, limits, from)
+		if isLimitError(err) {
+			continue
+		}
+		if err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+			return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+		}
+		if ok {
+			regs = append(regs, reg)
+		}
+	}
+	if err = s.attachUsers(ctx, tx, registrationPtrs(regs)...); err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSyncSeriesOccurrences))
+		return 0, nil, fmt.Errorf("%s: %w", opSyncSeriesOccurrences, err)
+	}
+	return created, regs, nil
+}
+
+// RegisterSeries подписывает пользователя на серию, к которой относится событие, и регистрирует его
+// на все повторения серии из registrableOccurrences в момент from с теми же ограничениями limits, что и
+// при регистрации на событие. Если ограничения не пропускают регистрацию на само событие eventID, возвращается
+// их ошибка, остальные такие повторения пропускаются. Повторная подписка не считается ошибкой.
+// Профиль пользователя обновляется по данным запроса. Возвращает созданные регистрации:
+// повторения, на которые пользователь уже зарегистрирован, пропускаются
+func (s *Storage) RegisterSeries(ctx context.Context, eventID string, user models.UserProfile, limits models.RegistrationLimits, from time.Time) (regs []models.Registration, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var seriesID *string
+	err = tx.GetContext(ctx, &seriesID, `select series_id from events where id = $1 and visibility <> 'private'`, eventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	if seriesID == nil {
+		err = storage.ErrNotSeriesEvent
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	if err = s.checkBlocked(ctx, tx, user.ChatID, from); err != nil {
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	if _, err = upsertUser(ctx, tx, user, from); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+
+	query := `insert into series_registrations (series_id, chat_id) values ($1, $2) on conflict (series_id, chat_id) do nothing`
+	if _, err = tx.ExecContext(ctx, query, *seriesID, user.ChatID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	ids, err := registrableOccurrences(ctx, tx, *seriesID, from)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	for _, id := range ids {
+		reg, ok, err := s.registerOccurrence(ctx, tx, id, user.ChatID, limits, from)
+		if isLimitError(err) && !strings.EqualFold(id, eventID) {
+			continue
+		}
+		if isLimitError(err) {
+			return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+		}
+		if err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+			return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+		}
+		if ok {
+			regs = append(regs, reg)
+		}
+	}
+	if err = s.attachUsers(ctx, tx, registrationPtrs(regs)...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opRegisterSeries))
+		return nil, fmt.Errorf("%s: %w", opRegisterSeries, err)
+	}
+	return regs, nil
+}
+
+// setSeriesExceptions сохраняет исключения серии
+func setSeriesExceptions(ctx context.Context, tx *sqlx.Tx, seriesID string, dates []string) error {
+	query := `insert into event_series_exceptions (series_id, occurrence_date)
+		select $1::uuid, unnest($2::date[]) on conflict do nothing`
+	_, err := tx.ExecContext(ctx, query, seriesID, pq.Array(dates))
+	return err
+}
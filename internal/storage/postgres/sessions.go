@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opCreateSession  = "postgres.createSession"
+	opUpdateSession  = "postgres.updateSession"
+	opDeleteSession  = "postgres.deleteSession"
+	opAttachSessions = "postgres.attachSessions"
+)
+
+// sessionsQuery выбирает сессии вместе с количеством занятых мест: регистраций вместе с гостями.
+// Заявки, ожидающие одобрения, и отклонённые заявки мест не занимают
+const sessionsQuery = `select es.*,
+		(select coalesce(sum(1 + r.guests), 0) from registration r
+			where r.session_id = es.id and r.status in ('confirmed', 'pending_payment')) as registered
+	from event_sessions es`
+
+// CreateSession создаёт сессию события
+func (s *Storage) CreateSession(ctx context.Context, session models.Session) (models.Session, error) {
+	query := `insert into event_sessions (event_id, title, starts_at, ends_at, capacity)
+		values ($1, $2, $3, $4, $5) returning *, 0 as registered`
+	var created models.Session
+	err := s.DB.GetContext(ctx, &created, query, session.EventID, session.Title, session.StartsAt, session.EndsAt, session.Capacity)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.Session{}, fmt.Errorf("%s: %w", opCreateSession, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateSession))
+		return models.Session{}, fmt.Errorf("%s: %w", opCreateSession, err)
+	}
+	return created, nil
+}
+
+// UpdateSession обновляет время, название и вместимость сессии
+func (s *Storage) UpdateSession(ctx context.Context, session models.Session) (models.Session, error) {
+	query := `update event_sessions es set title = $2, starts_at = $3, ends_at = $4, capacity = $5
+		where es.id = $1
+		returning es.*, (select coalesce(sum(1 + r.guests), 0) from registration r
+			where r.session_id = es.id and r.status in ('confirmed', 'pending_payment')) as registered`
+	var updated models.Session
+	err := s.DB.GetContext(ctx, &updated, query, session.ID, session.Title, session.StartsAt, session.EndsAt, session.Capacity)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Session{}, fmt.Errorf("%s: %w", opUpdateSession, storage.ErrSessionNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateSession))
+		return models.Session{}, fmt.Errorf("%s: %w", opUpdateSession, err)
+	}
+	return updated, nil
+}
+
+// DeleteSession удаляет сессию, если на неё никто не зарегистрирован
+func (s *Storage) DeleteSession(ctx context.Context, sessionID string) error {
+	res, err := s.DB.ExecContext(ctx, `delete from event_sessions where id = $1`, sessionID)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opDeleteSession, storage.ErrSessionNotFound)
+	}
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%s: %w", opDeleteSession, storage.ErrSessionInUse)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opDeleteSession))
+		return fmt.Errorf("%s: %w", opDeleteSession, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opDeleteSession, storage.ErrSessionNotFound)
+	}
+	return nil
+}
+
+// attachSessions загружает сессии событий одним запросом и заполняет поле Sessions
+func (s *Storage) attachSessions(ctx context.Context, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(events))
+	byID := make(map[string][]*models.Event, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+		byID[e.ID] = append(byID[e.ID], e)
+	}
+
+	var sessions []models.Session
+	query := sessionsQuery + ` where es.event_id = any($1::uuid[]) order by es.starts_at`
+	if err := s.DB.SelectContext(ctx, &sessions, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachSessions))
+		return fmt.Errorf("%s: %w", opAttachSessions, err)
+	}
+	for _, session := range sessions {
+		for _, e := range byID[session.EventID] {
+			e.Sessions = append(e.Sessions, session)
+		}
+	}
+	return nil
+}
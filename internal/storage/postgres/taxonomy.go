@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opGetCategories      = "postgres.getCategories"
+	opCreateCategory     = "postgres.createCategory"
+	opUpdateCategory     = "postgres.updateCategory"
+	opDeleteCategory     = "postgres.deleteCategory"
+	opSetEventCategories = "postgres.setEventCategories"
+	opSetEventTags       = "postgres.setEventTags"
+	opAttachTaxonomy     = "postgres.attachTaxonomy"
+)
+
+// GetCategories возвращает все категории в порядке отображения
+func (s *Storage) GetCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	if err := s.DB.SelectContext(ctx, &categories, `select * from categories order by position, name`); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetCategories))
+		return nil, fmt.Errorf("%s: %w", opGetCategories, err)
+	}
+	return categories, nil
+}
+
+// CreateCategory создаёт категорию
+func (s *Storage) CreateCategory(ctx context.Context, c models.Category) (models.Category, error) {
+	var created models.Category
+	query := `insert into categories (slug, name, position) values ($1, $2, $3) returning *`
+	err := s.DB.GetContext(ctx, &created, query, c.Slug, c.Name, c.Position)
+	if isUniqueViolation(err) {
+		return models.Category{}, fmt.Errorf("%s: %w", opCreateCategory, storage.ErrCategoryExists)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateCategory))
+		return models.Category{}, fmt.Errorf("%s: %w", opCreateCategory, err)
+	}
+	return created, nil
+}
+
+// UpdateCategory обновляет категорию
+func (s *Storage) UpdateCategory(ctx context.Context, c models.Category) (models.Category, error) {
+	var updated models.Category
+	query := `update categories set slug = $2, name = $3, position = $4 where id = $1 returning *`
+	err := s.DB.GetContext(ctx, &updated, query, c.ID, c.Slug, c.Name, c.Position)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Category{}, fmt.Errorf("%s: %w", opUpdateCategory, storage.ErrCategoryNotFound)
+	}
+	if isUniqueViolation(err) {
+		return models.Category{}, fmt.Errorf("%s: %w", opUpdateCategory, storage.ErrCategoryExists)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateCategory))
+		return models.Category{}, fmt.Errorf("%s: %w", opUpdateCategory, err)
+	}
+	return updated, nil
+}
+
+// DeleteCategory удаляет категорию вместе с её привязками к событиям
+func (s *Storage) DeleteCategory(ctx context.Context, categoryID string) error {
+	res, err := s.DB.ExecContext(ctx, `delete from categories where id = $1`, categoryID)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opDeleteCategory, storage.ErrCategoryNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opDeleteCategory))
+		return fmt.Errorf("%s: %w", opDeleteCategory, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opDeleteCategory, storage.ErrCategoryNotFound)
+	}
+	return nil
+}
+
+// SetEventCategories заменяет набор категорий события
+func (s *Storage) SetEventCategories(ctx context.Context, eventID string, categoryIDs []string) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventCategories))
+		return fmt.Errorf("%s: %w", opSetEventCategories, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = lockEvent(ctx, tx, eventID); err != nil {
+		return fmt.Errorf("%s: %w", opSetEventCategories, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `delete from event_categories where event_id = $1`, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventCategories))
+		return fmt.Errorf("%s: %w", opSetEventCategories, err)
+	}
+	query := `insert into event_categories (event_id, category_id)
+		select $1::uuid, unnest($2::uuid[]) on conflict do nothing`
+	_, err = tx.ExecContext(ctx, query, eventID, pq.Array(categoryIDs))
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opSetEventCategories, storage.ErrCategoryNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventCategories))
+		return fmt.Errorf("%s: %w", opSetEventCategories, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventCategories))
+		return fmt.Errorf("%s: %w", opSetEventCategories, err)
+	}
+	return nil
+}
+
+// SetEventTags заменяет набор тегов события, создавая недостающие теги
+func (s *Storage) SetEventTags(ctx context.Context, eventID string, tags []string) (err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventTags))
+		return fmt.Errorf("%s: %w", opSetEventTags, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = lockEvent(ctx, tx, eventID); err != nil {
+		return fmt.Errorf("%s: %w", opSetEventTags, err)
+	}
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`insert into tags (name) select unnest($1::text[]) on conflict (name) do nothing`, []any{pq.Array(tags)}},
+		{`delete from event_tags where event_id = $1`, []any{eventID}},
+		{`insert into event_tags (event_id, tag_id) select $1::uuid, id from tags where name = any($2::text[])`, []any{eventID, pq.Array(tags)}},
+	}
+	for _, st := range statements {
+		if _, err = tx.ExecContext(ctx, st.query, st.args...); err != nil {
+			s.log.Error("error", err.Error(), slog.String("operation", opSetEventTags))
+			return fmt.Errorf("%s: %w", opSetEventTags, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventTags))
+		return fmt.Errorf("%s: %w", opSetEventTags, err)
+	}
+	return nil
+}
+
+// attachTaxonomy загружает категории и теги событий и заполняет соответствующие поля
+func (s *Storage) attachTaxonomy(ctx context.Context, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(events))
+	byID := make(map[string][]*models.Event, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+		byID[e.ID] = append(byID[e.ID], e)
+	}
+
+	var categories []struct {
+		EventID string `db:"event_id"`
+		models.Category
+	}
+	query := `select ec.event_id, c.* from event_categories ec
+		join categories c on c.id = ec.category_id
+		where ec.event_id = any($1::uuid[])
+		order by c.position, c.name`
+	if err := s.DB.SelectContext(ctx, &categories, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachTaxonomy))
+		return fmt.Errorf("%s: %w", opAttachTaxonomy, err)
+	}
+	for _, c := range categories {
+		for _, e := range byID[c.EventID] {
+			e.Categories = append(e.Categories, c.Category)
+		}
+	}
+
+	var tags []struct {
+		EventID string `db:"event_id"`
+		Name    string `db:"name"`
+	}
+	query = `select et.event_id, t.name from event_tags et
+		join tags t on t.id = et.tag_id
+		where et.event_id = any($1::uuid[])
+		order by t.name`
+	if err := s.DB.SelectContext(ctx, &tags, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachTaxonomy))
+		return fmt.Errorf("%s: %w", opAttachTaxonomy, err)
+	}
+	for _, t := range tags {
+		for _, e := range byID[t.EventID] {
+			e.Tags = append(e.Tags, t.Name)
+		}
+	}
+	return nil
+}
+
+// lockEvent блокирует строку события до конца транзакции и проверяет, что событие существует
+func lockEvent(ctx context.Context, tx *sqlx.Tx, eventID string) error {
+	var id string
+	err := tx.GetContext(ctx, &id, `select id from events where id = $1 for update`, eventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return storage.ErrEventNotFound
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opCreateTicketType  = "postgres.createTicketType"
+	opUpdateTicketType  = "postgres.updateTicketType"
+	opDeleteTicketType  = "postgres.deleteTicketType"
+	opAttachTicketTypes = "postgres.attachTicketTypes"
+)
+
+// ticketTypesQuery выбирает типы билетов вместе с количеством проданных билетов: регистраций вместе с гостями.
+// Заявки, ожидающие одобрения, и отклонённые заявки билетов не занимают
+const ticketTypesQuery = `select tt.*,
+		(select coalesce(sum(1 + r.guests), 0) from registration r
+			where r.ticket_type_id = tt.id and r.status in ('confirmed', 'pending_payment')) as sold
+	from ticket_types tt`
+
+// CreateTicketType создаёт тип билета события
+func (s *Storage) CreateTicketType(ctx context.Context, t models.TicketType) (models.TicketType, error) {
+	query := `insert into ticket_types
+		(event_id, name, description, price_amount, currency, capacity, sales_start_at, sales_end_at, position)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9) returning *, 0 as sold`
+	var created models.TicketType
+	err := s.DB.GetContext(ctx, &created, query, t.EventID, t.Name, t.Description, t.PriceAmount, t.Currency,
+		t.Capacity, t.SalesStartAt, t.SalesEndAt, t.Position)
+	if isForeignKeyViolation(err) || isInvalidInput(err) {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opCreateTicketType, storage.ErrEventNotFound)
+	}
+	if isUniqueViolation(err) {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opCreateTicketType, storage.ErrTicketTypeExists)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateTicketType))
+		return models.TicketType{}, fmt.Errorf("%s: %w", opCreateTicketType, err)
+	}
+	return created, nil
+}
+
+// UpdateTicketType обновляет тип билета
+func (s *Storage) UpdateTicketType(ctx context.Context, t models.TicketType) (models.TicketType, error) {
+	query := `update ticket_types tt
+		set name = $2, description = $3, price_amount = $4, currency = $5, capacity = $6,
+			sales_start_at = $7, sales_end_at = $8, position = $9
+		where tt.id = $1
+		returning tt.*, (select coalesce(sum(1 + r.guests), 0) from registration r
+			where r.ticket_type_id = tt.id and r.status in ('confirmed', 'pending_payment')) as sold`
+	var updated models.TicketType
+	err := s.DB.GetContext(ctx, &updated, query, t.ID, t.Name, t.Description, t.PriceAmount, t.Currency,
+		t.Capacity, t.SalesStartAt, t.SalesEndAt, t.Position)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opUpdateTicketType, storage.ErrTicketTypeNotFound)
+	}
+	if isUniqueViolation(err) {
+		return models.TicketType{}, fmt.Errorf("%s: %w", opUpdateTicketType, storage.ErrTicketTypeExists)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateTicketType))
+		return models.TicketType{}, fmt.Errorf("%s: %w", opUpdateTicketType, err)
+	}
+	return updated, nil
+}
+
+// DeleteTicketType удаляет тип билета, если по нему никто не зарегистрирован
+func (s *Storage) DeleteTicketType(ctx context.Context, ticketTypeID string) error {
+	res, err := s.DB.ExecContext(ctx, `delete from ticket_types where id = $1`, ticketTypeID)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opDeleteTicketType, storage.ErrTicketTypeNotFound)
+	}
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%s: %w", opDeleteTicketType, storage.ErrTicketTypeInUse)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opDeleteTicketType))
+		return fmt.Errorf("%s: %w", opDeleteTicketType, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opDeleteTicketType, storage.ErrTicketTypeNotFound)
+	}
+	return nil
+}
+
+// attachTicketTypes загружает типы билетов событий одним запросом и заполняет поле TicketTypes
+func (s *Storage) attachTicketTypes(ctx context.Context, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(events))
+	byID := make(map[string][]*models.Event, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+		byID[e.ID] = append(byID[e.ID], e)
+	}
+
+	var ticketTypes []models.TicketType
+	query := ticketTypesQuery + ` where tt.event_id = any($1::uuid[]) order by tt.position, tt.price_amount, tt.name`
+	if err := s.DB.SelectContext(ctx, &ticketTypes, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachTicketTypes))
+		return fmt.Errorf("%s: %w", opAttachTicketTypes, err)
+	}
+	for _, t := range ticketTypes {
+		for _, e := range byID[t.EventID] {
+			e.TicketTypes = append(e.TicketTypes, t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opAttachTranslations     = "postgres.attachTranslations"
+	opGetEventTranslations   = "postgres.getEventTranslations"
+	opSetEventTranslation    = "postgres.setEventTranslation"
+	opDeleteEventTranslation = "postgres.deleteEventTranslation"
+)
+
+// GetEventTranslations возвращает переводы события, упорядоченные по языку
+func (s *Storage) GetEventTranslations(ctx context.Context, eventID string) ([]models.EventTranslation, error) {
+	var exists bool
+	err := s.DB.GetContext(ctx, &exists, `select exists (select 1 from events where id = $1)`, eventID)
+	if isInvalidInput(err) {
+		return nil, fmt.Errorf("%s: %w", opGetEventTranslations, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventTranslations))
+		return nil, fmt.Errorf("%s: %w", opGetEventTranslations, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", opGetEventTranslations, storage.ErrEventNotFound)
+	}
+
+	translations := []models.EventTranslation{}
+	query := `select * from event_translations where event_id = $1 order by language`
+	if err = s.DB.SelectContext(ctx, &translations, query, eventID); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventTranslations))
+		return nil, fmt.Errorf("%s: %w", opGetEventTranslations, err)
+	}
+	return translations, nil
+}
+
+// SetEventTranslation создаёт или заменяет перевод события на язык t.Language
+func (s *Storage) SetEventTranslation(ctx context.Context, t models.EventTranslation) (models.EventTranslation, error) {
+	query := `insert into event_translations (event_id, language, title, description, updated_at)
+		values ($1, $2, $3, $4, $5)
+		on conflict (event_id, language) do update set
+			title = excluded.title, description = excluded.description, updated_at = excluded.updated_at
+		returning *`
+	var saved models.EventTranslation
+	err := s.DB.GetContext(ctx, &saved, query, t.EventID, t.Language, t.Title, t.Description, time.Now())
+	if isInvalidInput(err) || isForeignKeyViolation(err) {
+		return models.EventTranslation{}, fmt.Errorf("%s: %w", opSetEventTranslation, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventTranslation))
+		return models.EventTranslation{}, fmt.Errorf("%s: %w", opSetEventTranslation, err)
+	}
+	return saved, nil
+}
+
+// DeleteEventTranslation удаляет перевод события на язык language
+func (s *Storage) DeleteEventTranslation(ctx context.Context, eventID, language string) error {
+	res, err := s.DB.ExecContext(ctx, `delete from event_translations where event_id = $1 and language = $2`, eventID, language)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opDeleteEventTranslation, storage.ErrTranslationNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opDeleteEventTranslation))
+		return fmt.Errorf("%s: %w", opDeleteEventTranslation, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opDeleteEventTranslation, storage.ErrTranslationNotFound)
+	}
+	return nil
+}
+
+// attachTranslations загружает переводы событий одним запросом
+func (s *Storage) attachTranslations(ctx context.Context, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(events))
+	byID := make(map[string][]*models.Event, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+		byID[e.ID] = append(byID[e.ID], e)
+	}
+
+	var rows []models.EventTranslation
+	query := `select * from event_translations where event_id = any($1::uuid[]) order by language`
+	if err := s.DB.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachTranslations))
+		return fmt.Errorf("%s: %w", opAttachTranslations, err)
+	}
+	for _, row := range rows {
+		for _, e := range byID[row.EventID] {
+			e.Translations = append(e.Translations, row)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opGetUser     = "postgres.getUser"
+	opAttachUsers = "postgres.attachUsers"
+)
+
+// GetUser возвращает профиль пользователя
+func (s *Storage) GetUser(ctx context.Context, chatID int64) (models.UserProfile, error) {
+	var user models.UserProfile
+	err := s.DB.GetContext(ctx, &user, `select * from users where chat_id = $1`, chatID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.UserProfile{}, fmt.Errorf("%s: %w", opGetUser, storage.ErrUserNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetUser))
+		return models.UserProfile{}, fmt.Errorf("%s: %w", opGetUser, err)
+	}
+	return user, nil
+}
+
+// upsertUser создаёт или обновляет профиль пользователя в транзакции регистрации. Username заменяется всегда,
+// так как пустой username означает, что пользователь его удалил. Имя и язык заменяются, только если переданы
+func upsertUser(ctx context.Context, tx *sqlx.Tx, user models.UserProfile, now time.Time) (models.UserProfile, error) {
+	query := `insert into users (chat_id, username, first_name, last_name, language_code, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $6)
+		on conflict (chat_id) do update set
+			username = excluded.username,
+			first_name = coalesce(nullif(excluded.first_name, ''), users.first_name),
+			last_name = coalesce(nullif(excluded.last_name, ''), users.last_name),
+			language_code = coalesce(nullif(excluded.language_code, ''), users.language_code),
+			updated_at = excluded.updated_at
+		returning *`
+	var saved models.UserProfile
+	err := tx.GetContext(ctx, &saved, query, user.ChatID, user.Username, user.FirstName, user.LastName, user.LanguageCode, now)
+	return saved, err
+}
+
+// attachUsers загружает профили пользователей регистраций одним запросом и заполняет поле User.
+// q - соединение или транзакция, в которой прочитаны регистрации
+func (s *Storage) attachUsers(ctx context.Context, q sqlx.QueryerContext, regs ...*models.Registration) error {
+	if len(regs) == 0 {
+		return nil
+	}
+	chatIDs := make([]int64, 0, len(regs))
+	for _, reg := range regs {
+		chatIDs = append(chatIDs, reg.ChatID)
+	}
+
+	var users []models.UserProfile
+	if err := sqlx.SelectContext(ctx, q, &users, `select * from users where chat_id = any($1)`, pq.Array(chatIDs)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachUsers))
+		return fmt.Errorf("%s: %w", opAttachUsers, err)
+	}
+	byChatID := make(map[int64]models.UserProfile, len(users))
+	for _, user := range users {
+		byChatID[user.ChatID] = user
+	}
+	for _, reg := range regs {
+		if user, ok := byChatID[reg.ChatID]; ok {
+			reg.User = user
+		} else {
+			reg.User = models.UserProfile{ChatID: reg.ChatID}
+		}
+	}
+	return nil
+}
+
+// registrationPtrs возвращает указатели на элементы среза регистраций
+func registrationPtrs(regs []models.Registration) []*models.Registration {
+	ptrs := make([]*models.Registration, 0, len(regs))
+	for i := range regs {
+		ptrs = append(ptrs, &regs[i])
+	}
+	return ptrs
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+	"github.com/lib/pq"
+)
+
+// Константы для описания операций
+const (
+	opCreateVenue   = "postgres.createVenue"
+	opUpdateVenue   = "postgres.updateVenue"
+	opGetVenues     = "postgres.getVenues"
+	opSetEventVenue = "postgres.setEventVenue"
+	opGetEventsNear = "postgres.getEventsNear"
+	opAttachVenues  = "postgres.attachVenues"
+)
+
+// kmPerDegreeOfLat длина одного градуса широты в километрах
+const kmPerDegreeOfLat = 111.045
+
+// CreateVenue создаёт площадку
+func (s *Storage) CreateVenue(ctx context.Context, v models.Venue) (models.Venue, error) {
+	query := `insert into venues (name, address, city, latitude, longitude, capacity, time_zone)
+		values ($1, $2, $3, $4, $5, $6, $7) returning *`
+	var created models.Venue
+	err := s.DB.GetContext(ctx, &created, query, v.Name, v.Address, v.City, v.Latitude, v.Longitude, v.Capacity, v.TimeZone)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opCreateVenue))
+		return models.Venue{}, fmt.Errorf("%s: %w", opCreateVenue, err)
+	}
+	return created, nil
+}
+
+// UpdateVenue обновляет площадку
+func (s *Storage) UpdateVenue(ctx context.Context, v models.Venue) (models.Venue, error) {
+	query := `update venues
+		set name = $2, address = $3, city = $4, latitude = $5, longitude = $6, capacity = $7, time_zone = $8
+		where id = $1 returning *`
+	var updated models.Venue
+	err := s.DB.GetContext(ctx, &updated, query, v.ID, v.Name, v.Address, v.City, v.Latitude, v.Longitude, v.Capacity, v.TimeZone)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.Venue{}, fmt.Errorf("%s: %w", opUpdateVenue, storage.ErrVenueNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opUpdateVenue))
+		return models.Venue{}, fmt.Errorf("%s: %w", opUpdateVenue, err)
+	}
+	return updated, nil
+}
+
+// GetVenues возвращает все площадки
+func (s *Storage) GetVenues(ctx context.Context) ([]models.Venue, error) {
+	var venues []models.Venue
+	if err := s.DB.SelectContext(ctx, &venues, `select * from venues order by city, name`); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetVenues))
+		return nil, fmt.Errorf("%s: %w", opGetVenues, err)
+	}
+	return venues, nil
+}
+
+// SetEventVenue привязывает событие к площадке. nil отвязывает событие от площадки
+func (s *Storage) SetEventVenue(ctx context.Context, eventID string, venueID *string) error {
+	res, err := s.DB.ExecContext(ctx, `update events set venue_id = $2 where id = $1`, eventID, venueID)
+	if isForeignKeyViolation(err) {
+		return fmt.Errorf("%s: %w", opSetEventVenue, storage.ErrVenueNotFound)
+	}
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opSetEventVenue, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetEventVenue))
+		return fmt.Errorf("%s: %w", opSetEventVenue, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opSetEventVenue, storage.ErrEventNotFound)
+	}
+	return nil
+}
+
+// GetEventsNear возвращает публичные события, которые начинаются не раньше from на площадках в радиусе radiusKm
+// от точки, отсортированные по расстоянию. Расстояние считается по формуле гаверсинусов, а площадки
+// предварительно отбираются по ограничивающему прямоугольнику, чтобы использовать индекс по координатам
+func (s *Storage) GetEventsNear(ctx context.Context, lat, lon, radiusKm float64, from time.Time) ([]models.NearbyEvent, error) {
+	minLat, maxLat, minLon, maxLon := boundingBox(lat, lon, radiusKm)
+	query := `select e.*, d.distance_km
+		from events e
+		join venues v on v.id = e.venue_id
+		cross join lateral (
+			select 2 * 6371.0 * asin(least(1, sqrt(
+				power(sin(radians(v.latitude - $1) / 2), 2) +
+				cos(radians($1)) * cos(radians(v.latitude)) * power(sin(radians(v.longitude - $2) / 2), 2)
+			))) as distance_km
+		) d
+		where e.starts_at >= $3
+			and e.visibility = 'public'
+			and v.latitude between $4 and $5
+			and v.longitude between $6 and $7
+			and d.distance_km <= $8
+		order by d.distance_km, e.starts_at`
+
+	var events []models.NearbyEvent
+	err := s.DB.SelectContext(ctx, &events, query, lat, lon, from, minLat, maxLat, minLon, maxLon, radiusKm)
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetEventsNear))
+		return nil, fmt.Errorf("%s: %w", opGetEventsNear, err)
+	}
+
+	ptrs := make([]*models.Event, 0, len(events))
+	for i := range events {
+		ptrs = append(ptrs, &events[i].Event)
+	}
+	if err = s.attachDetails(ctx, ptrs...); err != nil {
+		return nil, fmt.Errorf("%s: %w", opGetEventsNear, err)
+	}
+	return events, nil
+}
+
+// eventPtrs возвращает указатели на элементы среза событий
+func eventPtrs(events []models.Event) []*models.Event {
+	ptrs := make([]*models.Event, 0, len(events))
+	for i := range events {
+		ptrs = append(ptrs, &events[i])
+	}
+	return ptrs
+}
+
+// attachVenues загружает площадки событий одним запросом и заполняет поле Venue
+func (s *Storage) attachVenues(ctx context.Context, events ...*models.Event) error {
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		if e.VenueID != nil {
+			ids = append(ids, *e.VenueID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var venues []models.Venue
+	if err := s.DB.SelectContext(ctx, &venues, `select * from venues where id = any($1::uuid[])`, pq.Array(ids)); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opAttachVenues))
+		return fmt.Errorf("%s: %w", opAttachVenues, err)
+	}
+
+	byID := make(map[string]*models.Venue, len(venues))
+	for i := range venues {
+		byID[venues[i].ID] = &venues[i]
+	}
+	for _, e := range events {
+		if e.VenueID != nil {
+			e.Venue = byID[*e.VenueID]
+		}
+	}
+	return nil
+}
+
+// boundingBox вычисляет прямоугольник, содержащий круг радиуса radiusKm. Если круг пересекает
+// полюс или линию перемены дат, ограничение по долготе снимается
+func boundingBox(lat, lon, radiusKm float64) (minLat, maxLat, minLon, maxLon float64) {
+	dLat := radiusKm / kmPerDegreeOfLat
+	minLat, maxLat = lat-dLat, lat+dLat
+	minLon, maxLon = -180, 180
+
+	cosLat := math.Cos(lat * math.Pi / 180)
+	if minLat > -90 && maxLat < 90 && cosLat > 0 {
+		dLon := dLat / cosLat
+		if lon-dLon > -180 && lon+dLon < 180 {
+			minLon, maxLon = lon-dLon, lon+dLon
+		}
+	}
+	return minLat, maxLat, minLon, maxLon
+}
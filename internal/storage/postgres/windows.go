@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/domain/models"
+	"github.com/Telegram-bot-for-register-on-events/event-service/internal/storage"
+)
+
+// Константы для описания операций
+const (
+	opGetRegistrationWindow = "postgres.getRegistrationWindow"
+	opSetRegistrationWindow = "postgres.setRegistrationWindow"
+	opClaimOpenedWindows    = "postgres.claimOpenedRegistrations"
+)
+
+// GetRegistrationWindow возвращает окно регистрации на событие
+func (s *Storage) GetRegistrationWindow(ctx context.Context, eventID string) (models.RegistrationWindow, error) {
+	var w models.RegistrationWindow
+	query := `select starts_at, registration_opens_at, registration_closes_at, registration_closes_before
+		from events where id = $1`
+	err := s.DB.GetContext(ctx, &w, query, eventID)
+	if errors.Is(err, sql.ErrNoRows) || isInvalidInput(err) {
+		return models.RegistrationWindow{}, fmt.Errorf("%s: %w", opGetRegistrationWindow, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opGetRegistrationWindow))
+		return models.RegistrationWindow{}, fmt.Errorf("%s: %w", opGetRegistrationWindow, err)
+	}
+	return w, nil
+}
+
+// SetRegistrationWindow задаёт окно регистрации на событие. При переносе открытия регистрации
+// сообщение об открытии будет опубликовано заново
+func (s *Storage) SetRegistrationWindow(ctx context.Context, eventID string, w models.RegistrationWindow) error {
+	query := `update events set
+			registration_open_announced_at = case when registration_opens_at is distinct from $2
+				then null else registration_open_announced_at end,
+			registration_opens_at = $2, registration_closes_at = $3, registration_closes_before = $4
+		where id = $1`
+	res, err := s.DB.ExecContext(ctx, query, eventID, w.OpensAt, w.ClosesAt, w.ClosesBefore)
+	if isInvalidInput(err) {
+		return fmt.Errorf("%s: %w", opSetRegistrationWindow, storage.ErrEventNotFound)
+	}
+	if err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opSetRegistrationWindow))
+		return fmt.Errorf("%s: %w", opSetRegistrationWindow, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s: %w", opSetRegistrationWindow, storage.ErrEventNotFound)
+	}
+	return nil
+}
+
+// ClaimOpenedRegistrations отмечает объявленными и возвращает ещё не начавшиеся события, регистрация на которые
+// открылась к моменту now. Каждое событие возвращается один раз, даже при нескольких экземплярах сервиса
+func (s *Storage) ClaimOpenedRegistrations(ctx context.Context, now time.Time) ([]models.Event, error) {
+	var events []models.Event
+	query := `update events set registration_open_announced_at = $1
+		where registration_open_announced_at is null and registration_opens_at <= $1 and starts_at > $1
+		returning *`
+	if err := s.DB.SelectContext(ctx, &events, query, now); err != nil {
+		s.log.Error("error", err.Error(), slog.String("operation", opClaimOpenedWindows))
+		return nil, fmt.Errorf("%s: %w", opClaimOpenedWindows, err)
+	}
+	return events, nil
+}
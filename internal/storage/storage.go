@@ -0,0 +1,48 @@
+package storage
+
+import "errors"
+
+// Ошибки слоя хранения данных
+var (
+	ErrEventNotFound            = errors.New("event not found")
+	ErrFeedNotFound             = errors.New("calendar feed not found")
+	ErrVenueNotFound            = errors.New("venue not found")
+	ErrCategoryNotFound         = errors.New("category not found")
+	ErrCategoryExists           = errors.New("category already exists")
+	ErrSeriesNotFound           = errors.New("event series not found")
+	ErrNotSeriesEvent           = errors.New("event is not part of a series")
+	ErrAlreadyRegistered        = errors.New("user already registered for event")
+	ErrSessionNotFound          = errors.New("session not found")
+	ErrSessionRequired          = errors.New("event has sessions, session is required")
+	ErrSessionFull              = errors.New("session is full")
+	ErrSessionOverlap           = errors.New("user already registered for an overlapping session")
+	ErrSessionInUse             = errors.New("session has registrations")
+	ErrTicketTypeNotFound       = errors.New("ticket type not found")
+	ErrTicketTypeExists         = errors.New("ticket type with this name already exists")
+	ErrTicketTypeRequired       = errors.New("event has ticket types, ticket type is required")
+	ErrTicketTypeSoldOut        = errors.New("ticket type is sold out")
+	ErrTicketSalesClosed        = errors.New("ticket sales are closed")
+	ErrTicketTypeInUse          = errors.New("ticket type has registrations")
+	ErrPaymentNotFound          = errors.New("payment not found")
+	ErrPaymentProcessed         = errors.New("payment already processed")
+	ErrPaymentExpired           = errors.New("payment registration has expired")
+	ErrRegistrationNotFound     = errors.New("registration not found")
+	ErrRegistrationNotConfirmed = errors.New("registration is not confirmed")
+	ErrAlreadyCheckedIn         = errors.New("ticket already checked in")
+	ErrTooManyNoShows           = errors.New("registration blocked due to repeated no-shows")
+	ErrTooManyGuests            = errors.New("too many guests for event")
+	ErrGuestsPaidTicket         = errors.New("guests of a paid registration cannot be changed")
+	ErrQuestionNotFound         = errors.New("question not found")
+	ErrQuestionInUse            = errors.New("question has answers")
+	ErrApplicationDecided       = errors.New("registration is not awaiting approval")
+	ErrInviteCodeNotFound       = errors.New("invite code not found")
+	ErrInviteCodeRequired       = errors.New("invite code is required for private event")
+	ErrInviteCodeInvalid        = errors.New("invite code is expired, revoked or used up")
+	ErrDeepLinkNotFound         = errors.New("deep link not found")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrTranslationNotFound      = errors.New("translation not found")
+	ErrChatBlocked              = errors.New("chat is blocked")
+	ErrChatNotBlocked           = errors.New("chat is not blocked")
+	ErrEventRateLimited         = errors.New("too many registrations for event, try again later")
+	ErrIdempotencyKeyBusy       = errors.New("request with this idempotency key is in progress")
+)
@@ -0,0 +1,80 @@
+package ticket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Константы для описания операций
+const (
+	opQRCode = "ticket.QRCode"
+)
+
+// ErrInvalidToken токен билета повреждён или подписан другим ключом
+var ErrInvalidToken = errors.New("invalid ticket token")
+
+// Claims описывает данные, зашифрованные в токене билета
+type Claims struct {
+	RegistrationID string
+	EventID        string
+}
+
+// Signer выпускает и проверяет токены билетов. Токен имеет вид <данные>.<подпись>, где данные -
+// идентификаторы регистрации и события, а подпись - HMAC-SHA256 данных, обе части в base64url
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner конструктор для Signer
+func NewSigner(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+// Sign выпускает токен билета для регистрации
+func (s *Signer) Sign(registrationID, eventID string) string {
+	payload := []byte(registrationID + ":" + eventID)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify проверяет подпись токена и возвращает его данные
+func (s *Signer) Verify(token string) (Claims, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return Claims{}, ErrInvalidToken
+	}
+	registrationID, eventID, ok := strings.Cut(string(payload), ":")
+	if !ok || registrationID == "" || eventID == "" {
+		return Claims{}, ErrInvalidToken
+	}
+	return Claims{RegistrationID: registrationID, EventID: eventID}, nil
+}
+
+// mac возвращает подпись данных токена
+func (s *Signer) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// QRCode кодирует токен в QR-код и возвращает PNG-изображение со стороной size пикселей
+func QRCode(token string, size int) ([]byte, error) {
+	png, err := qrcode.Encode(token, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opQRCode, err)
+	}
+	return png, nil
+}
@@ -0,0 +1,89 @@
+package ticket
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testSecret = "ticket-secret-for-tests"
+
+func TestSignVerify(t *testing.T) {
+	tests := []struct {
+		name           string
+		registrationID string
+		eventID        string
+	}{
+		{name: "uuid", registrationID: "0b7f0d2e-6c3a-4f5e-9a51-3b1f2c4d5e6f", eventID: "6f5e4d3c-2b1a-4f0e-9d8c-7b6a5f4e3d2c"},
+		{name: "short", registrationID: "registration", eventID: "event"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSigner(testSecret)
+			token := s.Sign(tt.registrationID, tt.eventID)
+			got, err := s.Verify(token)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			want := Claims{RegistrationID: tt.registrationID, EventID: tt.eventID}
+			if got != want {
+				t.Fatalf("Verify = %+v, want %+v", got, want)
+			}
+			if again := s.Sign(tt.registrationID, tt.eventID); again != token {
+				t.Errorf("Sign is not deterministic: %q and %q", token, again)
+			}
+		})
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	s := NewSigner(testSecret)
+	token := s.Sign("registration", "event")
+	_, signature, _ := strings.Cut(token, ".")
+	encode := base64.RawURLEncoding.EncodeToString
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "signed with another key", token: NewSigner("another-secret").Sign("registration", "event")},
+		{name: "tampered payload", token: encode([]byte("other:event")) + "." + signature},
+		{name: "truncated signature", token: token[:len(token)-1]},
+		{name: "no separator", token: strings.Replace(token, ".", "", 1)},
+		{name: "payload not base64", token: "!!!." + signature},
+		{name: "signature not base64", token: encode([]byte("registration:event")) + ".!!!"},
+		{name: "empty"},
+		{name: "no event id", token: s.Sign("registration", "")},
+		{name: "no registration id", token: s.Sign("", "event")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Verify(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify(%q) error = %v, want ErrInvalidToken", tt.token, err)
+			}
+		})
+	}
+}
+
+func TestQRCode(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "small", size: 128},
+		{name: "large", size: 512},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			png, err := QRCode(NewSigner(testSecret).Sign("registration", "event"), tt.size)
+			if err != nil {
+				t.Fatalf("QRCode: %v", err)
+			}
+			if !bytes.HasPrefix(png, []byte("\x89PNG")) {
+				t.Fatal("QRCode did not return a PNG image")
+			}
+		})
+	}
+}
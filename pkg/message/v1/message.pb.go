@@ -0,0 +1,681 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pkg/message/v1/message.proto
+
+// Данные сообщений, которые event-service публикует в NATS при NATS_ENCODING=protobuf.
+// Версия пакета соответствует версии в типе сообщения (event-service.<имя>.v1).
+// Генерация Go-кода: см. раздел "Формат сообщений NATS" в README
+
+package messagev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RegistrationConfirmed подтверждённая регистрация на событие (event-service.registration.confirmed.v1)
+type RegistrationConfirmed struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ChatId   int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	EventId  string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// session_id сессия события, если регистрация выполнена на сессию
+	SessionId *string `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"`
+	// ticket_type_id тип билета, если у события есть типы билетов
+	TicketTypeId *string `protobuf:"bytes,5,opt,name=ticket_type_id,json=ticketTypeId,proto3,oneof" json:"ticket_type_id,omitempty"`
+	// ticket_token токен билета для входа на событие, пустой при регистрации на серию
+	TicketToken string `protobuf:"bytes,6,opt,name=ticket_token,json=ticketToken,proto3" json:"ticket_token,omitempty"`
+	// guests количество гостей, которых пользователь приведёт с собой
+	Guests        int32 `protobuf:"varint,7,opt,name=guests,proto3" json:"guests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationConfirmed) Reset() {
+	*x = RegistrationConfirmed{}
+	mi := &file_pkg_message_v1_message_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationConfirmed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationConfirmed) ProtoMessage() {}
+
+func (x *RegistrationConfirmed) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_message_v1_message_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationConfirmed.ProtoReflect.Descriptor instead.
+func (*RegistrationConfirmed) Descriptor() ([]byte, []int) {
+	return file_pkg_message_v1_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RegistrationConfirmed) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *RegistrationConfirmed) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegistrationConfirmed) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RegistrationConfirmed) GetSessionId() string {
+	if x != nil && x.SessionId != nil {
+		return *x.SessionId
+	}
+	return ""
+}
+
+func (x *RegistrationConfirmed) GetTicketTypeId() string {
+	if x != nil && x.TicketTypeId != nil {
+		return *x.TicketTypeId
+	}
+	return ""
+}
+
+func (x *RegistrationConfirmed) GetTicketToken() string {
+	if x != nil {
+		return x.TicketToken
+	}
+	return ""
+}
+
+func (x *RegistrationConfirmed) GetGuests() int32 {
+	if x != nil {
+		return x.Guests
+	}
+	return 0
+}
+
+// PaymentConfirmed подтверждённая оплата регистрации (event-service.payment.confirmed.v1)
+type PaymentConfirmed struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RegistrationId string                 `protobuf:"bytes,1,opt,name=registration_id,json=registrationId,proto3" json:"registration_id,omitempty"`
+	PaymentId      string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	EventId        string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	ChatId         int64                  `protobuf:"varint,4,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Username       string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	SessionId      *string                `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"`
+	TicketTypeId   *string                `protobuf:"bytes,7,opt,name=ticket_type_id,json=ticketTypeId,proto3,oneof" json:"ticket_type_id,omitempty"`
+	// amount сумма в минимальных единицах валюты
+	Amount   int64  `protobuf:"varint,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// ticket_token токен билета для входа на событие
+	TicketToken   string `protobuf:"bytes,10,opt,name=ticket_token,json=ticketToken,proto3" json:"ticket_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentConfirmed) Reset() {
+	*x = PaymentConfirmed{}
+	mi := &file_pkg_message_v1_message_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentConfirmed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentConfirmed) ProtoMessage() {}
+
+func (x *PaymentConfirmed) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_message_v1_message_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentConfirmed.ProtoReflect.Descriptor instead.
+func (*PaymentConfirmed) Descriptor() ([]byte, []int) {
+	return file_pkg_message_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaymentConfirmed) GetRegistrationId() string {
+	if x != nil {
+		return x.RegistrationId
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *PaymentConfirmed) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetSessionId() string {
+	if x != nil && x.SessionId != nil {
+		return *x.SessionId
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetTicketTypeId() string {
+	if x != nil && x.TicketTypeId != nil {
+		return *x.TicketTypeId
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentConfirmed) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentConfirmed) GetTicketToken() string {
+	if x != nil {
+		return x.TicketToken
+	}
+	return ""
+}
+
+// RegistrationCheckedIn проход на событие по билету (event-service.registration.checked_in.v1)
+type RegistrationCheckedIn struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RegistrationId string                 `protobuf:"bytes,1,opt,name=registration_id,json=registrationId,proto3" json:"registration_id,omitempty"`
+	EventId        string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	SessionId      *string                `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"`
+	ChatId         int64                  `protobuf:"varint,4,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Username       string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	CheckedInAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=checked_in_at,json=checkedInAt,proto3" json:"checked_in_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegistrationCheckedIn) Reset() {
+	*x = RegistrationCheckedIn{}
+	mi := &file_pkg_message_v1_message_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationCheckedIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationCheckedIn) ProtoMessage() {}
+
+func (x *RegistrationCheckedIn) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_message_v1_message_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationCheckedIn.ProtoReflect.Descriptor instead.
+func (*RegistrationCheckedIn) Descriptor() ([]byte, []int) {
+	return file_pkg_message_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegistrationCheckedIn) GetRegistrationId() string {
+	if x != nil {
+		return x.RegistrationId
+	}
+	return ""
+}
+
+func (x *RegistrationCheckedIn) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RegistrationCheckedIn) GetSessionId() string {
+	if x != nil && x.SessionId != nil {
+		return *x.SessionId
+	}
+	return ""
+}
+
+func (x *RegistrationCheckedIn) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *RegistrationCheckedIn) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegistrationCheckedIn) GetCheckedInAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedInAt
+	}
+	return nil
+}
+
+// RegistrationDecision решение организатора по заявке на регистрацию (event-service.registration.decision.v1)
+type RegistrationDecision struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RegistrationId string                 `protobuf:"bytes,1,opt,name=registration_id,json=registrationId,proto3" json:"registration_id,omitempty"`
+	EventId        string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	SessionId      *string                `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"`
+	ChatId         int64                  `protobuf:"varint,4,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Username       string                 `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	// decision решение: approved или rejected
+	Decision string `protobuf:"bytes,6,opt,name=decision,proto3" json:"decision,omitempty"`
+	// status состояние регистрации после решения: confirmed, pending_payment или rejected
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// ticket_token токен билета, передаётся для одобренной бесплатной регистрации
+	TicketToken string `protobuf:"bytes,9,opt,name=ticket_token,json=ticketToken,proto3" json:"ticket_token,omitempty"`
+	// payment_url ссылка на оплату, передаётся для одобренной регистрации с платным билетом
+	PaymentUrl       string                 `protobuf:"bytes,10,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	PaymentExpiresAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=payment_expires_at,json=paymentExpiresAt,proto3" json:"payment_expires_at,omitempty"`
+	DecidedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RegistrationDecision) Reset() {
+	*x = RegistrationDecision{}
+	mi := &file_pkg_message_v1_message_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationDecision) ProtoMessage() {}
+
+func (x *RegistrationDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_message_v1_message_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationDecision.ProtoReflect.Descriptor instead.
+func (*RegistrationDecision) Descriptor() ([]byte, []int) {
+	return file_pkg_message_v1_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegistrationDecision) GetRegistrationId() string {
+	if x != nil {
+		return x.RegistrationId
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetSessionId() string {
+	if x != nil && x.SessionId != nil {
+		return *x.SessionId
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *RegistrationDecision) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetTicketToken() string {
+	if x != nil {
+		return x.TicketToken
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetPaymentUrl() string {
+	if x != nil {
+		return x.PaymentUrl
+	}
+	return ""
+}
+
+func (x *RegistrationDecision) GetPaymentExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaymentExpiresAt
+	}
+	return nil
+}
+
+func (x *RegistrationDecision) GetDecidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return nil
+}
+
+// RegistrationOpened открытие регистрации на событие (event-service.registration.opened.v1)
+type RegistrationOpened struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EventId  string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	StartsAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	TimeZone string                 `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	OpensAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=opens_at,json=opensAt,proto3" json:"opens_at,omitempty"`
+	// closes_at момент закрытия регистрации, отсутствует, если регистрация не ограничена
+	ClosesAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationOpened) Reset() {
+	*x = RegistrationOpened{}
+	mi := &file_pkg_message_v1_message_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationOpened) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationOpened) ProtoMessage() {}
+
+func (x *RegistrationOpened) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_message_v1_message_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationOpened.ProtoReflect.Descriptor instead.
+func (*RegistrationOpened) Descriptor() ([]byte, []int) {
+	return file_pkg_message_v1_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegistrationOpened) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RegistrationOpened) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RegistrationOpened) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *RegistrationOpened) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *RegistrationOpened) GetOpensAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpensAt
+	}
+	return nil
+}
+
+func (x *RegistrationOpened) GetClosesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return nil
+}
+
+var File_pkg_message_v1_message_proto protoreflect.FileDescriptor
+
+const file_pkg_message_v1_message_proto_rawDesc = "" +
+	"\n" +
+	"\x1cpkg/message/v1/message.proto\x12\x17eventservice.message.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x02\n" +
+	"\x15RegistrationConfirmed\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\"\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tH\x00R\tsessionId\x88\x01\x01\x12)\n" +
+	"\x0eticket_type_id\x18\x05 \x01(\tH\x01R\fticketTypeId\x88\x01\x01\x12!\n" +
+	"\fticket_token\x18\x06 \x01(\tR\vticketToken\x12\x16\n" +
+	"\x06guests\x18\a \x01(\x05R\x06guestsB\r\n" +
+	"\v_session_idB\x11\n" +
+	"\x0f_ticket_type_id\"\xf2\x02\n" +
+	"\x10PaymentConfirmed\x12'\n" +
+	"\x0fregistration_id\x18\x01 \x01(\tR\x0eregistrationId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x17\n" +
+	"\achat_id\x18\x04 \x01(\x03R\x06chatId\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\"\n" +
+	"\n" +
+	"session_id\x18\x06 \x01(\tH\x00R\tsessionId\x88\x01\x01\x12)\n" +
+	"\x0eticket_type_id\x18\a \x01(\tH\x01R\fticketTypeId\x88\x01\x01\x12\x16\n" +
+	"\x06amount\x18\b \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12!\n" +
+	"\fticket_token\x18\n" +
+	" \x01(\tR\vticketTokenB\r\n" +
+	"\v_session_idB\x11\n" +
+	"\x0f_ticket_type_id\"\x83\x02\n" +
+	"\x15RegistrationCheckedIn\x12'\n" +
+	"\x0fregistration_id\x18\x01 \x01(\tR\x0eregistrationId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\"\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tH\x00R\tsessionId\x88\x01\x01\x12\x17\n" +
+	"\achat_id\x18\x04 \x01(\x03R\x06chatId\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12>\n" +
+	"\rchecked_in_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcheckedInAtB\r\n" +
+	"\v_session_id\"\xd7\x03\n" +
+	"\x14RegistrationDecision\x12'\n" +
+	"\x0fregistration_id\x18\x01 \x01(\tR\x0eregistrationId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\"\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tH\x00R\tsessionId\x88\x01\x01\x12\x17\n" +
+	"\achat_id\x18\x04 \x01(\x03R\x06chatId\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x1a\n" +
+	"\bdecision\x18\x06 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12!\n" +
+	"\fticket_token\x18\t \x01(\tR\vticketToken\x12\x1f\n" +
+	"\vpayment_url\x18\n" +
+	" \x01(\tR\n" +
+	"paymentUrl\x12H\n" +
+	"\x12payment_expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x10paymentExpiresAt\x129\n" +
+	"\n" +
+	"decided_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdecidedAtB\r\n" +
+	"\v_session_id\"\x8b\x02\n" +
+	"\x12RegistrationOpened\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x127\n" +
+	"\tstarts_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x125\n" +
+	"\bopens_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aopensAt\x127\n" +
+	"\tcloses_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bclosesAtBWZUgithub.com/Telegram-bot-for-register-on-events/event-service/pkg/message/v1;messagev1b\x06proto3"
+
+var (
+	file_pkg_message_v1_message_proto_rawDescOnce sync.Once
+	file_pkg_message_v1_message_proto_rawDescData []byte
+)
+
+func file_pkg_message_v1_message_proto_rawDescGZIP() []byte {
+	file_pkg_message_v1_message_proto_rawDescOnce.Do(func() {
+		file_pkg_message_v1_message_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_message_v1_message_proto_rawDesc), len(file_pkg_message_v1_message_proto_rawDesc)))
+	})
+	return file_pkg_message_v1_message_proto_rawDescData
+}
+
+var file_pkg_message_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_pkg_message_v1_message_proto_goTypes = []any{
+	(*RegistrationConfirmed)(nil), // 0: eventservice.message.v1.RegistrationConfirmed
+	(*PaymentConfirmed)(nil),      // 1: eventservice.message.v1.PaymentConfirmed
+	(*RegistrationCheckedIn)(nil), // 2: eventservice.message.v1.RegistrationCheckedIn
+	(*RegistrationDecision)(nil),  // 3: eventservice.message.v1.RegistrationDecision
+	(*RegistrationOpened)(nil),    // 4: eventservice.message.v1.RegistrationOpened
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_pkg_message_v1_message_proto_depIdxs = []int32{
+	5, // 0: eventservice.message.v1.RegistrationCheckedIn.checked_in_at:type_name -> google.protobuf.Timestamp
+	5, // 1: eventservice.message.v1.RegistrationDecision.payment_expires_at:type_name -> google.protobuf.Timestamp
+	5, // 2: eventservice.message.v1.RegistrationDecision.decided_at:type_name -> google.protobuf.Timestamp
+	5, // 3: eventservice.message.v1.RegistrationOpened.starts_at:type_name -> google.protobuf.Timestamp
+	5, // 4: eventservice.message.v1.RegistrationOpened.opens_at:type_name -> google.protobuf.Timestamp
+	5, // 5: eventservice.message.v1.RegistrationOpened.closes_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pkg_message_v1_message_proto_init() }
+func file_pkg_message_v1_message_proto_init() {
+	if File_pkg_message_v1_message_proto != nil {
+		return
+	}
+	file_pkg_message_v1_message_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pkg_message_v1_message_proto_msgTypes[1].OneofWrappers = []any{}
+	file_pkg_message_v1_message_proto_msgTypes[2].OneofWrappers = []any{}
+	file_pkg_message_v1_message_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_message_v1_message_proto_rawDesc), len(file_pkg_message_v1_message_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_message_v1_message_proto_goTypes,
+		DependencyIndexes: file_pkg_message_v1_message_proto_depIdxs,
+		MessageInfos:      file_pkg_message_v1_message_proto_msgTypes,
+	}.Build()
+	File_pkg_message_v1_message_proto = out.File
+	file_pkg_message_v1_message_proto_goTypes = nil
+	file_pkg_message_v1_message_proto_depIdxs = nil
+}